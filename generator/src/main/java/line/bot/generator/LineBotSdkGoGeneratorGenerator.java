@@ -7,6 +7,7 @@ import java.util.Map;
 import org.openapitools.codegen.CodegenModel;
 import org.openapitools.codegen.CodegenProperty;
 import org.openapitools.codegen.CodegenType;
+import org.openapitools.codegen.SupportingFile;
 import org.openapitools.codegen.languages.AbstractGoCodegen;
 import org.openapitools.codegen.model.ModelMap;
 import org.openapitools.codegen.model.ModelsMap;
@@ -51,11 +52,17 @@ public class LineBotSdkGoGeneratorGenerator extends AbstractGoCodegen {
     public void processOpts() {
         super.processOpts();
         supportingFiles.clear();
+        // webhook.yml has no operations, so there is no client that can return an APIError.
+        if (!"webhook".equals(packageName)) {
+            supportingFiles.add(new SupportingFile("line-bot-sdk-go-generator/apiError.pebble", "", "api_error.go"));
+        }
     }
 
     @Override
     public Map<String, ModelsMap> postProcessAllModels(Map<String, ModelsMap> objs) {
         Map<String, ModelsMap> stringModelsMapMap = super.postProcessAllModels(objs);
+        // APIError decodes the response body into ErrorResponse, but not every spec defines it.
+        additionalProperties.put("hasErrorResponse", stringModelsMapMap.containsKey("ErrorResponse"));
         HashSet<String> discriminators = new HashSet<>();
         for (ModelsMap modelsMap : stringModelsMapMap.values()) {
             for (ModelMap modelMap : modelsMap.getModels()) {
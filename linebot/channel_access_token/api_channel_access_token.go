@@ -31,12 +31,16 @@ import (
 	"path"
 
 	"github.com/line/line-bot-sdk-go/v8/linebot"
+	"github.com/line/line-bot-sdk-go/v8/linebot/ratelimit"
+	"github.com/line/line-bot-sdk-go/v8/linebot/retry"
 )
 
 type ChannelAccessTokenAPI struct {
-	httpClient *http.Client
-	endpoint   *url.URL
-	ctx        context.Context
+	httpClient  *http.Client
+	endpoint    *url.URL
+	ctx         context.Context
+	retryPolicy *retry.Policy
+	rateLimiter *ratelimit.Limiter
 }
 
 // ChannelAccessTokenAPIOption type
@@ -64,19 +68,47 @@ func NewChannelAccessTokenAPI(options ...ChannelAccessTokenAPIOption) (*ChannelA
 }
 
 // WithContext method
+// The context is stored on the client and shared by every call made through it.
+// To use a different context for each call, use the methods with the Ctx suffix instead.
 func (call *ChannelAccessTokenAPI) WithContext(ctx context.Context) *ChannelAccessTokenAPI {
 	call.ctx = ctx
 	return call
 }
 
 func (client *ChannelAccessTokenAPI) Do(req *http.Request) (*http.Response, error) {
-	req.Header.Set("User-Agent", "LINE-BotSDK-Go/"+linebot.GetVersion())
 	if client.ctx != nil {
 		req = req.WithContext(client.ctx)
 	}
+	return client.do(req)
+}
+
+// do sends req with the context it already carries.
+func (client *ChannelAccessTokenAPI) do(req *http.Request) (*http.Response, error) {
+	if client.retryPolicy != nil {
+		return client.retryPolicy.Do(req, client.send)
+	}
+	return client.send(req)
+}
+
+// send sends a single attempt of req.
+func (client *ChannelAccessTokenAPI) send(req *http.Request) (*http.Response, error) {
+	if client.rateLimiter != nil {
+		if err := client.rateLimiter.Wait(req); err != nil {
+			return nil, err
+		}
+	}
+	req.Header.Set("User-Agent", "LINE-BotSDK-Go/"+linebot.GetVersion())
 	return client.httpClient.Do(req)
 }
 
+// requestContext returns the context set by WithContext, or context.Background if there is none.
+func (client *ChannelAccessTokenAPI) requestContext() context.Context {
+	if client.ctx != nil {
+		return client.ctx
+	}
+	return context.Background()
+}
+
 func (client *ChannelAccessTokenAPI) Url(endpointPath string) string {
 	newPath := path.Join(client.endpoint.Path, endpointPath)
 	u := *client.endpoint
@@ -92,6 +124,25 @@ func WithHTTPClient(c *http.Client) ChannelAccessTokenAPIOption {
 	}
 }
 
+// WithRetryPolicy function
+// Failed requests are retried according to policy. See the retry package for which requests are retried.
+func WithRetryPolicy(policy *retry.Policy) ChannelAccessTokenAPIOption {
+	return func(client *ChannelAccessTokenAPI) error {
+		client.retryPolicy = policy
+		return nil
+	}
+}
+
+// WithRateLimiter function
+// Every request, including retries, waits for limiter before it is sent.
+// Share one limiter between the clients of the same channel.
+func WithRateLimiter(limiter *ratelimit.Limiter) ChannelAccessTokenAPIOption {
+	return func(client *ChannelAccessTokenAPI) error {
+		client.rateLimiter = limiter
+		return nil
+	}
+}
+
 // WithEndpoint function
 func WithEndpoint(endpoint string) ChannelAccessTokenAPIOption {
 	return func(client *ChannelAccessTokenAPI) error {
@@ -128,6 +179,24 @@ func (client *ChannelAccessTokenAPI) GetsAllValidChannelAccessTokenKeyIds(
 	return body, error
 }
 
+// GetsAllValidChannelAccessTokenKeyIdsCtx
+// The same as GetsAllValidChannelAccessTokenKeyIds, but the request uses ctx instead of the context set by WithContext.
+func (client *ChannelAccessTokenAPI) GetsAllValidChannelAccessTokenKeyIdsCtx(
+	ctx context.Context,
+	clientAssertionType string,
+
+	clientAssertion string,
+
+) (*ChannelAccessTokenKeyIdsResponse, error) {
+	_, body, error := client.GetsAllValidChannelAccessTokenKeyIdsWithHttpInfoCtx(
+		ctx,
+		clientAssertionType,
+
+		clientAssertion,
+	)
+	return body, error
+}
+
 // GetsAllValidChannelAccessTokenKeyIds
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -143,10 +212,27 @@ func (client *ChannelAccessTokenAPI) GetsAllValidChannelAccessTokenKeyIdsWithHtt
 
 	clientAssertion string,
 
+) (*http.Response, *ChannelAccessTokenKeyIdsResponse, error) {
+	return client.GetsAllValidChannelAccessTokenKeyIdsWithHttpInfoCtx(
+		client.requestContext(),
+		clientAssertionType,
+
+		clientAssertion,
+	)
+}
+
+// GetsAllValidChannelAccessTokenKeyIdsWithHttpInfoCtx
+// The same as GetsAllValidChannelAccessTokenKeyIdsWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *ChannelAccessTokenAPI) GetsAllValidChannelAccessTokenKeyIdsWithHttpInfoCtx(
+	ctx context.Context,
+	clientAssertionType string,
+
+	clientAssertion string,
+
 ) (*http.Response, *ChannelAccessTokenKeyIdsResponse, error) {
 	path := "/oauth2/v2.1/tokens/kid"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -157,7 +243,7 @@ func (client *ChannelAccessTokenAPI) GetsAllValidChannelAccessTokenKeyIdsWithHtt
 
 	req.URL.RawQuery = query.Encode()
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -170,7 +256,7 @@ func (client *ChannelAccessTokenAPI) GetsAllValidChannelAccessTokenKeyIdsWithHtt
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -213,6 +299,28 @@ func (client *ChannelAccessTokenAPI) IssueChannelToken(
 	return body, error
 }
 
+// IssueChannelTokenCtx
+// The same as IssueChannelToken, but the request uses ctx instead of the context set by WithContext.
+func (client *ChannelAccessTokenAPI) IssueChannelTokenCtx(
+	ctx context.Context,
+	grantType string,
+
+	clientId string,
+
+	clientSecret string,
+
+) (*IssueShortLivedChannelAccessTokenResponse, error) {
+	_, body, error := client.IssueChannelTokenWithHttpInfoCtx(
+		ctx,
+		grantType,
+
+		clientId,
+
+		clientSecret,
+	)
+	return body, error
+}
+
 // IssueChannelToken
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -231,6 +339,27 @@ func (client *ChannelAccessTokenAPI) IssueChannelTokenWithHttpInfo(
 
 	clientSecret string,
 
+) (*http.Response, *IssueShortLivedChannelAccessTokenResponse, error) {
+	return client.IssueChannelTokenWithHttpInfoCtx(
+		client.requestContext(),
+		grantType,
+
+		clientId,
+
+		clientSecret,
+	)
+}
+
+// IssueChannelTokenWithHttpInfoCtx
+// The same as IssueChannelTokenWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *ChannelAccessTokenAPI) IssueChannelTokenWithHttpInfoCtx(
+	ctx context.Context,
+	grantType string,
+
+	clientId string,
+
+	clientSecret string,
+
 ) (*http.Response, *IssueShortLivedChannelAccessTokenResponse, error) {
 	path := "/v2/oauth/accessToken"
 
@@ -242,13 +371,13 @@ func (client *ChannelAccessTokenAPI) IssueChannelTokenWithHttpInfo(
 	buf := vs.Encode()
 	body := bytes.NewBufferString(buf)
 
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), body)
 	if err != nil {
 		return nil, nil, err
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -261,7 +390,7 @@ func (client *ChannelAccessTokenAPI) IssueChannelTokenWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -304,6 +433,28 @@ func (client *ChannelAccessTokenAPI) IssueChannelTokenByJWT(
 	return body, error
 }
 
+// IssueChannelTokenByJWTCtx
+// The same as IssueChannelTokenByJWT, but the request uses ctx instead of the context set by WithContext.
+func (client *ChannelAccessTokenAPI) IssueChannelTokenByJWTCtx(
+	ctx context.Context,
+	grantType string,
+
+	clientAssertionType string,
+
+	clientAssertion string,
+
+) (*IssueChannelAccessTokenResponse, error) {
+	_, body, error := client.IssueChannelTokenByJWTWithHttpInfoCtx(
+		ctx,
+		grantType,
+
+		clientAssertionType,
+
+		clientAssertion,
+	)
+	return body, error
+}
+
 // IssueChannelTokenByJWT
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -322,6 +473,27 @@ func (client *ChannelAccessTokenAPI) IssueChannelTokenByJWTWithHttpInfo(
 
 	clientAssertion string,
 
+) (*http.Response, *IssueChannelAccessTokenResponse, error) {
+	return client.IssueChannelTokenByJWTWithHttpInfoCtx(
+		client.requestContext(),
+		grantType,
+
+		clientAssertionType,
+
+		clientAssertion,
+	)
+}
+
+// IssueChannelTokenByJWTWithHttpInfoCtx
+// The same as IssueChannelTokenByJWTWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *ChannelAccessTokenAPI) IssueChannelTokenByJWTWithHttpInfoCtx(
+	ctx context.Context,
+	grantType string,
+
+	clientAssertionType string,
+
+	clientAssertion string,
+
 ) (*http.Response, *IssueChannelAccessTokenResponse, error) {
 	path := "/oauth2/v2.1/token"
 
@@ -333,13 +505,13 @@ func (client *ChannelAccessTokenAPI) IssueChannelTokenByJWTWithHttpInfo(
 	buf := vs.Encode()
 	body := bytes.NewBufferString(buf)
 
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), body)
 	if err != nil {
 		return nil, nil, err
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -352,7 +524,7 @@ func (client *ChannelAccessTokenAPI) IssueChannelTokenByJWTWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -407,6 +579,38 @@ func (client *ChannelAccessTokenAPI) IssueStatelessChannelToken(
 	return body, error
 }
 
+// IssueStatelessChannelTokenCtx
+// The same as IssueStatelessChannelToken, but the request uses ctx instead of the context set by WithContext.
+//
+// Deprecated: Use IssueStatelessChannelTokenByJWTAssertionCtx or IssueStatelessChannelTokenByClientSecretCtx instead.
+func (client *ChannelAccessTokenAPI) IssueStatelessChannelTokenCtx(
+	ctx context.Context,
+	grantType string,
+
+	clientAssertionType string,
+
+	clientAssertion string,
+
+	clientId string,
+
+	clientSecret string,
+
+) (*IssueStatelessChannelAccessTokenResponse, error) {
+	_, body, error := client.IssueStatelessChannelTokenWithHttpInfoCtx(
+		ctx,
+		grantType,
+
+		clientAssertionType,
+
+		clientAssertion,
+
+		clientId,
+
+		clientSecret,
+	)
+	return body, error
+}
+
 // IssueStatelessChannelToken
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -433,6 +637,37 @@ func (client *ChannelAccessTokenAPI) IssueStatelessChannelTokenWithHttpInfo(
 
 	clientSecret string,
 
+) (*http.Response, *IssueStatelessChannelAccessTokenResponse, error) {
+	return client.IssueStatelessChannelTokenWithHttpInfoCtx(
+		client.requestContext(),
+		grantType,
+
+		clientAssertionType,
+
+		clientAssertion,
+
+		clientId,
+
+		clientSecret,
+	)
+}
+
+// IssueStatelessChannelTokenWithHttpInfoCtx
+// The same as IssueStatelessChannelTokenWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+//
+// Deprecated: Use IssueStatelessChannelTokenByJWTAssertionWithHttpInfoCtx or IssueStatelessChannelTokenByClientSecretWithHttpInfoCtx instead.
+func (client *ChannelAccessTokenAPI) IssueStatelessChannelTokenWithHttpInfoCtx(
+	ctx context.Context,
+	grantType string,
+
+	clientAssertionType string,
+
+	clientAssertion string,
+
+	clientId string,
+
+	clientSecret string,
+
 ) (*http.Response, *IssueStatelessChannelAccessTokenResponse, error) {
 	path := "/oauth2/v3/token"
 
@@ -455,13 +690,13 @@ func (client *ChannelAccessTokenAPI) IssueStatelessChannelTokenWithHttpInfo(
 	buf := vs.Encode()
 	body := bytes.NewBufferString(buf)
 
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), body)
 	if err != nil {
 		return nil, nil, err
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -474,7 +709,7 @@ func (client *ChannelAccessTokenAPI) IssueStatelessChannelTokenWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -507,6 +742,20 @@ func (client *ChannelAccessTokenAPI) RevokeChannelToken(
 	return body, error
 }
 
+// RevokeChannelTokenCtx
+// The same as RevokeChannelToken, but the request uses ctx instead of the context set by WithContext.
+func (client *ChannelAccessTokenAPI) RevokeChannelTokenCtx(
+	ctx context.Context,
+	accessToken string,
+
+) (struct{}, error) {
+	_, body, error := client.RevokeChannelTokenWithHttpInfoCtx(
+		ctx,
+		accessToken,
+	)
+	return body, error
+}
+
 // RevokeChannelToken
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -519,6 +768,19 @@ func (client *ChannelAccessTokenAPI) RevokeChannelTokenWithHttpInfo(
 
 	accessToken string,
 
+) (*http.Response, struct{}, error) {
+	return client.RevokeChannelTokenWithHttpInfoCtx(
+		client.requestContext(),
+		accessToken,
+	)
+}
+
+// RevokeChannelTokenWithHttpInfoCtx
+// The same as RevokeChannelTokenWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *ChannelAccessTokenAPI) RevokeChannelTokenWithHttpInfoCtx(
+	ctx context.Context,
+	accessToken string,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/oauth/revoke"
 
@@ -528,13 +790,13 @@ func (client *ChannelAccessTokenAPI) RevokeChannelTokenWithHttpInfo(
 	buf := vs.Encode()
 	body := bytes.NewBufferString(buf)
 
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), body)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -547,7 +809,7 @@ func (client *ChannelAccessTokenAPI) RevokeChannelTokenWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -585,6 +847,28 @@ func (client *ChannelAccessTokenAPI) RevokeChannelTokenByJWT(
 	return body, error
 }
 
+// RevokeChannelTokenByJWTCtx
+// The same as RevokeChannelTokenByJWT, but the request uses ctx instead of the context set by WithContext.
+func (client *ChannelAccessTokenAPI) RevokeChannelTokenByJWTCtx(
+	ctx context.Context,
+	clientId string,
+
+	clientSecret string,
+
+	accessToken string,
+
+) (struct{}, error) {
+	_, body, error := client.RevokeChannelTokenByJWTWithHttpInfoCtx(
+		ctx,
+		clientId,
+
+		clientSecret,
+
+		accessToken,
+	)
+	return body, error
+}
+
 // RevokeChannelTokenByJWT
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -603,6 +887,27 @@ func (client *ChannelAccessTokenAPI) RevokeChannelTokenByJWTWithHttpInfo(
 
 	accessToken string,
 
+) (*http.Response, struct{}, error) {
+	return client.RevokeChannelTokenByJWTWithHttpInfoCtx(
+		client.requestContext(),
+		clientId,
+
+		clientSecret,
+
+		accessToken,
+	)
+}
+
+// RevokeChannelTokenByJWTWithHttpInfoCtx
+// The same as RevokeChannelTokenByJWTWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *ChannelAccessTokenAPI) RevokeChannelTokenByJWTWithHttpInfoCtx(
+	ctx context.Context,
+	clientId string,
+
+	clientSecret string,
+
+	accessToken string,
+
 ) (*http.Response, struct{}, error) {
 	path := "/oauth2/v2.1/revoke"
 
@@ -614,13 +919,13 @@ func (client *ChannelAccessTokenAPI) RevokeChannelTokenByJWTWithHttpInfo(
 	buf := vs.Encode()
 	body := bytes.NewBufferString(buf)
 
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), body)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -633,7 +938,7 @@ func (client *ChannelAccessTokenAPI) RevokeChannelTokenByJWTWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -661,6 +966,20 @@ func (client *ChannelAccessTokenAPI) VerifyChannelToken(
 	return body, error
 }
 
+// VerifyChannelTokenCtx
+// The same as VerifyChannelToken, but the request uses ctx instead of the context set by WithContext.
+func (client *ChannelAccessTokenAPI) VerifyChannelTokenCtx(
+	ctx context.Context,
+	accessToken string,
+
+) (*VerifyChannelAccessTokenResponse, error) {
+	_, body, error := client.VerifyChannelTokenWithHttpInfoCtx(
+		ctx,
+		accessToken,
+	)
+	return body, error
+}
+
 // VerifyChannelToken
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -673,6 +992,19 @@ func (client *ChannelAccessTokenAPI) VerifyChannelTokenWithHttpInfo(
 
 	accessToken string,
 
+) (*http.Response, *VerifyChannelAccessTokenResponse, error) {
+	return client.VerifyChannelTokenWithHttpInfoCtx(
+		client.requestContext(),
+		accessToken,
+	)
+}
+
+// VerifyChannelTokenWithHttpInfoCtx
+// The same as VerifyChannelTokenWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *ChannelAccessTokenAPI) VerifyChannelTokenWithHttpInfoCtx(
+	ctx context.Context,
+	accessToken string,
+
 ) (*http.Response, *VerifyChannelAccessTokenResponse, error) {
 	path := "/v2/oauth/verify"
 
@@ -682,13 +1014,13 @@ func (client *ChannelAccessTokenAPI) VerifyChannelTokenWithHttpInfo(
 	buf := vs.Encode()
 	body := bytes.NewBufferString(buf)
 
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), body)
 	if err != nil {
 		return nil, nil, err
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -701,7 +1033,7 @@ func (client *ChannelAccessTokenAPI) VerifyChannelTokenWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -734,6 +1066,20 @@ func (client *ChannelAccessTokenAPI) VerifyChannelTokenByJWT(
 	return body, error
 }
 
+// VerifyChannelTokenByJWTCtx
+// The same as VerifyChannelTokenByJWT, but the request uses ctx instead of the context set by WithContext.
+func (client *ChannelAccessTokenAPI) VerifyChannelTokenByJWTCtx(
+	ctx context.Context,
+	accessToken string,
+
+) (*VerifyChannelAccessTokenResponse, error) {
+	_, body, error := client.VerifyChannelTokenByJWTWithHttpInfoCtx(
+		ctx,
+		accessToken,
+	)
+	return body, error
+}
+
 // VerifyChannelTokenByJWT
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -746,10 +1092,23 @@ func (client *ChannelAccessTokenAPI) VerifyChannelTokenByJWTWithHttpInfo(
 
 	accessToken string,
 
+) (*http.Response, *VerifyChannelAccessTokenResponse, error) {
+	return client.VerifyChannelTokenByJWTWithHttpInfoCtx(
+		client.requestContext(),
+		accessToken,
+	)
+}
+
+// VerifyChannelTokenByJWTWithHttpInfoCtx
+// The same as VerifyChannelTokenByJWTWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *ChannelAccessTokenAPI) VerifyChannelTokenByJWTWithHttpInfoCtx(
+	ctx context.Context,
+	accessToken string,
+
 ) (*http.Response, *VerifyChannelAccessTokenResponse, error) {
 	path := "/oauth2/v2.1/verify"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -759,7 +1118,7 @@ func (client *ChannelAccessTokenAPI) VerifyChannelTokenByJWTWithHttpInfo(
 
 	req.URL.RawQuery = query.Encode()
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -772,7 +1131,7 @@ func (client *ChannelAccessTokenAPI) VerifyChannelTokenByJWTWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -804,6 +1163,22 @@ func (client *ChannelAccessTokenAPI) IssueStatelessChannelTokenByJWTAssertion(
 	)
 }
 
+// IssueStatelessChannelTokenByJWTAssertionCtx
+// The same as IssueStatelessChannelTokenByJWTAssertion, but the request uses ctx instead of the context set by WithContext.
+func (client *ChannelAccessTokenAPI) IssueStatelessChannelTokenByJWTAssertionCtx(
+	ctx context.Context,
+	clientAssertion string,
+) (*IssueStatelessChannelAccessTokenResponse, error) {
+	return client.IssueStatelessChannelTokenCtx(
+		ctx,
+		"client_credentials",
+		"urn:ietf:params:oauth:client-assertion-type:jwt-bearer",
+		clientAssertion,
+		"",
+		"",
+	)
+}
+
 // IssueStatelessChannelTokenByJWTAssertionWithHttpInfo
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -823,6 +1198,22 @@ func (client *ChannelAccessTokenAPI) IssueStatelessChannelTokenByJWTAssertionWit
 	)
 }
 
+// IssueStatelessChannelTokenByJWTAssertionWithHttpInfoCtx
+// The same as IssueStatelessChannelTokenByJWTAssertionWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *ChannelAccessTokenAPI) IssueStatelessChannelTokenByJWTAssertionWithHttpInfoCtx(
+	ctx context.Context,
+	clientAssertion string,
+) (*http.Response, *IssueStatelessChannelAccessTokenResponse, error) {
+	return client.IssueStatelessChannelTokenWithHttpInfoCtx(
+		ctx,
+		"client_credentials",
+		"urn:ietf:params:oauth:client-assertion-type:jwt-bearer",
+		clientAssertion,
+		"",
+		"",
+	)
+}
+
 // IssueStatelessChannelTokenByClientSecret
 //
 // Issue a stateless channel access token by client secret.
@@ -843,6 +1234,23 @@ func (client *ChannelAccessTokenAPI) IssueStatelessChannelTokenByClientSecret(
 	)
 }
 
+// IssueStatelessChannelTokenByClientSecretCtx
+// The same as IssueStatelessChannelTokenByClientSecret, but the request uses ctx instead of the context set by WithContext.
+func (client *ChannelAccessTokenAPI) IssueStatelessChannelTokenByClientSecretCtx(
+	ctx context.Context,
+	clientId string,
+	clientSecret string,
+) (*IssueStatelessChannelAccessTokenResponse, error) {
+	return client.IssueStatelessChannelTokenCtx(
+		ctx,
+		"client_credentials",
+		"",
+		"",
+		clientId,
+		clientSecret,
+	)
+}
+
 // IssueStatelessChannelTokenByClientSecretWithHttpInfo
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -863,3 +1271,20 @@ func (client *ChannelAccessTokenAPI) IssueStatelessChannelTokenByClientSecretWit
 		clientSecret,
 	)
 }
+
+// IssueStatelessChannelTokenByClientSecretWithHttpInfoCtx
+// The same as IssueStatelessChannelTokenByClientSecretWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *ChannelAccessTokenAPI) IssueStatelessChannelTokenByClientSecretWithHttpInfoCtx(
+	ctx context.Context,
+	clientId string,
+	clientSecret string,
+) (*http.Response, *IssueStatelessChannelAccessTokenResponse, error) {
+	return client.IssueStatelessChannelTokenWithHttpInfoCtx(
+		ctx,
+		"client_credentials",
+		"",
+		"",
+		clientId,
+		clientSecret,
+	)
+}
@@ -0,0 +1,110 @@
+package channel_access_token
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	jwtAudience = "https://api.line.me/"
+
+	// jwtLifetime is the lifetime of a client assertion. LINE accepts up to 30 minutes.
+	jwtLifetime = 10 * time.Minute
+)
+
+// rsaPrivateJWK is an RSA private key in JSON Web Key format (RFC 7518 section 6.3).
+type rsaPrivateJWK struct {
+	Kty string `json:"kty"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	D   string `json:"d"`
+	P   string `json:"p"`
+	Q   string `json:"q"`
+}
+
+// ParseRSAPrivateJWK parses the private key of an Assertion Signing Key in JWK format.
+// https://developers.line.biz/en/docs/messaging-api/generate-json-web-token/#create-an-assertion-signing-key
+func ParseRSAPrivateJWK(data []byte) (*rsa.PrivateKey, error) {
+	var jwk rsaPrivateJWK
+	if err := json.Unmarshal(data, &jwk); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JWK: %w", err)
+	}
+	if jwk.Kty != "RSA" {
+		return nil, fmt.Errorf("unsupported key type: %q", jwk.Kty)
+	}
+
+	params := map[string]*big.Int{}
+	for name, value := range map[string]string{"n": jwk.N, "e": jwk.E, "d": jwk.D, "p": jwk.P, "q": jwk.Q} {
+		if value == "" {
+			return nil, fmt.Errorf("missing JWK parameter: %s", name)
+		}
+		b, err := base64.RawURLEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWK parameter %s: %w", name, err)
+		}
+		params[name] = new(big.Int).SetBytes(b)
+	}
+	if !params["e"].IsInt64() || params["e"].Int64() > 1<<31-1 {
+		return nil, errors.New("invalid JWK parameter e")
+	}
+
+	key := &rsa.PrivateKey{
+		PublicKey: rsa.PublicKey{
+			N: params["n"],
+			E: int(params["e"].Int64()),
+		},
+		D:      params["d"],
+		Primes: []*big.Int{params["p"], params["q"]},
+	}
+	if err := key.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid RSA private key: %w", err)
+	}
+	key.Precompute()
+	return key, nil
+}
+
+// CreateClientAssertion creates a JWT signed with key, to be passed as clientAssertion.
+// keyID is the kid returned when the public key was registered.
+// tokenExp is the lifetime of the channel access token to issue, up to 30 days.
+// Pass 0 to omit it, for example when issuing a stateless channel access token.
+// https://developers.line.biz/en/docs/messaging-api/generate-json-web-token/#generate-jwt
+func CreateClientAssertion(channelID, keyID string, key *rsa.PrivateKey, tokenExp time.Duration, now time.Time) (string, error) {
+	header := map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+		"kid": keyID,
+	}
+	payload := map[string]interface{}{
+		"iss": channelID,
+		"sub": channelID,
+		"aud": jwtAudience,
+		"exp": now.Add(jwtLifetime).Unix(),
+	}
+	if tokenExp > 0 {
+		payload["token_exp"] = int64(tokenExp / time.Second)
+	}
+
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(payloadJSON)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign JWT: %w", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
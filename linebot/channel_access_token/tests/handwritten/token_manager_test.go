@@ -0,0 +1,286 @@
+package tests
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/channel_access_token"
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+func generateJWK(t *testing.T) (*rsa.PrivateKey, []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc := func(i *big.Int) string {
+		return base64.RawURLEncoding.EncodeToString(i.Bytes())
+	}
+	jwk, err := json.Marshal(map[string]string{
+		"kty": "RSA",
+		"alg": "RS256",
+		"use": "sig",
+		"n":   enc(key.N),
+		"e":   enc(big.NewInt(int64(key.E))),
+		"d":   enc(key.D),
+		"p":   enc(key.Primes[0]),
+		"q":   enc(key.Primes[1]),
+		"dp":  enc(key.Precomputed.Dp),
+		"dq":  enc(key.Precomputed.Dq),
+		"qi":  enc(key.Precomputed.Qinv),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, jwk
+}
+
+func verifyJWT(t *testing.T, jwt string, key *rsa.PublicKey) (map[string]interface{}, map[string]interface{}) {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("invalid JWT: %s", jwt)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		t.Fatalf("invalid signature: %v", err)
+	}
+	decode := func(s string) map[string]interface{} {
+		b, err := base64.RawURLEncoding.DecodeString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := map[string]interface{}{}
+		if err := json.Unmarshal(b, &m); err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+	return decode(parts[0]), decode(parts[1])
+}
+
+func TestParseRSAPrivateJWK(t *testing.T) {
+	key, jwk := generateJWK(t)
+	parsed, err := channel_access_token.ParseRSAPrivateJWK(jwk)
+	if err != nil {
+		t.Fatalf("Failed to parse JWK: %v", err)
+	}
+	if !parsed.Equal(key) {
+		t.Errorf("parsed key does not match")
+	}
+
+	if _, err := channel_access_token.ParseRSAPrivateJWK([]byte(`{"kty":"EC"}`)); err == nil {
+		t.Errorf("EC key should be rejected")
+	}
+	if _, err := channel_access_token.ParseRSAPrivateJWK([]byte(`{"kty":"RSA","n":"AQAB"}`)); err == nil {
+		t.Errorf("public key should be rejected")
+	}
+}
+
+func TestCreateClientAssertion(t *testing.T) {
+	key, _ := generateJWK(t)
+	now := time.Unix(1700000000, 0)
+	jwt, err := channel_access_token.CreateClientAssertion("1234567890", "kid-1", key, 24*time.Hour, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, payload := verifyJWT(t, jwt, &key.PublicKey)
+	if header["alg"] != "RS256" || header["typ"] != "JWT" || header["kid"] != "kid-1" {
+		t.Errorf("header: %v", header)
+	}
+	if payload["iss"] != "1234567890" || payload["sub"] != "1234567890" || payload["aud"] != "https://api.line.me/" {
+		t.Errorf("payload: %v", payload)
+	}
+	if payload["exp"].(float64) != float64(now.Add(10*time.Minute).Unix()) {
+		t.Errorf("exp: %v", payload["exp"])
+	}
+	if payload["token_exp"].(float64) != 86400 {
+		t.Errorf("token_exp: %v", payload["token_exp"])
+	}
+
+	jwt, err = channel_access_token.CreateClientAssertion("1234567890", "kid-1", key, 0, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, payload := verifyJWT(t, jwt, &key.PublicKey); payload["token_exp"] != nil {
+		t.Errorf("token_exp should be omitted: %v", payload)
+	}
+}
+
+type fakeTokenServer struct {
+	t         *testing.T
+	key       *rsa.PublicKey
+	expiresIn int
+
+	mu      sync.Mutex
+	issued  int
+	revoked []string
+}
+
+func (s *fakeTokenServer) revokedTokens() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.revoked...)
+}
+
+func (s *fakeTokenServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		s.t.Fatal(err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.URL.Path {
+	case "/oauth2/v2.1/token":
+		verifyJWT(s.t, r.PostForm.Get("client_assertion"), s.key)
+		s.issued++
+		fmt.Fprintf(w, `{"access_token":"token-%d","expires_in":%d,"token_type":"Bearer","key_id":"key-%d"}`, s.issued, s.expiresIn, s.issued)
+	case "/oauth2/v2.1/revoke":
+		if r.PostForm.Get("client_secret") != "secret" {
+			s.t.Errorf("client_secret: %s", r.PostForm.Get("client_secret"))
+		}
+		s.revoked = append(s.revoked, r.PostForm.Get("access_token"))
+	case "/v2/bot/info":
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"userId":"U1","basicId":"@bot","displayName":"%s","chatMode":"bot","markAsReadMode":"auto"}`, r.Header.Get("Authorization"))
+	default:
+		s.t.Errorf("unexpected path: %s", r.URL.Path)
+	}
+}
+
+func TestTokenManager(t *testing.T) {
+	key, _ := generateJWK(t)
+	fake := &fakeTokenServer{t: t, key: &key.PublicKey, expiresIn: 3600}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client, err := channel_access_token.NewChannelAccessTokenAPI(
+		channel_access_token.WithEndpoint(server.URL),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	manager, err := channel_access_token.NewTokenManager(
+		client, "1234567890", "kid-1", key,
+		channel_access_token.WithChannelSecret("secret"),
+		channel_access_token.WithRefreshMargin(10*time.Minute),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create token manager: %v", err)
+	}
+
+	bot, err := messaging_api.NewMessagingApiAPI(
+		"",
+		messaging_api.WithEndpoint(server.URL),
+		messaging_api.WithTokenSource(manager),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		info, err := bot.GetBotInfo()
+		if err != nil {
+			t.Fatalf("Failed to get bot info: %v", err)
+		}
+		if info.DisplayName != "Bearer token-1" {
+			t.Errorf("Authorization: %s", info.DisplayName)
+		}
+	}
+	if fake.issued != 1 {
+		t.Errorf("token should be cached: issued %d times", fake.issued)
+	}
+
+	// Close revokes the current token
+	if err := manager.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if revoked := fake.revokedTokens(); len(revoked) != 1 || revoked[0] != "token-1" {
+		t.Errorf("revoked: %v", revoked)
+	}
+
+	// a token is replaced before it expires, and the old one is revoked
+	fake.expiresIn = 2
+	token, err := manager.Token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "token-2" {
+		t.Errorf("token: %s", token)
+	}
+	time.Sleep(1100 * time.Millisecond)
+	token, err = manager.Token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "token-3" {
+		t.Errorf("token should be refreshed after half of its lifetime: %s", token)
+	}
+	// the old token stays valid for the requests in flight
+	if revoked := fake.revokedTokens(); len(revoked) != 1 {
+		t.Errorf("the old token should not be revoked at once: %v", revoked)
+	}
+	// Close revokes the old token, which is still waiting, and the current one
+	if err := manager.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if revoked := strings.Join(fake.revokedTokens(), ","); revoked != "token-1,token-3,token-2" {
+		t.Errorf("revoked: %v", revoked)
+	}
+}
+
+func TestTokenManagerRevokesAfterDelay(t *testing.T) {
+	key, _ := generateJWK(t)
+	fake := &fakeTokenServer{t: t, key: &key.PublicKey, expiresIn: 2}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client, err := channel_access_token.NewChannelAccessTokenAPI(channel_access_token.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	manager, err := channel_access_token.NewTokenManager(
+		client, "1234567890", "kid-1", key,
+		channel_access_token.WithChannelSecret("secret"),
+		channel_access_token.WithRevokeDelay(10*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.Token(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	// The token is refreshed after half of its lifetime.
+	time.Sleep(time.Until(now.Add(1100 * time.Millisecond)))
+	if token, err := manager.Token(context.Background()); err != nil || token != "token-2" {
+		t.Fatalf("token: %s, %v", token, err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for len(fake.revokedTokens()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if revoked := fake.revokedTokens(); len(revoked) != 1 || revoked[0] != "token-1" {
+		t.Errorf("revoked: %v", revoked)
+	}
+}
+
+func TestNewAPIWithoutToken(t *testing.T) {
+	if _, err := messaging_api.NewMessagingApiAPI(""); err == nil {
+		t.Errorf("Expected an error for a missing channel access token")
+	}
+}
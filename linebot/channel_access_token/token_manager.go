@@ -0,0 +1,218 @@
+package channel_access_token
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"sync"
+	"time"
+)
+
+// TokenSource supplies channel access tokens.
+// Pass it to the WithTokenSource option of an API client instead of a static channel access token.
+type TokenSource interface {
+	// Token returns a valid channel access token.
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenManager is a TokenSource that issues channel access tokens by JWT
+// assertion, caches them, and issues a new one before the current one expires.
+//
+// By default it issues channel access tokens v2.1. When a channel secret is
+// given, the token that is replaced is revoked, so the channel does not run
+// out of valid tokens. It is revoked after a delay, in the background, so
+// that the requests still in flight with it do not fail.
+type TokenManager struct {
+	client        *ChannelAccessTokenAPI
+	channelID     string
+	keyID         string
+	key           *rsa.PrivateKey
+	channelSecret string
+	stateless     bool
+	tokenExp      time.Duration
+	refreshMargin time.Duration
+	revokeDelay   time.Duration
+	now           func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+	// retired holds the replaced tokens waiting to be revoked.
+	retired map[string]*time.Timer
+}
+
+// TokenManagerOption type
+type TokenManagerOption func(*TokenManager)
+
+// NewTokenManager returns a TokenManager for the channel.
+// keyID and key are the kid and the private key of the Assertion Signing Key.
+func NewTokenManager(client *ChannelAccessTokenAPI, channelID, keyID string, key *rsa.PrivateKey, options ...TokenManagerOption) (*TokenManager, error) {
+	if client == nil {
+		return nil, errors.New("missing channel access token client")
+	}
+	if channelID == "" {
+		return nil, errors.New("missing channel ID")
+	}
+	if keyID == "" || key == nil {
+		return nil, errors.New("missing assertion signing key")
+	}
+	m := &TokenManager{
+		client:        client,
+		channelID:     channelID,
+		keyID:         keyID,
+		key:           key,
+		tokenExp:      24 * time.Hour,
+		refreshMargin: 5 * time.Minute,
+		revokeDelay:   time.Minute,
+		now:           time.Now,
+		retired:       map[string]*time.Timer{},
+	}
+	for _, option := range options {
+		option(m)
+	}
+	return m, nil
+}
+
+// WithChannelSecret function
+// Tokens replaced by a new one, or released by Close, are revoked with the channel secret.
+func WithChannelSecret(channelSecret string) TokenManagerOption {
+	return func(m *TokenManager) {
+		m.channelSecret = channelSecret
+	}
+}
+
+// WithStatelessToken function
+// Issues stateless channel access tokens, which are valid for 15 minutes and cannot be revoked.
+func WithStatelessToken() TokenManagerOption {
+	return func(m *TokenManager) {
+		m.stateless = true
+	}
+}
+
+// WithTokenExp function
+// Sets the lifetime of the channel access tokens v2.1 to issue, up to 30 days. The default is 24 hours.
+func WithTokenExp(d time.Duration) TokenManagerOption {
+	return func(m *TokenManager) {
+		m.tokenExp = d
+	}
+}
+
+// WithRefreshMargin function
+// A new token is issued when the current one expires within d. The default is 5 minutes.
+// The margin never exceeds half of the token lifetime.
+func WithRefreshMargin(d time.Duration) TokenManagerOption {
+	return func(m *TokenManager) {
+		m.refreshMargin = d
+	}
+}
+
+// WithRevokeDelay function
+// Sets how long after it is replaced a token is revoked. The default is 1 minute.
+// The delay never exceeds the refresh margin, after which the token expires anyway.
+func WithRevokeDelay(d time.Duration) TokenManagerOption {
+	return func(m *TokenManager) {
+		m.revokeDelay = d
+	}
+}
+
+// Token method
+func (m *TokenManager) Token(ctx context.Context) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if m.token != "" && now.Before(m.expiresAt) {
+		return m.token, nil
+	}
+
+	token, expiresIn, err := m.issue(ctx, now)
+	if err != nil {
+		return "", err
+	}
+	margin := m.refreshMargin
+	if half := expiresIn / 2; margin > half {
+		margin = half
+	}
+	if m.token != "" && m.revocable() {
+		m.retire(m.token, min(m.revokeDelay, margin))
+	}
+	m.token = token
+	m.expiresAt = now.Add(expiresIn - margin)
+	return token, nil
+}
+
+// Close revokes the current token and the replaced tokens not revoked yet, if they can be revoked.
+func (m *TokenManager) Close(ctx context.Context) error {
+	m.mu.Lock()
+	var tokens []string
+	if m.token != "" {
+		tokens = append(tokens, m.token)
+	}
+	for token, timer := range m.retired {
+		if timer.Stop() {
+			tokens = append(tokens, token)
+		}
+	}
+	m.retired = map[string]*time.Timer{}
+	m.token = ""
+	m.expiresAt = time.Time{}
+	m.mu.Unlock()
+
+	var errs []error
+	for _, token := range tokens {
+		errs = append(errs, m.revoke(ctx, token))
+	}
+	return errors.Join(errs...)
+}
+
+// retire revokes token in the background after delay. It must be called with m.mu held.
+func (m *TokenManager) retire(token string, delay time.Duration) {
+	m.retired[token] = time.AfterFunc(delay, func() {
+		m.mu.Lock()
+		delete(m.retired, token)
+		m.mu.Unlock()
+		// The new token is already in use, so a failure to revoke the old one is not fatal.
+		_ = m.revoke(context.Background(), token)
+	})
+}
+
+func (m *TokenManager) issue(ctx context.Context, now time.Time) (string, time.Duration, error) {
+	if m.stateless {
+		assertion, err := CreateClientAssertion(m.channelID, m.keyID, m.key, 0, now)
+		if err != nil {
+			return "", 0, err
+		}
+		resp, err := m.client.IssueStatelessChannelTokenByJWTAssertionCtx(ctx, assertion)
+		if err != nil {
+			return "", 0, err
+		}
+		return resp.AccessToken, time.Duration(resp.ExpiresIn) * time.Second, nil
+	}
+
+	assertion, err := CreateClientAssertion(m.channelID, m.keyID, m.key, m.tokenExp, now)
+	if err != nil {
+		return "", 0, err
+	}
+	resp, err := m.client.IssueChannelTokenByJWTCtx(
+		ctx,
+		"client_credentials",
+		"urn:ietf:params:oauth:client-assertion-type:jwt-bearer",
+		assertion,
+	)
+	if err != nil {
+		return "", 0, err
+	}
+	return resp.AccessToken, time.Duration(resp.ExpiresIn) * time.Second, nil
+}
+
+func (m *TokenManager) revocable() bool {
+	return !m.stateless && m.channelSecret != ""
+}
+
+func (m *TokenManager) revoke(ctx context.Context, token string) error {
+	if !m.revocable() {
+		return nil
+	}
+	_, err := m.client.RevokeChannelTokenByJWTCtx(ctx, m.channelID, m.channelSecret, token)
+	return err
+}
@@ -0,0 +1,401 @@
+// Package content downloads the content of the images, videos, audios and
+// files sent by users.
+//
+// The content of a message is streamed to an io.Writer. A download that is cut
+// off is resumed with a range request, the content of videos and audios is
+// downloaded once the LINE Platform has prepared it, and the content of an
+// external content provider is fetched from its URL.
+//
+//	downloader := content.NewDownloader(blob)
+//	dispatcher.OnMessage(func(ctx context.Context, e *webhook.MessageEvent) error {
+//		c, err := downloader.DownloadFile(ctx, e.Message, filepath.Join(dir, e.WebhookEventId))
+//		if err != nil {
+//			return err
+//		}
+//		log.Printf("saved %d bytes of %s", c.Size, c.ContentType)
+//		return nil
+//	})
+package content
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+	"github.com/line/line-bot-sdk-go/v8/linebot/webhook"
+)
+
+const (
+	// DefaultPollInterval is the delay before the first poll of the preparation status.
+	DefaultPollInterval = time.Second
+	// DefaultMaxPollInterval caps the delay between the polls of the preparation status.
+	DefaultMaxPollInterval = 10 * time.Second
+	// DefaultTranscodingTimeout is how long the preparation of a video or an audio is waited for.
+	DefaultTranscodingTimeout = 5 * time.Minute
+	// DefaultMaxAttempts is the number of attempts to download the content, including the resumed ones.
+	DefaultMaxAttempts = 3
+)
+
+var (
+	// ErrTranscodingFailed is returned when the LINE Platform failed to prepare a video or an audio.
+	ErrTranscodingFailed = errors.New("content: the preparation of the content failed")
+	// ErrNoContent is returned for messages that have no content to download, such as text messages.
+	ErrNoContent = errors.New("content: the message has no content")
+)
+
+// Content describes the content downloaded.
+type Content struct {
+	MessageId string
+	// ContentType is the MIME type of the content. If the response does not
+	// tell it, it is detected from the data.
+	ContentType string
+	// Size is the number of bytes written.
+	Size int64
+	// URL is the URL the content was fetched from when it is provided by an
+	// external content provider, or empty when it is on the LINE Platform.
+	URL string
+}
+
+// Downloader downloads the content of messages.
+type Downloader struct {
+	client             *messaging_api.MessagingApiBlobAPI
+	httpClient         *http.Client
+	pollInterval       time.Duration
+	maxPollInterval    time.Duration
+	transcodingTimeout time.Duration
+	maxAttempts        int
+	sleep              func(ctx context.Context, d time.Duration) error
+}
+
+// DownloaderOption type
+type DownloaderOption func(*Downloader)
+
+// WithHTTPClient function
+// Sets the client used to fetch the content of external content providers. The default is http.DefaultClient.
+func WithHTTPClient(c *http.Client) DownloaderOption {
+	return func(d *Downloader) {
+		d.httpClient = c
+	}
+}
+
+// WithPollInterval function
+// The preparation status is polled after interval, then after twice as long each time, up to max.
+func WithPollInterval(interval, max time.Duration) DownloaderOption {
+	return func(d *Downloader) {
+		d.pollInterval = interval
+		d.maxPollInterval = max
+	}
+}
+
+// WithTranscodingTimeout function
+// Sets how long the preparation of a video or an audio is waited for. If timeout is zero, it is waited for until the context is done.
+func WithTranscodingTimeout(timeout time.Duration) DownloaderOption {
+	return func(d *Downloader) {
+		d.transcodingTimeout = timeout
+	}
+}
+
+// WithMaxAttempts function
+// Sets the number of attempts to download the content, including the first one.
+// Requests that fail are also retried by the retry policy of the client, if any;
+// the attempts counted here are the ones whose response was cut off.
+func WithMaxAttempts(n int) DownloaderOption {
+	return func(d *Downloader) {
+		d.maxAttempts = n
+	}
+}
+
+// NewDownloader function
+func NewDownloader(client *messaging_api.MessagingApiBlobAPI, options ...DownloaderOption) *Downloader {
+	d := &Downloader{
+		client:             client,
+		httpClient:         http.DefaultClient,
+		pollInterval:       DefaultPollInterval,
+		maxPollInterval:    DefaultMaxPollInterval,
+		transcodingTimeout: DefaultTranscodingTimeout,
+		maxAttempts:        DefaultMaxAttempts,
+		sleep:              sleep,
+	}
+	for _, option := range options {
+		option(d)
+	}
+	return d
+}
+
+// WaitForTranscoding method
+// Polls the preparation status of a video or an audio until it succeeds. It
+// returns an error wrapping ErrTranscodingFailed if the preparation failed.
+func (d *Downloader) WaitForTranscoding(ctx context.Context, messageId string) error {
+	ctx, cancel := d.withTranscodingTimeout(ctx)
+	defer cancel()
+	return d.waitForTranscoding(ctx, messageId)
+}
+
+// withTranscodingTimeout returns ctx with the transcoding timeout, if any.
+func (d *Downloader) withTranscodingTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if d.transcodingTimeout > 0 {
+		return context.WithTimeout(ctx, d.transcodingTimeout)
+	}
+	return ctx, func() {}
+}
+
+func (d *Downloader) waitForTranscoding(ctx context.Context, messageId string) error {
+	interval := d.pollInterval
+	for {
+		res, err := d.client.GetMessageContentTranscodingByMessageIdCtx(ctx, messageId)
+		if err != nil {
+			return err
+		}
+		switch res.Status {
+		case messaging_api.GetMessageContentTranscodingResponseSTATUS_SUCCEEDED:
+			return nil
+		case messaging_api.GetMessageContentTranscodingResponseSTATUS_FAILED:
+			return fmt.Errorf("%w: message %s", ErrTranscodingFailed, messageId)
+		}
+		if err := d.sleep(ctx, interval); err != nil {
+			return fmt.Errorf("content: waiting for the preparation of message %s: %w", messageId, err)
+		}
+		interval = min(interval*2, d.maxPollInterval)
+	}
+}
+
+// Download method
+// Streams the content of the message on the LINE Platform to w. If the
+// content is still being prepared, it waits for the preparation first.
+func (d *Downloader) Download(ctx context.Context, messageId string, w io.Writer) (*Content, error) {
+	c := &Content{MessageId: messageId}
+	err := d.fetch(ctx, w, c, func(offset int64) (*http.Response, error) {
+		return d.get(ctx, messageId, offset)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// DownloadMessage method
+// Streams the content of an image, video, audio or file message to w. The
+// content of an external content provider is fetched from its original
+// content URL. For other messages, it returns ErrNoContent.
+func (d *Downloader) DownloadMessage(ctx context.Context, message webhook.MessageContentInterface, w io.Writer) (*Content, error) {
+	var (
+		messageId string
+		provider  *webhook.ContentProvider
+		prepared  bool
+	)
+	if m, ok := as[webhook.ImageMessageContent](message); ok {
+		messageId, provider = m.Id, m.ContentProvider
+	} else if m, ok := as[webhook.VideoMessageContent](message); ok {
+		messageId, provider, prepared = m.Id, m.ContentProvider, true
+	} else if m, ok := as[webhook.AudioMessageContent](message); ok {
+		messageId, provider, prepared = m.Id, m.ContentProvider, true
+	} else if m, ok := as[webhook.FileMessageContent](message); ok {
+		messageId = m.Id
+	} else if message != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoContent, message.GetType())
+	} else {
+		return nil, ErrNoContent
+	}
+
+	if provider != nil && provider.Type == webhook.ContentProviderTYPE_EXTERNAL {
+		c := &Content{MessageId: messageId, URL: provider.OriginalContentUrl}
+		err := d.fetch(ctx, w, c, func(offset int64) (*http.Response, error) {
+			return d.getExternal(ctx, provider.OriginalContentUrl, offset)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+	if prepared {
+		if err := d.WaitForTranscoding(ctx, messageId); err != nil {
+			return nil, err
+		}
+	}
+	return d.Download(ctx, messageId, w)
+}
+
+// DownloadFile method
+// Saves the content of an image, video, audio or file message to the file at
+// path. The file is removed if the download fails.
+func (d *Downloader) DownloadFile(ctx context.Context, message webhook.MessageContentInterface, path string) (*Content, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	c, err := d.DownloadMessage(ctx, message, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return nil, err
+	}
+	return c, nil
+}
+
+// get requests the content of the message on the LINE Platform from offset,
+// waiting for its preparation while the LINE Platform responds with 202. The
+// content is requested again after the poll interval, which doubles up to its
+// maximum, and the whole wait is limited by the transcoding timeout.
+func (d *Downloader) get(ctx context.Context, messageId string, offset int64) (*http.Response, error) {
+	var waitCtx context.Context
+	interval := d.pollInterval
+	for {
+		// The response is read after get returns, so it is not limited by waitCtx.
+		res, err := d.client.GetMessageContentRangeCtx(ctx, messageId, offset)
+		if err != nil {
+			return nil, err
+		}
+		if res.StatusCode != http.StatusAccepted {
+			return res, nil
+		}
+		_ = res.Body.Close()
+		if waitCtx == nil {
+			var cancel context.CancelFunc
+			waitCtx, cancel = d.withTranscodingTimeout(ctx)
+			defer cancel()
+		}
+		if err := d.waitForTranscoding(waitCtx, messageId); err != nil {
+			return nil, err
+		}
+		if err := d.sleep(waitCtx, interval); err != nil {
+			return nil, fmt.Errorf("content: waiting for the preparation of message %s: %w", messageId, err)
+		}
+		interval = min(interval*2, d.maxPollInterval)
+	}
+}
+
+// getExternal requests the content at the URL of an external content provider from offset.
+func (d *Downloader) getExternal(ctx context.Context, contentUrl string, offset int64) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, contentUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	setRange(req, offset)
+	res, err := d.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode/100 != 2 {
+		_ = res.Body.Close()
+		return nil, fmt.Errorf("content: unexpected status code %d from %s", res.StatusCode, contentUrl)
+	}
+	return res, nil
+}
+
+// fetch streams the content to w. When the response is cut off, the rest is
+// requested from the offset reached, up to the maximum number of attempts.
+func (d *Downloader) fetch(ctx context.Context, w io.Writer, c *Content, get func(offset int64) (*http.Response, error)) error {
+	dst := &trackingWriter{w: w}
+	for attempt := 1; ; attempt++ {
+		res, err := get(dst.n)
+		if err != nil {
+			return err
+		}
+		err = d.copy(dst, res, c)
+		_ = res.Body.Close()
+		if err == nil {
+			c.Size = dst.n
+			return nil
+		}
+		if dst.err != nil || attempt >= d.maxAttempts || ctx.Err() != nil {
+			return err
+		}
+		if err := d.sleep(ctx, d.pollInterval); err != nil {
+			return err
+		}
+	}
+}
+
+// copy writes the body of res to dst, skipping what dst already has.
+func (d *Downloader) copy(dst *trackingWriter, res *http.Response, c *Content) error {
+	body := io.Reader(res.Body)
+	if dst.n > 0 {
+		if res.StatusCode == http.StatusPartialContent {
+			if start, ok := rangeStart(res.Header.Get("Content-Range")); !ok || start != dst.n {
+				return fmt.Errorf("content: got the range %q, want the content from byte %d", res.Header.Get("Content-Range"), dst.n)
+			}
+		} else if _, err := io.CopyN(io.Discard, body, dst.n); err != nil {
+			// The server ignored the range, so the content starts over.
+			return err
+		}
+	}
+	if c.ContentType == "" {
+		r := bufio.NewReaderSize(body, 512)
+		sniff, _ := r.Peek(512)
+		c.ContentType = res.Header.Get("Content-Type")
+		if c.ContentType == "" || c.ContentType == "application/octet-stream" {
+			c.ContentType = http.DetectContentType(sniff)
+		}
+		body = r
+	}
+	_, err := io.Copy(dst, body)
+	return err
+}
+
+// trackingWriter counts the bytes written, and keeps the error of the writer
+// apart from the errors of the response body.
+type trackingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (t *trackingWriter) Write(p []byte) (int, error) {
+	n, err := t.w.Write(p)
+	t.n += int64(n)
+	if err != nil {
+		t.err = err
+	}
+	return n, err
+}
+
+func setRange(req *http.Request, offset int64) {
+	if offset > 0 {
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	}
+}
+
+// rangeStart returns the first byte of a Content-Range header, such as "bytes 100-199/200".
+func rangeStart(contentRange string) (int64, bool) {
+	spec, ok := strings.CutPrefix(contentRange, "bytes ")
+	if !ok {
+		return 0, false
+	}
+	start, _, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(start, 10, 64)
+	return n, err == nil
+}
+
+func as[T any](v any) (*T, bool) {
+	switch t := v.(type) {
+	case T:
+		return &t, true
+	case *T:
+		return t, t != nil
+	}
+	return nil, false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
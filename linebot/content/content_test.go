@@ -0,0 +1,250 @@
+package content
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/linetest"
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+	"github.com/line/line-bot-sdk-go/v8/linebot/webhook"
+	"github.com/line/line-bot-sdk-go/v8/linebot/webhook/webhooktest"
+)
+
+var png = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{1}, 100)...)
+
+func newDownloader(t *testing.T, server *linetest.Server, options ...messaging_api.MessagingApiBlobAPIOption) *Downloader {
+	blob, err := messaging_api.NewMessagingApiBlobAPI("token", append([]messaging_api.MessagingApiBlobAPIOption{messaging_api.WithBlobEndpoint(server.URL)}, options...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := NewDownloader(blob, WithPollInterval(time.Second, 4*time.Second))
+	d.sleep = func(ctx context.Context, d time.Duration) error { return nil }
+	return d
+}
+
+func newServer(t *testing.T) *linetest.Server {
+	server := linetest.NewServer()
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDownload(t *testing.T) {
+	server := newServer(t)
+	server.SetMessageContent("1", linetest.MessageContent{ContentType: "application/octet-stream", Data: png})
+	d := newDownloader(t, server)
+
+	var buf bytes.Buffer
+	c, err := d.Download(context.Background(), "1", &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.MessageId != "1" || c.ContentType != "image/png" || c.Size != int64(len(png)) || !bytes.Equal(buf.Bytes(), png) {
+		t.Errorf("got %+v, want the PNG image", c)
+	}
+
+	if _, err := d.Download(context.Background(), "2", io.Discard); !messaging_api.IsNotFound(err) {
+		t.Errorf("got %v, want a 404 error", err)
+	}
+	event := webhooktest.Text(webhooktest.User("U1"), "hi")
+	if _, err := d.DownloadMessage(context.Background(), event.Message, io.Discard); !errors.Is(err, ErrNoContent) {
+		t.Errorf("got %v, want ErrNoContent", err)
+	}
+}
+
+func TestDownloadUsesCallContext(t *testing.T) {
+	server := newServer(t)
+	server.SetMessageContent("1", linetest.MessageContent{ContentType: "image/png", Data: png})
+	d := newDownloader(t, server)
+	d.client.WithContext(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := d.Download(ctx, "1", io.Discard); !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, want the context of the call canceled", err)
+	}
+}
+
+func TestWaitForTranscoding(t *testing.T) {
+	server := newServer(t)
+	event := webhooktest.Video(webhooktest.User("U1"), time.Minute)
+	video := event.Message.(*webhook.VideoMessageContent)
+	content := linetest.MessageContent{ContentType: "video/mp4", Data: []byte("video"), Transcoding: messaging_api.GetMessageContentTranscodingResponseSTATUS_PROCESSING}
+	server.SetMessageContent(video.Id, content)
+
+	d := newDownloader(t, server)
+	var intervals []time.Duration
+	d.sleep = func(ctx context.Context, interval time.Duration) error {
+		intervals = append(intervals, interval)
+		if len(intervals) == 4 {
+			content.Transcoding = messaging_api.GetMessageContentTranscodingResponseSTATUS_SUCCEEDED
+			server.SetMessageContent(video.Id, content)
+		}
+		return nil
+	}
+	var buf bytes.Buffer
+	c, err := d.DownloadMessage(context.Background(), event.Message, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.ContentType != "video/mp4" || buf.String() != "video" {
+		t.Errorf("got %+v %q, want the video", c, buf.String())
+	}
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second}
+	if len(intervals) != len(want) {
+		t.Fatalf("got intervals %v, want %v", intervals, want)
+	}
+	for i := range want {
+		if intervals[i] != want[i] {
+			t.Errorf("got intervals %v, want %v", intervals, want)
+		}
+	}
+
+	content.Transcoding = messaging_api.GetMessageContentTranscodingResponseSTATUS_FAILED
+	server.SetMessageContent(video.Id, content)
+	if _, err := d.DownloadMessage(context.Background(), event.Message, io.Discard); !errors.Is(err, ErrTranscodingFailed) {
+		t.Errorf("got %v, want ErrTranscodingFailed", err)
+	}
+}
+
+func TestWaitForTranscodingTimeout(t *testing.T) {
+	server := newServer(t)
+	server.SetMessageContent("1", linetest.MessageContent{Data: []byte("audio"), Transcoding: messaging_api.GetMessageContentTranscodingResponseSTATUS_PROCESSING})
+	d := newDownloader(t, server)
+	d.transcodingTimeout = time.Millisecond
+	d.sleep = sleep
+
+	// The content is requested while it is processing, so the preparation is waited for.
+	if _, err := d.Download(context.Background(), "1", io.Discard); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want the deadline exceeded", err)
+	}
+}
+
+// cutTransport cuts off the body of the first response after n bytes.
+type cutTransport struct {
+	n      int64
+	ranges []string
+}
+
+func (c *cutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.ranges = append(c.ranges, req.Header.Get("Range"))
+	res, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil || len(c.ranges) > 1 {
+		return res, err
+	}
+	res.Body = &cutBody{r: io.LimitReader(res.Body, c.n), c: res.Body}
+	return res, nil
+}
+
+type cutBody struct {
+	r io.Reader
+	c io.Closer
+}
+
+func (b *cutBody) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func (b *cutBody) Close() error {
+	return b.c.Close()
+}
+
+func TestResume(t *testing.T) {
+	server := newServer(t)
+	server.SetMessageContent("1", linetest.MessageContent{ContentType: "image/png", Data: png})
+	transport := &cutTransport{n: 10}
+	d := newDownloader(t, server, messaging_api.WithBlobHTTPClient(&http.Client{Transport: transport}))
+
+	var buf bytes.Buffer
+	c, err := d.Download(context.Background(), "1", &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Size != int64(len(png)) || !bytes.Equal(buf.Bytes(), png) {
+		t.Errorf("got %d bytes, want the whole image", c.Size)
+	}
+	if len(transport.ranges) != 2 || transport.ranges[0] != "" || transport.ranges[1] != "bytes=10-" {
+		t.Errorf("got ranges %q, want the rest requested from byte 10", transport.ranges)
+	}
+
+	transport = &cutTransport{n: 10}
+	d = newDownloader(t, server, messaging_api.WithBlobHTTPClient(&http.Client{Transport: transport}))
+	d.maxAttempts = 1
+	if _, err := d.Download(context.Background(), "1", io.Discard); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("got %v, want the download cut off", err)
+	}
+}
+
+func TestExternal(t *testing.T) {
+	external := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Error("the channel access token was sent to the external content provider")
+		}
+		http.ServeContent(w, r, "image.png", time.Time{}, bytes.NewReader(png))
+	}))
+	defer external.Close()
+
+	event := webhooktest.Message(webhooktest.User("U1"), &webhook.ImageMessageContent{
+		Id:              "1",
+		ContentProvider: &webhook.ContentProvider{Type: webhook.ContentProviderTYPE_EXTERNAL, OriginalContentUrl: external.URL + "/image.png"},
+	})
+	d := newDownloader(t, newServer(t))
+	path := filepath.Join(t.TempDir(), "image")
+	c, err := d.DownloadFile(context.Background(), event.Message, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.URL != external.URL+"/image.png" || c.ContentType != "image/png" {
+		t.Errorf("got %+v, want the external image", c)
+	}
+	if data, err := os.ReadFile(path); err != nil || !bytes.Equal(data, png) {
+		t.Errorf("got %d bytes, %v, want the image saved", len(data), err)
+	}
+
+	missing := webhooktest.Image(webhooktest.User("U1"))
+	if _, err := d.DownloadFile(context.Background(), missing.Message, path); !messaging_api.IsNotFound(err) {
+		t.Errorf("got %v, want a 404 error", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("got %v, want the file removed", err)
+	}
+}
+
+func TestWaitForContentTimeout(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/transcoding") {
+			w.Write([]byte(`{"status":"succeeded"}`))
+			return
+		}
+		// The content stays unavailable although its preparation succeeded.
+		requests.Add(1)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	blob, err := messaging_api.NewMessagingApiBlobAPI("token", messaging_api.WithBlobEndpoint(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := NewDownloader(blob, WithPollInterval(time.Millisecond, 4*time.Millisecond), WithTranscodingTimeout(20*time.Millisecond))
+
+	if _, err := d.Download(context.Background(), "1", io.Discard); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want the deadline exceeded", err)
+	}
+	if n := requests.Load(); n < 2 || n > 10 {
+		t.Errorf("got %d requests of the content, want a few, spaced by the poll interval", n)
+	}
+}
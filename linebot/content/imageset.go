@@ -0,0 +1,158 @@
+package content
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/webhook"
+)
+
+// ImageSet is a group of images sent together by a user. Each image of the
+// group arrives as its own message event, possibly in another webhook.
+type ImageSet struct {
+	// Id is the image set ID, or empty for an image sent alone.
+	Id string
+	// Images are the message events of the images, ordered by their index.
+	Images []*webhook.MessageEvent
+}
+
+// ImageSetCollector gathers the message events of the images of each image
+// set, until all of them have arrived.
+type ImageSetCollector struct {
+	mu   sync.Mutex
+	sets map[string]*pendingImageSet
+	// completed holds when each image set was completed, so that its
+	// redelivered images are not collected into a new set.
+	completed map[string]time.Time
+	ttl       time.Duration
+	nextSweep time.Time
+	now       func() time.Time
+}
+
+type pendingImageSet struct {
+	images    []*webhook.MessageEvent
+	received  int
+	createdAt time.Time
+}
+
+// completedRetention is how long the IDs of completed image sets are
+// remembered when the collector has no ttl.
+const completedRetention = time.Hour
+
+// NewImageSetCollector function
+// Image sets that are still incomplete ttl after their first image arrived are
+// forgotten. If ttl is zero, they are kept until complete. The IDs of complete
+// image sets are remembered for ttl, or for an hour if ttl is zero.
+func NewImageSetCollector(ttl time.Duration) *ImageSetCollector {
+	return &ImageSetCollector{
+		sets:      map[string]*pendingImageSet{},
+		completed: map[string]time.Time{},
+		ttl:       ttl,
+		now:       time.Now,
+	}
+}
+
+// Add method
+// Adds the event of an image message. When it completes its image set, it
+// returns the image set and true. An image sent alone is returned at once, as
+// a set of one image. Other events are ignored, and so are the images
+// redelivered while their set is pending or remembered as complete.
+//
+// Images without an index, sent by old versions of LINE, are ordered by arrival.
+func (c *ImageSetCollector) Add(event *webhook.MessageEvent) (*ImageSet, bool) {
+	image, ok := as[webhook.ImageMessageContent](event.Message)
+	if !ok {
+		return nil, false
+	}
+	if image.ImageSet == nil || image.ImageSet.Total <= 1 {
+		return &ImageSet{Images: []*webhook.MessageEvent{event}}, true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sweep()
+	id := image.ImageSet.Id
+	if _, ok := c.completed[id]; ok {
+		return nil, false
+	}
+	set, ok := c.sets[id]
+	if !ok {
+		set = &pendingImageSet{images: make([]*webhook.MessageEvent, image.ImageSet.Total), createdAt: c.now()}
+		c.sets[id] = set
+	}
+	for _, e := range set.images {
+		if e != nil && messageId(e) == image.Id {
+			return nil, false
+		}
+	}
+
+	slot := int(image.ImageSet.Index) - 1
+	if slot < 0 || slot >= len(set.images) || set.images[slot] != nil {
+		slot = -1
+		for i, e := range set.images {
+			if e == nil {
+				slot = i
+				break
+			}
+		}
+	}
+	if slot < 0 {
+		return nil, false
+	}
+	set.images[slot] = event
+	set.received++
+	if set.received < len(set.images) {
+		return nil, false
+	}
+	delete(c.sets, id)
+	c.completed[id] = c.now()
+	return &ImageSet{Id: id, Images: set.images}, true
+}
+
+func (c *ImageSetCollector) sweep() {
+	now := c.now()
+	if now.Before(c.nextSweep) {
+		return
+	}
+	retention := c.ttl
+	if retention <= 0 {
+		retention = completedRetention
+	}
+	for id, completedAt := range c.completed {
+		if !now.Before(completedAt.Add(retention)) {
+			delete(c.completed, id)
+		}
+	}
+	if c.ttl > 0 {
+		for id, set := range c.sets {
+			if !now.Before(set.createdAt.Add(c.ttl)) {
+				delete(c.sets, id)
+			}
+		}
+	}
+	c.nextSweep = now.Add(time.Minute)
+}
+
+// DownloadImageSet method
+// Streams the content of each image of the set, in order, to the writer
+// returned by w for its position in the set, starting at 0.
+func (d *Downloader) DownloadImageSet(ctx context.Context, set *ImageSet, w func(i int) io.Writer) ([]*Content, error) {
+	contents := make([]*Content, 0, len(set.Images))
+	for i, event := range set.Images {
+		c, err := d.DownloadMessage(ctx, event.Message, w(i))
+		if err != nil {
+			return contents, err
+		}
+		contents = append(contents, c)
+	}
+	return contents, nil
+}
+
+func messageId(event *webhook.MessageEvent) string {
+	if image, ok := as[webhook.ImageMessageContent](event.Message); ok {
+		return image.Id
+	}
+	return ""
+}
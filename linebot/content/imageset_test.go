@@ -0,0 +1,110 @@
+package content
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/linetest"
+	"github.com/line/line-bot-sdk-go/v8/linebot/webhook"
+	"github.com/line/line-bot-sdk-go/v8/linebot/webhook/webhooktest"
+)
+
+func image(id, setId string, index, total int32) *webhook.MessageEvent {
+	return webhooktest.Message(webhooktest.User("U1"), &webhook.ImageMessageContent{
+		Id:              id,
+		ContentProvider: &webhook.ContentProvider{Type: webhook.ContentProviderTYPE_LINE},
+		ImageSet:        &webhook.ImageSet{Id: setId, Index: index, Total: total},
+	})
+}
+
+func ids(set *ImageSet) string {
+	var s string
+	for _, e := range set.Images {
+		s += e.Message.(*webhook.ImageMessageContent).Id
+	}
+	return s
+}
+
+func TestImageSetCollector(t *testing.T) {
+	collector := NewImageSetCollector(time.Hour)
+
+	if set, ok := collector.Add(webhooktest.Image(webhooktest.User("U1"))); !ok || set.Id != "" || len(set.Images) != 1 {
+		t.Errorf("got %+v, want a set of one image", set)
+	}
+	if _, ok := collector.Add(webhooktest.Text(webhooktest.User("U1"), "hi")); ok {
+		t.Error("a text message was collected")
+	}
+
+	for _, event := range []*webhook.MessageEvent{image("3", "S", 3, 3), image("1", "S", 1, 3), image("1", "S", 1, 3)} {
+		if set, ok := collector.Add(event); ok {
+			t.Fatalf("got %+v before all the images arrived", set)
+		}
+	}
+	set, ok := collector.Add(image("2", "S", 2, 3))
+	if !ok || set.Id != "S" || ids(set) != "123" {
+		t.Fatalf("got %+v, want the images in order", set)
+	}
+
+	// Without indexes, the images are ordered by arrival.
+	collector.Add(image("b", "T", 0, 2))
+	if set, ok := collector.Add(image("a", "T", 0, 2)); !ok || ids(set) != "ba" {
+		t.Errorf("got %+v, want the images in the order of arrival", set)
+	}
+}
+
+func TestImageSetCollectorExpiry(t *testing.T) {
+	now := time.Now()
+	collector := NewImageSetCollector(time.Minute)
+	collector.now = func() time.Time { return now }
+
+	collector.Add(image("1", "S", 1, 2))
+	now = now.Add(time.Minute)
+	if set, ok := collector.Add(image("2", "S", 2, 2)); ok {
+		t.Errorf("got %+v, want the incomplete set forgotten", set)
+	}
+}
+
+func TestImageSetCollectorRedelivery(t *testing.T) {
+	now := time.Now()
+	collector := NewImageSetCollector(0)
+	collector.now = func() time.Time { return now }
+
+	collector.Add(image("1", "S", 1, 2))
+	if _, ok := collector.Add(image("2", "S", 2, 2)); !ok {
+		t.Fatal("the set was not completed")
+	}
+	// An image redelivered after its set completed does not start a new set.
+	collector.Add(webhooktest.Redelivered(image("1", "S", 1, 2)))
+	if len(collector.sets) != 0 {
+		t.Errorf("got %d pending sets, want none", len(collector.sets))
+	}
+
+	now = now.Add(completedRetention)
+	collector.Add(image("3", "T", 1, 2))
+	if _, ok := collector.completed["S"]; ok {
+		t.Error("the completed set is remembered forever")
+	}
+}
+
+func TestDownloadImageSet(t *testing.T) {
+	server := newServer(t)
+	server.SetMessageContent("1", linetest.MessageContent{ContentType: "image/jpeg", Data: []byte("one")})
+	server.SetMessageContent("2", linetest.MessageContent{ContentType: "image/jpeg", Data: []byte("two")})
+	d := newDownloader(t, server)
+
+	collector := NewImageSetCollector(0)
+	collector.Add(image("2", "S", 2, 2))
+	set, _ := collector.Add(image("1", "S", 1, 2))
+
+	bufs := make([]bytes.Buffer, len(set.Images))
+	contents, err := d.DownloadImageSet(context.Background(), set, func(i int) io.Writer { return &bufs[i] })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contents) != 2 || contents[0].MessageId != "1" || bufs[0].String() != "one" || bufs[1].String() != "two" {
+		t.Errorf("got %+v, want both images in order", contents)
+	}
+}
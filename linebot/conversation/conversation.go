@@ -0,0 +1,437 @@
+// Package conversation runs multi-turn dialogs, such as taking an order,
+// as state machines declared with states and transitions.
+//
+// A Machine keeps a Session per chat in a SessionStore. For each event, the
+// transitions of the current state are tried in order, and the first one
+// whose trigger matches moves the conversation to another state, whose Enter
+// function usually replies with a prompt. The messages are replied with the
+// reply token of the event.
+//
+//	machine, err := conversation.NewMachine(bot, conversation.NewMemorySessionStore(24*time.Hour), []conversation.State{
+//		{Name: "idle", Transitions: []conversation.Transition{{On: conversation.Text(`(?i)^order$`), To: "address"}}},
+//		{Name: "address", Enter: prompt("Where should we deliver?"), Timeout: 10 * time.Minute,
+//			Transitions: []conversation.Transition{{On: conversation.Text(`.+`), To: "confirm", Do: saveAddress}}},
+//		{Name: "confirm", Enter: prompt("Confirm your order?"), Choices: []conversation.Choice{
+//			{Label: "Yes", To: "idle"}, {Label: "No", To: "idle"},
+//		}},
+//	})
+//	dispatcher.Fallback(machine.Handle)
+package conversation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+	"github.com/line/line-bot-sdk-go/v8/linebot/postback"
+	"github.com/line/line-bot-sdk-go/v8/linebot/webhook"
+)
+
+// MaxReplyMessages is the maximum number of messages of a reply.
+const MaxReplyMessages = 5
+
+// Context is the event being handled, with the session of its chat.
+type Context struct {
+	Event   webhook.EventInterface
+	Session *Session
+	// Text is the text of a text message event, or "".
+	Text string
+	// Matches are the submatches of the pattern of the Text trigger that matched.
+	Matches []string
+	// Postback is the data of a postback event, or nil.
+	Postback *postback.Request
+
+	replies []messaging_api.MessageInterface
+	next    string
+}
+
+// Reply adds messages to the reply to the event.
+func (c *Context) Reply(messages ...messaging_api.MessageInterface) {
+	c.replies = append(c.replies, messages...)
+}
+
+// Goto moves the conversation to the state instead of the target of the transition.
+func (c *Context) Goto(state string) {
+	c.next = state
+}
+
+// Trigger reports whether an event fires a transition.
+type Trigger func(c *Context) bool
+
+// Text function
+// Returns a Trigger for the text messages matching the regular expression,
+// whose submatches are set in Context.Matches. It panics if pattern is not valid.
+func Text(pattern string) Trigger {
+	re := regexp.MustCompile(pattern)
+	return func(c *Context) bool {
+		if c.Text == "" {
+			return false
+		}
+		c.Matches = re.FindStringSubmatch(c.Text)
+		return c.Matches != nil
+	}
+}
+
+// Postback function
+// Returns a Trigger for the postback events whose data has the action, as parsed by the postback package.
+func Postback(action string) Trigger {
+	return func(c *Context) bool {
+		return c.Postback != nil && c.Postback.Action == action
+	}
+}
+
+// Any is a Trigger that matches every event.
+func Any(c *Context) bool {
+	return true
+}
+
+// Transition moves the conversation to another state when its trigger matches.
+type Transition struct {
+	On Trigger
+	// To is the state to move to. If it is "", the conversation stays in the
+	// current state without entering it again.
+	To string
+	// Do is called before moving, for example to store the text of the event
+	// in Session.Data. If it returns an error, the conversation does not move.
+	Do func(ctx context.Context, c *Context) error
+}
+
+// Choice is an answer offered as a quick reply button.
+// Selecting it sends a postback event that moves the conversation to To.
+type Choice struct {
+	Label string
+	// Data is the postback data of the button. The default is the label.
+	Data string
+	To   string
+}
+
+func (c Choice) data() string {
+	if c.Data != "" {
+		return c.Data
+	}
+	return c.Label
+}
+
+// State is a step of a conversation.
+type State struct {
+	Name string
+	// Enter is called when the conversation enters the state, usually to reply with a prompt.
+	Enter func(ctx context.Context, c *Context) error
+	// Choices are set as the quick reply of the last message replied by Enter.
+	// They are tried before the transitions.
+	Choices     []Choice
+	Transitions []Transition
+	// Otherwise is called for the events that no choice or transition
+	// matches. If it is nil, these events are ignored.
+	Otherwise func(ctx context.Context, c *Context) error
+	// Timeout is how long the conversation can wait in the state. When an
+	// event comes later, the conversation first enters TimeoutTo, or the
+	// initial state if it is "", and the event is handled from there.
+	Timeout   time.Duration
+	TimeoutTo string
+}
+
+// Machine runs the conversations of the chats.
+//
+// The first state is the initial one. A chat without a session is in the
+// initial state, and moving back to it ends the conversation and deletes its
+// session.
+type Machine struct {
+	client  *messaging_api.MessagingApiAPI
+	store   SessionStore
+	states  map[string]*State
+	initial string
+	key     func(webhook.SourceInterface) string
+	now     func() time.Time
+}
+
+// MachineOption type
+type MachineOption func(*Machine)
+
+// WithSessionKey function
+// Sets the function that returns the key of the session of a chat. The
+// default is webhook.GetSourceId, so that all the members of a group share
+// one conversation. Events whose key is "" are ignored.
+func WithSessionKey(key func(webhook.SourceInterface) string) MachineOption {
+	return func(m *Machine) {
+		m.key = key
+	}
+}
+
+// WithClock function
+// Sets the function that returns the current time, used for the timeouts.
+func WithClock(now func() time.Time) MachineOption {
+	return func(m *Machine) {
+		m.now = now
+	}
+}
+
+// NewMachine function
+// Returns an error if the names of the states are not unique, or if a
+// transition, a choice or a timeout moves to an unknown state.
+func NewMachine(client *messaging_api.MessagingApiAPI, store SessionStore, states []State, options ...MachineOption) (*Machine, error) {
+	if len(states) == 0 {
+		return nil, errors.New("conversation: no states")
+	}
+	m := &Machine{
+		client:  client,
+		store:   store,
+		states:  map[string]*State{},
+		initial: states[0].Name,
+		key:     webhook.GetSourceId,
+		now:     time.Now,
+	}
+	for _, option := range options {
+		option(m)
+	}
+	states = slices.Clone(states)
+	for i := range states {
+		if _, ok := m.states[states[i].Name]; ok {
+			return nil, fmt.Errorf("conversation: duplicate state %q", states[i].Name)
+		}
+		m.states[states[i].Name] = &states[i]
+	}
+	var errs []error
+	target := func(state, to, what string) {
+		if _, ok := m.states[to]; !ok && to != "" {
+			errs = append(errs, fmt.Errorf("conversation: state %q: %s moves to unknown state %q", state, what, to))
+		}
+	}
+	for _, s := range states {
+		for i, t := range s.Transitions {
+			if t.On == nil {
+				errs = append(errs, fmt.Errorf("conversation: state %q: transitions[%d] has no trigger", s.Name, i))
+			}
+			target(s.Name, t.To, fmt.Sprintf("transitions[%d]", i))
+		}
+		for i, c := range s.Choices {
+			if c.To == "" {
+				errs = append(errs, fmt.Errorf("conversation: state %q: choices[%d] has no target", s.Name, i))
+			}
+			target(s.Name, c.To, fmt.Sprintf("choices[%d]", i))
+		}
+		target(s.Name, s.TimeoutTo, "timeout")
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Handle method
+// Moves the conversation of the chat of the event, saves its session, and
+// replies with the messages added by the functions called. It is a
+// webhook.EventHandler.
+//
+// The session is saved before the reply is sent, so that the reply is not
+// sent for a move that was lost. If the reply fails, the conversation has
+// still moved, and the error of the reply is returned.
+func (m *Machine) Handle(ctx context.Context, event webhook.EventInterface) error {
+	source := webhook.GetSource(event)
+	if source == nil {
+		return nil
+	}
+	key := m.key(source)
+	if key == "" {
+		return nil
+	}
+	session, err := m.store.Load(ctx, key)
+	if err != nil {
+		return err
+	}
+	now := m.now()
+	if session == nil {
+		session = &Session{Key: key, State: m.initial, UpdatedAt: now}
+	}
+	if session.Data == nil {
+		session.Data = map[string]string{}
+	}
+	c := &Context{Event: event, Session: session}
+	if e, ok := as[webhook.MessageEvent](event); ok {
+		if text, ok := as[webhook.TextMessageContent](e.Message); ok {
+			c.Text = text.Text
+		}
+	} else if e, ok := as[webhook.PostbackEvent](event); ok && e.Postback != nil {
+		// Data that the postback package cannot parse can still match a choice.
+		if c.Postback, err = postback.Parse(e.Postback.Data); err != nil {
+			c.Postback = &postback.Request{Data: e.Postback.Data}
+		}
+	}
+
+	state, err := m.state(session.State)
+	if err != nil {
+		return err
+	}
+	if state.Timeout > 0 && !now.Before(session.UpdatedAt.Add(state.Timeout)) {
+		to := state.TimeoutTo
+		if to == "" {
+			to = m.initial
+		}
+		if err := m.enter(ctx, c, to); err != nil {
+			return err
+		}
+		if state, err = m.state(session.State); err != nil {
+			return err
+		}
+	}
+
+	if err := m.fire(ctx, c, state); err != nil {
+		return err
+	}
+
+	if session.State == m.initial {
+		err = m.store.Delete(ctx, key)
+	} else {
+		err = m.store.Save(ctx, session)
+	}
+	if err != nil {
+		return err
+	}
+	return m.reply(ctx, event, c.replies)
+}
+
+func (m *Machine) state(name string) (*State, error) {
+	state, ok := m.states[name]
+	if !ok {
+		return nil, fmt.Errorf("conversation: unknown state %q", name)
+	}
+	return state, nil
+}
+
+// fire runs the first choice or transition of the state that matches the event.
+func (m *Machine) fire(ctx context.Context, c *Context, state *State) error {
+	if c.Postback != nil {
+		for _, choice := range state.Choices {
+			if c.Postback.Data == choice.data() {
+				return m.enter(ctx, c, choice.To)
+			}
+		}
+	}
+	for _, t := range state.Transitions {
+		if !t.On(c) {
+			continue
+		}
+		c.next = t.To
+		if t.Do != nil {
+			if err := t.Do(ctx, c); err != nil {
+				return err
+			}
+		}
+		if c.next == "" {
+			return nil
+		}
+		return m.enter(ctx, c, c.next)
+	}
+	if state.Otherwise == nil {
+		return nil
+	}
+	c.next = ""
+	if err := state.Otherwise(ctx, c); err != nil {
+		return err
+	}
+	if c.next == "" {
+		return nil
+	}
+	return m.enter(ctx, c, c.next)
+}
+
+// enter moves the conversation to the state and calls its Enter function.
+func (m *Machine) enter(ctx context.Context, c *Context, name string) error {
+	state, err := m.state(name)
+	if err != nil {
+		return err
+	}
+	c.Session.State = name
+	c.Session.UpdatedAt = m.now()
+	if name == m.initial {
+		clear(c.Session.Data)
+	}
+	if state.Enter == nil {
+		return nil
+	}
+	prompt := len(c.replies)
+	if err := state.Enter(ctx, c); err != nil {
+		return err
+	}
+	if len(state.Choices) > 0 && len(c.replies) > prompt {
+		last := len(c.replies) - 1
+		c.replies[last] = withQuickReply(c.replies[last], quickReply(state.Choices))
+	}
+	return nil
+}
+
+func (m *Machine) reply(ctx context.Context, event webhook.EventInterface, messages []messaging_api.MessageInterface) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	if len(messages) > MaxReplyMessages {
+		return fmt.Errorf("conversation: %d messages to reply, at most %d can be replied", len(messages), MaxReplyMessages)
+	}
+	replyToken := webhook.GetReplyToken(event)
+	if replyToken == "" {
+		return errors.New("conversation: the event has no reply token")
+	}
+	_, err := m.client.ReplyMessageCtx(ctx, &messaging_api.ReplyMessageRequest{
+		ReplyToken: replyToken,
+		Messages:   messages,
+	})
+	return err
+}
+
+func quickReply(choices []Choice) *messaging_api.QuickReply {
+	items := make([]messaging_api.QuickReplyItem, len(choices))
+	for i, c := range choices {
+		items[i] = messaging_api.QuickReplyItem{
+			Type: "action",
+			Action: &messaging_api.PostbackAction{
+				Label:       c.Label,
+				Data:        c.data(),
+				DisplayText: c.Label,
+			},
+		}
+	}
+	return &messaging_api.QuickReply{Items: items}
+}
+
+// withQuickReply returns a copy of the message with the quick reply. The copy
+// is a pointer, since the generated message types are used both as values
+// and as pointers. A message without a QuickReply field is returned as is.
+func withQuickReply(message messaging_api.MessageInterface, quickReply *messaging_api.QuickReply) messaging_api.MessageInterface {
+	v := reflect.ValueOf(message)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return message
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return message
+	}
+	copied := reflect.New(v.Type())
+	copied.Elem().Set(v)
+	field := copied.Elem().FieldByName("QuickReply")
+	if !field.IsValid() || field.Type() != reflect.TypeOf(quickReply) {
+		return message
+	}
+	field.Set(reflect.ValueOf(quickReply))
+	if m, ok := copied.Interface().(messaging_api.MessageInterface); ok {
+		return m
+	}
+	return message
+}
+
+// as returns v as a *T if it is a T or a non-nil *T. The generated types are used both ways.
+func as[T any](v any) (*T, bool) {
+	switch t := v.(type) {
+	case T:
+		return &t, true
+	case *T:
+		return t, t != nil
+	}
+	return nil, false
+}
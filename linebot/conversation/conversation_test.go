@@ -0,0 +1,217 @@
+package conversation
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/linetest"
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+	"github.com/line/line-bot-sdk-go/v8/linebot/webhook"
+	"github.com/line/line-bot-sdk-go/v8/linebot/webhook/webhooktest"
+)
+
+func prompt(text string) func(context.Context, *Context) error {
+	return func(ctx context.Context, c *Context) error {
+		c.Reply(messaging_api.TextMessage{Text: text})
+		return nil
+	}
+}
+
+func orderStates() []State {
+	return []State{
+		{
+			Name: "idle",
+			Transitions: []Transition{{On: Text(`(?i)^order (\w+)$`), To: "address", Do: func(ctx context.Context, c *Context) error {
+				c.Session.Data["item"] = c.Matches[1]
+				return nil
+			}}},
+		},
+		{
+			Name:      "address",
+			Enter:     prompt("Where should we deliver?"),
+			Timeout:   10 * time.Minute,
+			TimeoutTo: "expired",
+			Transitions: []Transition{
+				{On: Text(`^cancel$`), To: "idle"},
+				{On: Text(`.+`), To: "confirm", Do: func(ctx context.Context, c *Context) error {
+					c.Session.Data["address"] = c.Text
+					return nil
+				}},
+			},
+			Otherwise: prompt("Please send the address as text."),
+		},
+		{
+			Name: "confirm",
+			Enter: func(ctx context.Context, c *Context) error {
+				c.Reply(messaging_api.TextMessage{Text: "Deliver " + c.Session.Data["item"] + " to " + c.Session.Data["address"] + "?"})
+				return nil
+			},
+			Choices: []Choice{{Label: "Yes", To: "done"}, {Label: "No", To: "idle"}},
+		},
+		{Name: "done", Enter: prompt("Thank you!"), Transitions: []Transition{{On: Any, To: "idle"}}},
+		{Name: "expired", Enter: prompt("The order timed out."), Transitions: []Transition{{On: Any, To: "idle"}}},
+	}
+}
+
+type harness struct {
+	t       *testing.T
+	server  *linetest.Server
+	machine *Machine
+	store   *MemorySessionStore
+	now     time.Time
+}
+
+func newHarness(t *testing.T) *harness {
+	h := &harness{t: t, server: linetest.NewServer(), store: NewMemorySessionStore(time.Hour), now: time.Now()}
+	t.Cleanup(h.server.Close)
+	bot, err := messaging_api.NewMessagingApiAPI("token", messaging_api.WithEndpoint(h.server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.machine, err = NewMachine(bot, h.store, orderStates(), WithClock(func() time.Time { return h.now }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+// send handles the event and returns the texts replied to it.
+func (h *harness) send(event webhook.EventInterface) []string {
+	h.t.Helper()
+	h.server.AddReplyToken(webhook.GetReplyToken(event), "U1")
+	h.server.ClearSent()
+	if err := h.machine.Handle(context.Background(), event); err != nil {
+		h.t.Fatal(err)
+	}
+	var texts []string
+	for _, m := range h.server.SentTo("U1") {
+		texts = append(texts, m.(messaging_api.TextMessage).Text)
+	}
+	return texts
+}
+
+func (h *harness) state() string {
+	session, err := h.store.Load(context.Background(), "U1")
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	if session == nil {
+		return ""
+	}
+	return session.State
+}
+
+func TestConversation(t *testing.T) {
+	h := newHarness(t)
+	user := webhooktest.User("U1")
+
+	if got := h.send(webhooktest.Text(user, "hello")); got != nil || h.state() != "" {
+		t.Fatalf("got %v in %q, want no reply and no session", got, h.state())
+	}
+	if got := h.send(webhooktest.Text(user, "order pizza")); strings.Join(got, "|") != "Where should we deliver?" || h.state() != "address" {
+		t.Fatalf("got %v in %q", got, h.state())
+	}
+	if got := h.send(webhooktest.Sticker(user, "1", "1")); strings.Join(got, "|") != "Please send the address as text." || h.state() != "address" {
+		t.Fatalf("got %v in %q", got, h.state())
+	}
+	if got := h.send(webhooktest.Text(user, "1-2-3 Tokyo")); strings.Join(got, "|") != "Deliver pizza to 1-2-3 Tokyo?" || h.state() != "confirm" {
+		t.Fatalf("got %v in %q", got, h.state())
+	}
+	sent := h.server.Sent()
+	quickReply := sent[len(sent)-1].Messages[0].(messaging_api.TextMessage).QuickReply
+	if quickReply == nil || len(quickReply.Items) != 2 || quickReply.Items[0].Action.(messaging_api.PostbackAction).Data != "Yes" {
+		t.Fatalf("unexpected quick reply: %+v", quickReply)
+	}
+
+	// Text does not match a choice, and the state has no transitions.
+	if got := h.send(webhooktest.Text(user, "Yes")); got != nil || h.state() != "confirm" {
+		t.Fatalf("got %v in %q", got, h.state())
+	}
+	if got := h.send(webhooktest.Postback(user, "Yes", nil)); strings.Join(got, "|") != "Thank you!" || h.state() != "done" {
+		t.Fatalf("got %v in %q", got, h.state())
+	}
+	if got := h.send(webhooktest.Text(user, "bye")); got != nil || h.state() != "" {
+		t.Fatalf("got %v in %q, want the session to end", got, h.state())
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	h := newHarness(t)
+	user := webhooktest.User("U1")
+
+	h.send(webhooktest.Text(user, "order tea"))
+	h.now = h.now.Add(10 * time.Minute)
+	if got := h.send(webhooktest.Text(user, "somewhere")); strings.Join(got, "|") != "The order timed out." || h.state() != "" {
+		t.Fatalf("got %v in %q, want the timeout to end the order", got, h.state())
+	}
+
+	// The events that do not move the conversation do not delay the timeout.
+	h.send(webhooktest.Text(user, "order tea"))
+	h.now = h.now.Add(6 * time.Minute)
+	h.send(webhooktest.Sticker(user, "1", "1"))
+	h.now = h.now.Add(4 * time.Minute)
+	if got := h.send(webhooktest.Text(user, "somewhere")); strings.Join(got, "|") != "The order timed out." {
+		t.Fatalf("got %v in %q, want the timeout counted from entering the state", got, h.state())
+	}
+
+	h.send(webhooktest.Text(user, "order tea"))
+	h.now = h.now.Add(5 * time.Minute)
+	if got := h.send(webhooktest.Text(user, "cancel")); got != nil || h.state() != "" {
+		t.Fatalf("got %v in %q, want the order canceled", got, h.state())
+	}
+}
+
+func TestSessionKey(t *testing.T) {
+	h := newHarness(t)
+	group := webhooktest.Group("C1", "U1")
+	event := webhooktest.Text(group, "order tea")
+	h.server.AddReplyToken(event.ReplyToken, "C1")
+	if err := h.machine.Handle(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+	if session, _ := h.store.Load(context.Background(), "C1"); session == nil || session.State != "address" || session.Data["item"] != "tea" {
+		t.Errorf("got %+v, want the session of the group", session)
+	}
+}
+
+func TestNewMachine(t *testing.T) {
+	_, err := NewMachine(nil, NewMemorySessionStore(0), []State{
+		{Name: "a", Transitions: []Transition{{On: Any, To: "b"}, {To: "a"}}},
+		{Name: "c", Choices: []Choice{{Label: "x"}}, TimeoutTo: "d"},
+	})
+	for _, want := range []string{
+		`state "a": transitions[0] moves to unknown state "b"`,
+		`state "a": transitions[1] has no trigger`,
+		`state "c": choices[0] has no target`,
+		`state "c": timeout moves to unknown state "d"`,
+	} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("got %v, want %s", err, want)
+		}
+	}
+	if _, err := NewMachine(nil, NewMemorySessionStore(0), []State{{Name: "a"}, {Name: "a"}}); err == nil {
+		t.Error("expected an error for duplicate states")
+	}
+}
+
+func TestMemorySessionStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	store := NewMemorySessionStore(time.Hour)
+	store.now = func() time.Time { return now }
+
+	session := &Session{Key: "U1", State: "a", Data: map[string]string{"k": "v"}, UpdatedAt: now}
+	if err := store.Save(ctx, session); err != nil {
+		t.Fatal(err)
+	}
+	session.Data["k"] = "changed"
+	if loaded, _ := store.Load(ctx, "U1"); loaded == nil || loaded.Data["k"] != "v" {
+		t.Errorf("got %+v, want a copy of the saved session", loaded)
+	}
+	now = now.Add(time.Hour)
+	if loaded, _ := store.Load(ctx, "U1"); loaded != nil {
+		t.Errorf("got %+v, want the session expired", loaded)
+	}
+}
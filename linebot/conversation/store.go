@@ -0,0 +1,111 @@
+package conversation
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+)
+
+// Session is the state of the conversation with a chat.
+type Session struct {
+	// Key identifies the chat, by default its user, group or room ID.
+	Key string `json:"key"`
+	// State is the name of the current state.
+	State string `json:"state"`
+	// Data holds the values collected during the conversation, such as an address.
+	Data map[string]string `json:"data,omitempty"`
+	// UpdatedAt is when the conversation last entered a state, used for the
+	// timeouts of states. The events that do not move the conversation leave
+	// it unchanged.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (s *Session) clone() *Session {
+	c := *s
+	c.Data = maps.Clone(s.Data)
+	if c.Data == nil {
+		c.Data = map[string]string{}
+	}
+	return &c
+}
+
+// SessionStore keeps the sessions of the conversations.
+//
+// A Machine loads, saves and deletes the session of a chat while it handles
+// an event of the chat, and expects the events of a chat to be handled one at
+// a time, as the WorkerPool of the webhook package does.
+type SessionStore interface {
+	// Load returns the session of the chat, or nil if there is none.
+	Load(ctx context.Context, key string) (*Session, error)
+	// Save creates or replaces the session of its chat.
+	Save(ctx context.Context, session *Session) error
+	// Delete deletes the session of the chat, if any.
+	Delete(ctx context.Context, key string) error
+}
+
+// MemorySessionStore is a SessionStore that keeps the sessions in memory.
+// It only knows the sessions of the current process.
+type MemorySessionStore struct {
+	mu        sync.Mutex
+	sessions  map[string]*Session
+	ttl       time.Duration
+	nextSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemorySessionStore function
+// Sessions whose conversation has not moved for ttl are forgotten. If ttl is zero, they are kept until deleted.
+func NewMemorySessionStore(ttl time.Duration) *MemorySessionStore {
+	return &MemorySessionStore{
+		sessions: map[string]*Session{},
+		ttl:      ttl,
+		now:      time.Now,
+	}
+}
+
+// Load method
+func (s *MemorySessionStore) Load(ctx context.Context, key string) (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep()
+	session, ok := s.sessions[key]
+	if !ok || s.expired(session) {
+		return nil, nil
+	}
+	return session.clone(), nil
+}
+
+// Save method
+func (s *MemorySessionStore) Save(ctx context.Context, session *Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep()
+	s.sessions[session.Key] = session.clone()
+	return nil
+}
+
+// Delete method
+func (s *MemorySessionStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, key)
+	return nil
+}
+
+func (s *MemorySessionStore) expired(session *Session) bool {
+	return s.ttl > 0 && !s.now().Before(session.UpdatedAt.Add(s.ttl))
+}
+
+func (s *MemorySessionStore) sweep() {
+	now := s.now()
+	if s.ttl <= 0 || now.Before(s.nextSweep) {
+		return
+	}
+	for key, session := range s.sessions {
+		if s.expired(session) {
+			delete(s.sessions, key)
+		}
+	}
+	s.nextSweep = now.Add(time.Minute)
+}
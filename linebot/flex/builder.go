@@ -0,0 +1,189 @@
+// Package flex builds and validates Flex Message containers.
+//
+// The builders are chained constructors for the messaging_api Flex types:
+//
+//	bubble, err := flex.Bubble().
+//		Body(flex.VBox(
+//			flex.Text("hi").Bold(),
+//			flex.Button(&messaging_api.UriAction{Label: "Open", Uri: "https://example.com"}),
+//		)).
+//		Build()
+//
+// Build validates the result with Validate, so that mistakes are reported
+// locally with the path of the offending property instead of as a 400
+// response from the LINE Platform. Enumerated properties take plain strings,
+// such as "bold" or "lg", which Validate checks.
+//
+// Unlike the JSON format, the Go types always send the flex property of
+// components, so the builders set it to the default of the parent box
+// layout when it is not set explicitly: 1 in horizontal and baseline boxes,
+// and 0 in vertical boxes.
+package flex
+
+import (
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+// Container is a Flex container under construction: a bubble or a carousel.
+type Container interface {
+	// Build returns the container, or ValidationErrors if it is invalid.
+	Build() (messaging_api.FlexContainerInterface, error)
+}
+
+// Component is a Flex component under construction.
+type Component interface {
+	// build returns the component to be placed in a box of layout, or in a bubble block if layout is "".
+	build(layout messaging_api.FlexBoxLAYOUT) messaging_api.FlexComponentInterface
+}
+
+// Message returns a Flex Message with the container, or ValidationErrors if it is invalid.
+func Message(altText string, container Container) (*messaging_api.FlexMessage, error) {
+	contents, err := container.Build()
+	if err != nil {
+		return nil, err
+	}
+	return &messaging_api.FlexMessage{
+		AltText:  altText,
+		Contents: contents,
+	}, nil
+}
+
+// defaultFlex returns the flex of a component in a box of layout.
+func defaultFlex(flex *int32, layout messaging_api.FlexBoxLAYOUT) int32 {
+	if flex != nil {
+		return *flex
+	}
+	if layout == messaging_api.FlexBoxLAYOUT_HORIZONTAL || layout == messaging_api.FlexBoxLAYOUT_BASELINE {
+		return 1
+	}
+	return 0
+}
+
+// BubbleBuilder builds a bubble.
+type BubbleBuilder struct {
+	bubble messaging_api.FlexBubble
+	header *BoxBuilder
+	hero   Component
+	body   *BoxBuilder
+	footer *BoxBuilder
+	each   string
+	cond   string
+}
+
+// Bubble function
+func Bubble() *BubbleBuilder {
+	return &BubbleBuilder{}
+}
+
+// Header method
+func (b *BubbleBuilder) Header(box *BoxBuilder) *BubbleBuilder {
+	b.header = box
+	return b
+}
+
+// Hero method
+// The hero is a box, an image or a video.
+func (b *BubbleBuilder) Hero(c Component) *BubbleBuilder {
+	b.hero = c
+	return b
+}
+
+// Body method
+func (b *BubbleBuilder) Body(box *BoxBuilder) *BubbleBuilder {
+	b.body = box
+	return b
+}
+
+// Footer method
+func (b *BubbleBuilder) Footer(box *BoxBuilder) *BubbleBuilder {
+	b.footer = box
+	return b
+}
+
+// Size method
+// size is one of nano, micro, deca, hecto, kilo, mega and giga.
+func (b *BubbleBuilder) Size(size string) *BubbleBuilder {
+	b.bubble.Size = messaging_api.FlexBubbleSIZE(size)
+	return b
+}
+
+// RTL method
+// Lays out the bubble from right to left.
+func (b *BubbleBuilder) RTL() *BubbleBuilder {
+	b.bubble.Direction = messaging_api.FlexBubbleDIRECTION_RTL
+	return b
+}
+
+// Styles method
+func (b *BubbleBuilder) Styles(styles *messaging_api.FlexBubbleStyles) *BubbleBuilder {
+	b.bubble.Styles = styles
+	return b
+}
+
+// Action method
+func (b *BubbleBuilder) Action(action messaging_api.ActionInterface) *BubbleBuilder {
+	b.bubble.Action = action
+	return b
+}
+
+func (b *BubbleBuilder) build() messaging_api.FlexBubble {
+	bubble := b.bubble
+	if b.header != nil {
+		bubble.Header = b.header.buildBox("")
+	}
+	if b.hero != nil {
+		bubble.Hero = b.hero.build("")
+	}
+	if b.body != nil {
+		bubble.Body = b.body.buildBox("")
+	}
+	if b.footer != nil {
+		bubble.Footer = b.footer.buildBox("")
+	}
+	return bubble
+}
+
+// Build method
+func (b *BubbleBuilder) Build() (messaging_api.FlexContainerInterface, error) {
+	if b.each != "" || b.cond != "" {
+		return nil, ValidationErrors{{Message: "Each and If are only allowed in templates"}}
+	}
+	bubble := b.build()
+	if err := Validate(&bubble); err != nil {
+		return nil, err
+	}
+	return &bubble, nil
+}
+
+// CarouselBuilder builds a carousel.
+type CarouselBuilder struct {
+	bubbles []*BubbleBuilder
+}
+
+// Carousel function
+func Carousel(bubbles ...*BubbleBuilder) *CarouselBuilder {
+	return &CarouselBuilder{bubbles: bubbles}
+}
+
+// Add method
+func (c *CarouselBuilder) Add(bubbles ...*BubbleBuilder) *CarouselBuilder {
+	c.bubbles = append(c.bubbles, bubbles...)
+	return c
+}
+
+// Build method
+func (c *CarouselBuilder) Build() (messaging_api.FlexContainerInterface, error) {
+	carousel := &messaging_api.FlexCarousel{
+		Contents: make([]messaging_api.FlexBubble, len(c.bubbles)),
+	}
+	for i, bubble := range c.bubbles {
+		if bubble.each != "" || bubble.cond != "" {
+			return nil, ValidationErrors{{Property: index("contents", i), Message: "Each and If are only allowed in templates"}}
+		}
+		carousel.Contents[i] = bubble.build()
+	}
+	if err := Validate(carousel); err != nil {
+		return nil, err
+	}
+	return carousel, nil
+}
@@ -0,0 +1,147 @@
+package flex
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+func TestBubble(t *testing.T) {
+	container, err := Bubble().
+		Size("kilo").
+		Hero(Image("https://example.com/hero.png").Size("full").AspectRatio("20:13").Cover()).
+		Body(VBox(
+			Text("hi").Bold().Size("xl"),
+			HBox(
+				Text("Price").Color("#aaaaaa"),
+				Text("$10").Flex(2).Align("end"),
+			).Spacing("sm").Margin("lg"),
+			BaselineBox(
+				Icon("https://example.com/star.png").Size("sm"),
+				Text("4.0").Spans(Span("4").Bold(), Span(".0")),
+			),
+		)).
+		Footer(VBox(
+			Button(&messaging_api.UriAction{Label: "Open", Uri: "https://example.com"}).Primary().Height("sm"),
+		)).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bubble := container.(*messaging_api.FlexBubble)
+	title := bubble.Body.Contents[0].(*messaging_api.FlexText)
+	if title.Text != "hi" || title.Weight != messaging_api.FlexTextWEIGHT_BOLD || title.Flex != 0 {
+		t.Errorf("unexpected title: %+v", title)
+	}
+	row := bubble.Body.Contents[1].(*messaging_api.FlexBox)
+	if row.Layout != messaging_api.FlexBoxLAYOUT_HORIZONTAL {
+		t.Errorf("unexpected layout: %s", row.Layout)
+	}
+	if label := row.Contents[0].(*messaging_api.FlexText); label.Flex != 1 {
+		t.Errorf("texts in horizontal boxes should default to flex 1, got %d", label.Flex)
+	}
+	if price := row.Contents[1].(*messaging_api.FlexText); price.Flex != 2 {
+		t.Errorf("explicit flex should be kept, got %d", price.Flex)
+	}
+
+	b, err := json.Marshal(&messaging_api.FlexMessage{AltText: "hi", Contents: container})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"type":"bubble"`, `"type":"box"`, `"layout":"baseline"`, `"type":"icon"`, `"type":"span"`, `"type":"uri"`} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("%s does not contain %s", b, want)
+		}
+	}
+}
+
+func TestBubbleValidation(t *testing.T) {
+	_, err := Bubble().
+		Body(VBox(
+			Text("a").Size("huge"),
+			Icon("https://example.com/icon.png"),
+			HBox(Text("b").Color("red")).Padding("10pt"),
+			Button(nil),
+		)).
+		Build()
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("got %v, want ValidationErrors", err)
+	}
+	want := []string{
+		"body.contents[0].size",
+		"body.contents[1]",
+		"body.contents[2].contents[0].color",
+		"body.contents[2].paddingAll",
+		"body.contents[3].action",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("got %v, want errors at %v", errs, want)
+	}
+	for i, e := range errs {
+		if e.Property != want[i] {
+			t.Errorf("error %d at %q, want %q: %v", i, e.Property, want[i], e)
+		}
+	}
+}
+
+func TestCarousel(t *testing.T) {
+	bubble := func() *BubbleBuilder {
+		return Bubble().Body(VBox(Text("hi")))
+	}
+
+	container, err := Carousel(bubble(), bubble()).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(container.(*messaging_api.FlexCarousel).Contents); n != 2 {
+		t.Errorf("got %d bubbles", n)
+	}
+
+	c := Carousel()
+	for i := 0; i < 13; i++ {
+		c.Add(bubble())
+	}
+	if _, err := c.Build(); err == nil || !strings.Contains(err.Error(), "contents: must have 1 to 12 bubbles") {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestVideoHero(t *testing.T) {
+	video := Video("https://example.com/video.mp4", "https://example.com/preview.png",
+		Image("https://example.com/preview.png")).AspectRatio("16:9")
+
+	if _, err := Bubble().Size("mega").Hero(video).Build(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := Bubble().Hero(video).Build(); err == nil || !strings.Contains(err.Error(), "size: must be kilo, mega or giga") {
+		t.Errorf("got %v", err)
+	}
+	if _, err := Bubble().Body(VBox(video)).Build(); err == nil {
+		t.Errorf("videos should only be allowed as the hero")
+	}
+}
+
+func TestBubbleSizeLimit(t *testing.T) {
+	box := VBox()
+	for i := 0; i < 100; i++ {
+		box.Add(Text(strings.Repeat("a", 400)))
+	}
+	if _, err := Bubble().Body(box).Build(); err == nil || !strings.Contains(err.Error(), "bytes of JSON") {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestMessage(t *testing.T) {
+	msg, err := Message("hello", Bubble().Body(VBox(Text("hello"))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.AltText != "hello" || msg.Contents == nil {
+		t.Errorf("unexpected message: %+v", msg)
+	}
+}
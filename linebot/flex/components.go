@@ -0,0 +1,624 @@
+package flex
+
+import (
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+// BoxBuilder builds a box.
+type BoxBuilder struct {
+	box      messaging_api.FlexBox
+	flex     *int32
+	children []Component
+}
+
+// Box function
+// layout is one of horizontal, vertical and baseline.
+func Box(layout string, children ...Component) *BoxBuilder {
+	return &BoxBuilder{
+		box:      messaging_api.FlexBox{Layout: messaging_api.FlexBoxLAYOUT(layout)},
+		children: children,
+	}
+}
+
+// VBox returns a vertical box.
+func VBox(children ...Component) *BoxBuilder {
+	return Box("vertical", children...)
+}
+
+// HBox returns a horizontal box.
+func HBox(children ...Component) *BoxBuilder {
+	return Box("horizontal", children...)
+}
+
+// BaselineBox returns a baseline box, which holds texts and icons.
+func BaselineBox(children ...Component) *BoxBuilder {
+	return Box("baseline", children...)
+}
+
+// Add method
+func (b *BoxBuilder) Add(children ...Component) *BoxBuilder {
+	b.children = append(b.children, children...)
+	return b
+}
+
+// Flex method
+func (b *BoxBuilder) Flex(flex int32) *BoxBuilder {
+	b.flex = &flex
+	return b
+}
+
+// Spacing method
+func (b *BoxBuilder) Spacing(spacing string) *BoxBuilder {
+	b.box.Spacing = spacing
+	return b
+}
+
+// Margin method
+func (b *BoxBuilder) Margin(margin string) *BoxBuilder {
+	b.box.Margin = margin
+	return b
+}
+
+// Padding method
+// Sets paddingAll.
+func (b *BoxBuilder) Padding(padding string) *BoxBuilder {
+	b.box.PaddingAll = padding
+	return b
+}
+
+// PaddingEach method
+// Sets paddingTop, paddingBottom, paddingStart and paddingEnd. Empty values are left unset.
+func (b *BoxBuilder) PaddingEach(top, bottom, start, end string) *BoxBuilder {
+	b.box.PaddingTop = top
+	b.box.PaddingBottom = bottom
+	b.box.PaddingStart = start
+	b.box.PaddingEnd = end
+	return b
+}
+
+// BackgroundColor method
+func (b *BoxBuilder) BackgroundColor(color string) *BoxBuilder {
+	b.box.BackgroundColor = color
+	return b
+}
+
+// LinearGradient method
+func (b *BoxBuilder) LinearGradient(angle, startColor, endColor string) *BoxBuilder {
+	b.box.Background = &messaging_api.FlexBoxLinearGradient{
+		Angle:      angle,
+		StartColor: startColor,
+		EndColor:   endColor,
+	}
+	return b
+}
+
+// Border method
+func (b *BoxBuilder) Border(width, color string) *BoxBuilder {
+	b.box.BorderWidth = width
+	b.box.BorderColor = color
+	return b
+}
+
+// CornerRadius method
+func (b *BoxBuilder) CornerRadius(radius string) *BoxBuilder {
+	b.box.CornerRadius = radius
+	return b
+}
+
+// Width method
+func (b *BoxBuilder) Width(width string) *BoxBuilder {
+	b.box.Width = width
+	return b
+}
+
+// MaxWidth method
+func (b *BoxBuilder) MaxWidth(width string) *BoxBuilder {
+	b.box.MaxWidth = width
+	return b
+}
+
+// Height method
+func (b *BoxBuilder) Height(height string) *BoxBuilder {
+	b.box.Height = height
+	return b
+}
+
+// MaxHeight method
+func (b *BoxBuilder) MaxHeight(height string) *BoxBuilder {
+	b.box.MaxHeight = height
+	return b
+}
+
+// JustifyContent method
+func (b *BoxBuilder) JustifyContent(justify string) *BoxBuilder {
+	b.box.JustifyContent = messaging_api.FlexBoxJUSTIFY_CONTENT(justify)
+	return b
+}
+
+// AlignItems method
+func (b *BoxBuilder) AlignItems(align string) *BoxBuilder {
+	b.box.AlignItems = messaging_api.FlexBoxALIGN_ITEMS(align)
+	return b
+}
+
+// Absolute method
+// Positions the box relative to its parent with the offsets. Empty offsets are left unset.
+func (b *BoxBuilder) Absolute(top, bottom, start, end string) *BoxBuilder {
+	b.box.Position = messaging_api.FlexBoxPOSITION_ABSOLUTE
+	b.box.OffsetTop, b.box.OffsetBottom, b.box.OffsetStart, b.box.OffsetEnd = top, bottom, start, end
+	return b
+}
+
+// Action method
+func (b *BoxBuilder) Action(action messaging_api.ActionInterface) *BoxBuilder {
+	b.box.Action = action
+	return b
+}
+
+func (b *BoxBuilder) buildBox(layout messaging_api.FlexBoxLAYOUT) *messaging_api.FlexBox {
+	box := b.box
+	box.Flex = defaultFlex(b.flex, layout)
+	box.Contents = make([]messaging_api.FlexComponentInterface, len(b.children))
+	for i, child := range b.children {
+		box.Contents[i] = child.build(box.Layout)
+	}
+	return &box
+}
+
+func (b *BoxBuilder) build(layout messaging_api.FlexBoxLAYOUT) messaging_api.FlexComponentInterface {
+	return b.buildBox(layout)
+}
+
+// TextBuilder builds a text.
+type TextBuilder struct {
+	text  messaging_api.FlexText
+	flex  *int32
+	spans []*SpanBuilder
+}
+
+// Text function
+func Text(text string) *TextBuilder {
+	return &TextBuilder{text: messaging_api.FlexText{Text: text}}
+}
+
+// Spans method
+// The spans replace the text, which is then only shown on LINE versions that do not support spans.
+func (t *TextBuilder) Spans(spans ...*SpanBuilder) *TextBuilder {
+	t.spans = append(t.spans, spans...)
+	return t
+}
+
+// Flex method
+func (t *TextBuilder) Flex(flex int32) *TextBuilder {
+	t.flex = &flex
+	return t
+}
+
+// Size method
+func (t *TextBuilder) Size(size string) *TextBuilder {
+	t.text.Size = size
+	return t
+}
+
+// Color method
+func (t *TextBuilder) Color(color string) *TextBuilder {
+	t.text.Color = color
+	return t
+}
+
+// Bold method
+func (t *TextBuilder) Bold() *TextBuilder {
+	t.text.Weight = messaging_api.FlexTextWEIGHT_BOLD
+	return t
+}
+
+// Italic method
+func (t *TextBuilder) Italic() *TextBuilder {
+	t.text.Style = messaging_api.FlexTextSTYLE_ITALIC
+	return t
+}
+
+// Decoration method
+// decoration is one of none, underline and line-through.
+func (t *TextBuilder) Decoration(decoration string) *TextBuilder {
+	t.text.Decoration = messaging_api.FlexTextDECORATION(decoration)
+	return t
+}
+
+// Align method
+// align is one of start, end and center.
+func (t *TextBuilder) Align(align string) *TextBuilder {
+	t.text.Align = messaging_api.FlexTextALIGN(align)
+	return t
+}
+
+// Gravity method
+// gravity is one of top, bottom and center.
+func (t *TextBuilder) Gravity(gravity string) *TextBuilder {
+	t.text.Gravity = messaging_api.FlexTextGRAVITY(gravity)
+	return t
+}
+
+// Wrap method
+func (t *TextBuilder) Wrap() *TextBuilder {
+	t.text.Wrap = true
+	return t
+}
+
+// MaxLines method
+func (t *TextBuilder) MaxLines(n int32) *TextBuilder {
+	t.text.MaxLines = n
+	return t
+}
+
+// LineSpacing method
+func (t *TextBuilder) LineSpacing(spacing string) *TextBuilder {
+	t.text.LineSpacing = spacing
+	return t
+}
+
+// Margin method
+func (t *TextBuilder) Margin(margin string) *TextBuilder {
+	t.text.Margin = margin
+	return t
+}
+
+// ShrinkToFit method
+func (t *TextBuilder) ShrinkToFit() *TextBuilder {
+	t.text.AdjustMode = messaging_api.FlexTextADJUST_MODE_SHRINK_TO_FIT
+	return t
+}
+
+// Scaling method
+// Scales the font size with the font size setting of the LINE app.
+func (t *TextBuilder) Scaling() *TextBuilder {
+	t.text.Scaling = true
+	return t
+}
+
+// Absolute method
+// Positions the text relative to its parent with the offsets. Empty offsets are left unset.
+func (t *TextBuilder) Absolute(top, bottom, start, end string) *TextBuilder {
+	t.text.Position = messaging_api.FlexTextPOSITION_ABSOLUTE
+	t.text.OffsetTop, t.text.OffsetBottom, t.text.OffsetStart, t.text.OffsetEnd = top, bottom, start, end
+	return t
+}
+
+// Action method
+func (t *TextBuilder) Action(action messaging_api.ActionInterface) *TextBuilder {
+	t.text.Action = action
+	return t
+}
+
+func (t *TextBuilder) build(layout messaging_api.FlexBoxLAYOUT) messaging_api.FlexComponentInterface {
+	text := t.text
+	text.Flex = defaultFlex(t.flex, layout)
+	if len(t.spans) > 0 {
+		text.Contents = make([]messaging_api.FlexSpan, len(t.spans))
+		for i, span := range t.spans {
+			text.Contents[i] = span.span
+		}
+	}
+	return &text
+}
+
+// SpanBuilder builds a span of a text.
+type SpanBuilder struct {
+	span messaging_api.FlexSpan
+}
+
+// Span function
+func Span(text string) *SpanBuilder {
+	return &SpanBuilder{span: messaging_api.FlexSpan{Text: text}}
+}
+
+// Size method
+func (s *SpanBuilder) Size(size string) *SpanBuilder {
+	s.span.Size = size
+	return s
+}
+
+// Color method
+func (s *SpanBuilder) Color(color string) *SpanBuilder {
+	s.span.Color = color
+	return s
+}
+
+// Bold method
+func (s *SpanBuilder) Bold() *SpanBuilder {
+	s.span.Weight = messaging_api.FlexSpanWEIGHT_BOLD
+	return s
+}
+
+// Italic method
+func (s *SpanBuilder) Italic() *SpanBuilder {
+	s.span.Style = messaging_api.FlexSpanSTYLE_ITALIC
+	return s
+}
+
+// Decoration method
+// decoration is one of none, underline and line-through.
+func (s *SpanBuilder) Decoration(decoration string) *SpanBuilder {
+	s.span.Decoration = messaging_api.FlexSpanDECORATION(decoration)
+	return s
+}
+
+// ButtonBuilder builds a button.
+type ButtonBuilder struct {
+	button messaging_api.FlexButton
+	flex   *int32
+}
+
+// Button function
+func Button(action messaging_api.ActionInterface) *ButtonBuilder {
+	return &ButtonBuilder{button: messaging_api.FlexButton{Action: action}}
+}
+
+// Primary method
+func (b *ButtonBuilder) Primary() *ButtonBuilder {
+	b.button.Style = messaging_api.FlexButtonSTYLE_PRIMARY
+	return b
+}
+
+// Secondary method
+func (b *ButtonBuilder) Secondary() *ButtonBuilder {
+	b.button.Style = messaging_api.FlexButtonSTYLE_SECONDARY
+	return b
+}
+
+// Link method
+func (b *ButtonBuilder) Link() *ButtonBuilder {
+	b.button.Style = messaging_api.FlexButtonSTYLE_LINK
+	return b
+}
+
+// Color method
+func (b *ButtonBuilder) Color(color string) *ButtonBuilder {
+	b.button.Color = color
+	return b
+}
+
+// Height method
+// height is sm or md.
+func (b *ButtonBuilder) Height(height string) *ButtonBuilder {
+	b.button.Height = messaging_api.FlexButtonHEIGHT(height)
+	return b
+}
+
+// Gravity method
+// gravity is one of top, bottom and center.
+func (b *ButtonBuilder) Gravity(gravity string) *ButtonBuilder {
+	b.button.Gravity = messaging_api.FlexButtonGRAVITY(gravity)
+	return b
+}
+
+// Flex method
+func (b *ButtonBuilder) Flex(flex int32) *ButtonBuilder {
+	b.flex = &flex
+	return b
+}
+
+// Margin method
+func (b *ButtonBuilder) Margin(margin string) *ButtonBuilder {
+	b.button.Margin = margin
+	return b
+}
+
+// Absolute method
+// Positions the button relative to its parent with the offsets. Empty offsets are left unset.
+func (b *ButtonBuilder) Absolute(top, bottom, start, end string) *ButtonBuilder {
+	b.button.Position = messaging_api.FlexButtonPOSITION_ABSOLUTE
+	b.button.OffsetTop, b.button.OffsetBottom, b.button.OffsetStart, b.button.OffsetEnd = top, bottom, start, end
+	return b
+}
+
+func (b *ButtonBuilder) build(layout messaging_api.FlexBoxLAYOUT) messaging_api.FlexComponentInterface {
+	button := b.button
+	button.Flex = defaultFlex(b.flex, layout)
+	return &button
+}
+
+// ImageBuilder builds an image.
+type ImageBuilder struct {
+	image messaging_api.FlexImage
+	flex  *int32
+}
+
+// Image function
+func Image(url string) *ImageBuilder {
+	return &ImageBuilder{image: messaging_api.FlexImage{Url: url}}
+}
+
+// Size method
+func (i *ImageBuilder) Size(size string) *ImageBuilder {
+	i.image.Size = size
+	return i
+}
+
+// AspectRatio method
+// ratio is in the format {width}:{height}, such as 20:13.
+func (i *ImageBuilder) AspectRatio(ratio string) *ImageBuilder {
+	i.image.AspectRatio = ratio
+	return i
+}
+
+// Cover method
+// Fills the area of the aspect ratio with the image, cropping it if needed.
+func (i *ImageBuilder) Cover() *ImageBuilder {
+	i.image.AspectMode = messaging_api.FlexImageASPECT_MODE_COVER
+	return i
+}
+
+// Align method
+// align is one of start, end and center.
+func (i *ImageBuilder) Align(align string) *ImageBuilder {
+	i.image.Align = messaging_api.FlexImageALIGN(align)
+	return i
+}
+
+// Gravity method
+// gravity is one of top, bottom and center.
+func (i *ImageBuilder) Gravity(gravity string) *ImageBuilder {
+	i.image.Gravity = messaging_api.FlexImageGRAVITY(gravity)
+	return i
+}
+
+// BackgroundColor method
+func (i *ImageBuilder) BackgroundColor(color string) *ImageBuilder {
+	i.image.BackgroundColor = color
+	return i
+}
+
+// Animated method
+func (i *ImageBuilder) Animated() *ImageBuilder {
+	i.image.Animated = true
+	return i
+}
+
+// Flex method
+func (i *ImageBuilder) Flex(flex int32) *ImageBuilder {
+	i.flex = &flex
+	return i
+}
+
+// Margin method
+func (i *ImageBuilder) Margin(margin string) *ImageBuilder {
+	i.image.Margin = margin
+	return i
+}
+
+// Absolute method
+// Positions the image relative to its parent with the offsets. Empty offsets are left unset.
+func (i *ImageBuilder) Absolute(top, bottom, start, end string) *ImageBuilder {
+	i.image.Position = messaging_api.FlexImagePOSITION_ABSOLUTE
+	i.image.OffsetTop, i.image.OffsetBottom, i.image.OffsetStart, i.image.OffsetEnd = top, bottom, start, end
+	return i
+}
+
+// Action method
+func (i *ImageBuilder) Action(action messaging_api.ActionInterface) *ImageBuilder {
+	i.image.Action = action
+	return i
+}
+
+func (i *ImageBuilder) build(layout messaging_api.FlexBoxLAYOUT) messaging_api.FlexComponentInterface {
+	image := i.image
+	image.Flex = defaultFlex(i.flex, layout)
+	return &image
+}
+
+// IconBuilder builds an icon, which is only allowed in baseline boxes.
+type IconBuilder struct {
+	icon messaging_api.FlexIcon
+}
+
+// Icon function
+func Icon(url string) *IconBuilder {
+	return &IconBuilder{icon: messaging_api.FlexIcon{Url: url}}
+}
+
+// Size method
+func (i *IconBuilder) Size(size string) *IconBuilder {
+	i.icon.Size = size
+	return i
+}
+
+// AspectRatio method
+func (i *IconBuilder) AspectRatio(ratio string) *IconBuilder {
+	i.icon.AspectRatio = ratio
+	return i
+}
+
+// Margin method
+func (i *IconBuilder) Margin(margin string) *IconBuilder {
+	i.icon.Margin = margin
+	return i
+}
+
+func (i *IconBuilder) build(layout messaging_api.FlexBoxLAYOUT) messaging_api.FlexComponentInterface {
+	icon := i.icon
+	return &icon
+}
+
+// SeparatorBuilder builds a separator.
+type SeparatorBuilder struct {
+	separator messaging_api.FlexSeparator
+}
+
+// Separator function
+func Separator() *SeparatorBuilder {
+	return &SeparatorBuilder{}
+}
+
+// Margin method
+func (s *SeparatorBuilder) Margin(margin string) *SeparatorBuilder {
+	s.separator.Margin = margin
+	return s
+}
+
+// Color method
+func (s *SeparatorBuilder) Color(color string) *SeparatorBuilder {
+	s.separator.Color = color
+	return s
+}
+
+func (s *SeparatorBuilder) build(layout messaging_api.FlexBoxLAYOUT) messaging_api.FlexComponentInterface {
+	separator := s.separator
+	return &separator
+}
+
+// FillerBuilder builds a filler.
+type FillerBuilder struct {
+	flex *int32
+}
+
+// Filler function
+func Filler() *FillerBuilder {
+	return &FillerBuilder{}
+}
+
+// Flex method
+func (f *FillerBuilder) Flex(flex int32) *FillerBuilder {
+	f.flex = &flex
+	return f
+}
+
+func (f *FillerBuilder) build(layout messaging_api.FlexBoxLAYOUT) messaging_api.FlexComponentInterface {
+	return &messaging_api.FlexFiller{Flex: defaultFlex(f.flex, layout)}
+}
+
+// VideoBuilder builds a video, which is only allowed as the hero of a bubble.
+type VideoBuilder struct {
+	video      messaging_api.FlexVideo
+	altContent Component
+}
+
+// Video function
+// altContent, a box or an image, is shown on LINE versions that do not support videos.
+func Video(url, previewUrl string, altContent Component) *VideoBuilder {
+	return &VideoBuilder{
+		video:      messaging_api.FlexVideo{Url: url, PreviewUrl: previewUrl},
+		altContent: altContent,
+	}
+}
+
+// AspectRatio method
+func (v *VideoBuilder) AspectRatio(ratio string) *VideoBuilder {
+	v.video.AspectRatio = ratio
+	return v
+}
+
+// Action method
+// The action is shown after the video has been played.
+func (v *VideoBuilder) Action(action messaging_api.ActionInterface) *VideoBuilder {
+	v.video.Action = action
+	return v
+}
+
+func (v *VideoBuilder) build(layout messaging_api.FlexBoxLAYOUT) messaging_api.FlexComponentInterface {
+	video := v.video
+	if v.altContent != nil {
+		video.AltContent = v.altContent.build("")
+	}
+	return &video
+}
@@ -0,0 +1,668 @@
+package flex
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+const (
+	eachKey = "$each"
+	ifKey   = "$if"
+)
+
+// typedProperties are the properties of Flex components that are not strings.
+// A placeholder that makes up the whole value of one of them renders the
+// number or the boolean itself instead of a string.
+var typedProperties = []string{"animated", "flex", "maxLines", "scaling", "separator", "wrap"}
+
+// filters are the functions that can be applied to the values of placeholders.
+var filters = map[string]func(string) string{
+	"urlquery": url.QueryEscape,
+	"urlpath":  url.PathEscape,
+	"upper":    strings.ToUpper,
+	"lower":    strings.ToLower,
+}
+
+// Template is a Flex container with placeholders, loops and conditionals,
+// rendered with a Go value. It is safe for concurrent use.
+//
+// In the JSON of a template:
+//
+//   - "{{path}}" in a string is replaced with the value at path, such as
+//     "{{customer.name}}" or "{{items.0.price}}". Fields are looked up by
+//     their json tag or their name, and maps by key. "{{.}}" is the current
+//     element of a loop, "{{$index}}" its index, and "{{$root.path}}"
+//     looks up from the rendered value instead of the current element.
+//     "{{path | urlquery}}" escapes the value for a URL query, and urlpath,
+//     upper and lower are also available. "{{\"{{\"}}" renders "{{".
+//   - An object in an array, such as the contents of a box or a carousel, with
+//     "$each": "path" is repeated for each element of the slice at path.
+//   - An object with "$if": "path" is removed unless the value at path is
+//     set: not false, zero, empty or nil. "$if": "!path" negates it.
+//
+// Values are inserted as JSON strings, so they can neither change the
+// structure of the container nor be expanded again. A placeholder that is the
+// whole value of flex, maxLines, wrap, scaling, animated or separator
+// renders the number or boolean.
+type Template struct {
+	tree any
+}
+
+// ParseTemplate parses the JSON of a template of a bubble or a carousel.
+func ParseTemplate(data []byte) (*Template, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var tree any
+	if err := dec.Decode(&tree); err != nil {
+		return nil, &ValidationError{Message: fmt.Sprintf("invalid JSON: %v", err)}
+	}
+	var errs ValidationErrors
+	checkTemplate("", tree, false, &errs)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return &Template{tree: tree}, nil
+}
+
+// NewTemplate returns a template of a bubble or a carousel built with
+// placeholders in its strings, and Each and If components.
+func NewTemplate(c Container) (*Template, error) {
+	var tree any
+	switch c := c.(type) {
+	case *BubbleBuilder:
+		if c.each != "" || c.cond != "" {
+			return nil, &ValidationError{Message: "Each and If are only allowed for the bubbles of a carousel"}
+		}
+		bubble, err := c.template()
+		if err != nil {
+			return nil, err
+		}
+		tree = bubble
+	case *CarouselBuilder:
+		contents := make([]any, len(c.bubbles))
+		for i, b := range c.bubbles {
+			bubble, err := b.template()
+			if err != nil {
+				return nil, err
+			}
+			contents[i] = bubble
+		}
+		tree = map[string]any{"type": "carousel", "contents": contents}
+	default:
+		container, err := c.Build()
+		if err != nil {
+			return nil, err
+		}
+		data, err := messaging_api.MarshalFlexContainerJSON(container)
+		if err != nil {
+			return nil, err
+		}
+		return ParseTemplate(data)
+	}
+	data, err := json.Marshal(tree)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTemplate(data)
+}
+
+// template returns the JSON of the bubble, with its directives.
+func (b *BubbleBuilder) template() (map[string]any, error) {
+	bubble := b.build()
+	data, err := messaging_api.MarshalFlexContainerJSON(&bubble)
+	if err != nil {
+		return nil, err
+	}
+	var tree map[string]any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+	if b.each != "" {
+		tree[eachKey] = b.each
+	}
+	if b.cond != "" {
+		tree[ifKey] = b.cond
+	}
+	return tree, nil
+}
+
+// Render returns the container for data, or ValidationErrors if the template
+// cannot be rendered with data or the result is invalid, including when it
+// exceeds the size limits.
+func (t *Template) Render(data any) (messaging_api.FlexContainerInterface, error) {
+	r := &renderer{}
+	root := &scope{value: reflect.ValueOf(data)}
+	root.root = root
+	tree, _ := r.render("", "", t.tree, root)
+	if len(r.errs) > 0 {
+		return nil, r.errs
+	}
+
+	b, err := json.Marshal(tree)
+	if err != nil {
+		return nil, err
+	}
+	container, err := messaging_api.UnmarshalFlexContainerJSON(b)
+	if err != nil {
+		return nil, validationErrors(err)
+	}
+	if err := Validate(container); err != nil {
+		return nil, err
+	}
+	return container, nil
+}
+
+// Message returns a Flex Message with the container for data. Placeholders
+// in altText are replaced too.
+func (t *Template) Message(altText string, data any) (*messaging_api.FlexMessage, error) {
+	r := &renderer{}
+	root := &scope{value: reflect.ValueOf(data)}
+	root.root = root
+	altText = r.text("altText", altText, root)
+	if len(r.errs) > 0 {
+		return nil, r.errs
+	}
+	contents, err := t.Render(data)
+	if err != nil {
+		return nil, err
+	}
+	return &messaging_api.FlexMessage{
+		AltText:  altText,
+		Contents: contents,
+	}, nil
+}
+
+// validationErrors converts the errors of messaging_api.UnmarshalFlexContainerJSON.
+func validationErrors(err error) ValidationErrors {
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	var result ValidationErrors
+	for _, err := range errs {
+		var jsonErr *messaging_api.FlexJSONError
+		if errors.As(err, &jsonErr) {
+			result = append(result, &ValidationError{Property: jsonErr.Path, Message: jsonErr.Message})
+		} else {
+			result = append(result, &ValidationError{Message: err.Error()})
+		}
+	}
+	return result
+}
+
+// checkTemplate reports the syntax errors of the template tree.
+func checkTemplate(path string, v any, inArray bool, errs *ValidationErrors) {
+	switch t := v.(type) {
+	case map[string]any:
+		for _, key := range sortedKeys(t) {
+			value := t[key]
+			switch key {
+			case eachKey:
+				if !inArray {
+					*errs = append(*errs, &ValidationError{Property: join(path, key), Message: "is only allowed for the elements of arrays"})
+				}
+				fallthrough
+			case ifKey:
+				if s, ok := value.(string); !ok || strings.TrimPrefix(s, "!") == "" {
+					*errs = append(*errs, &ValidationError{Property: join(path, key), Message: "must be a path"})
+				}
+			default:
+				checkTemplate(join(path, key), value, false, errs)
+			}
+		}
+	case []any:
+		for i, e := range t {
+			checkTemplate(index(path, i), e, true, errs)
+		}
+	case string:
+		if _, err := parsePlaceholders(t); err != nil {
+			*errs = append(*errs, &ValidationError{Property: path, Message: err.Error()})
+		}
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// segment is a part of a string of a template: a literal, or a placeholder.
+type segment struct {
+	literal string
+	path    string
+	filters []string
+}
+
+// parsePlaceholders splits s into literals and placeholders.
+func parsePlaceholders(s string) ([]segment, error) {
+	var segments []segment
+	for s != "" {
+		start := strings.Index(s, "{{")
+		if start < 0 {
+			segments = append(segments, segment{literal: s})
+			break
+		}
+		if start > 0 {
+			segments = append(segments, segment{literal: s[:start]})
+		}
+		s = s[start+2:]
+		end := strings.Index(s, "}}")
+		if strings.HasPrefix(strings.TrimSpace(s), `"`) {
+			// A quoted literal may contain "}}".
+			literal, rest, ok := cutQuoted(strings.TrimSpace(s))
+			if !ok || !strings.HasPrefix(strings.TrimSpace(rest), "}}") {
+				return nil, fmt.Errorf("invalid quoted string in placeholder")
+			}
+			segments = append(segments, segment{literal: literal})
+			s = strings.TrimPrefix(strings.TrimSpace(rest), "}}")
+			continue
+		}
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated placeholder")
+		}
+		parts := strings.Split(s[:end], "|")
+		seg := segment{path: strings.TrimSpace(parts[0])}
+		if seg.path == "" {
+			return nil, fmt.Errorf("empty placeholder")
+		}
+		for _, f := range parts[1:] {
+			f = strings.TrimSpace(f)
+			if _, ok := filters[f]; !ok {
+				return nil, fmt.Errorf("unknown filter %q", f)
+			}
+			seg.filters = append(seg.filters, f)
+		}
+		segments = append(segments, seg)
+		s = s[end+2:]
+	}
+	return segments, nil
+}
+
+// cutQuoted returns the Go string literal at the start of s, and the rest of s.
+func cutQuoted(s string) (string, string, bool) {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			literal, err := strconv.Unquote(s[:i+1])
+			return literal, s[i+1:], err == nil
+		}
+	}
+	return "", "", false
+}
+
+// scope is the value that paths are looked up in.
+type scope struct {
+	value  reflect.Value
+	index  int
+	parent *scope
+	root   *scope
+}
+
+// lookup returns the value at path, and false if it is not defined.
+func (s *scope) lookup(path string) (reflect.Value, bool, error) {
+	if path == "." {
+		return s.value, true, nil
+	}
+	if path == "$index" {
+		return reflect.ValueOf(s.index), true, nil
+	}
+	var names []string
+	var v reflect.Value
+	switch {
+	case strings.HasPrefix(path, "$root."):
+		names = strings.Split(strings.TrimPrefix(path, "$root."), ".")
+		var ok bool
+		if v, ok = field(s.root.value, names[0]); !ok {
+			return v, false, nil
+		}
+	case strings.HasPrefix(path, "."):
+		names = strings.Split(strings.TrimPrefix(path, "."), ".")
+		var ok bool
+		if v, ok = field(s.value, names[0]); !ok {
+			return v, false, nil
+		}
+	default:
+		names = strings.Split(path, ".")
+		found := false
+		for sc := s; sc != nil && !found; sc = sc.parent {
+			v, found = field(sc.value, names[0])
+		}
+		if !found {
+			return v, false, nil
+		}
+	}
+	for i, name := range names[1:] {
+		var ok bool
+		if v, ok = field(v, name); !ok {
+			return v, false, fmt.Errorf("%s has no field %q", strings.Join(names[:i+1], "."), name)
+		}
+	}
+	return v, true, nil
+}
+
+// indirect dereferences pointers and interfaces, and returns the zero Value for nil.
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// field returns the field, map value or element name of v. Nil values have
+// all fields, with nil values.
+func field(v reflect.Value, name string) (reflect.Value, bool) {
+	v = indirect(v)
+	if !v.IsValid() {
+		return v, true
+	}
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return reflect.Value{}, false
+		}
+		value := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+		return value, value.IsValid()
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if tag == name {
+				return v.Field(i), true
+			}
+		}
+		// Like encoding/json, fall back to a case-insensitive match of the names.
+		for i := 0; i < t.NumField(); i++ {
+			if f := t.Field(i); f.IsExported() && strings.EqualFold(f.Name, name) {
+				return v.Field(i), true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(name)
+		if err == nil && i >= 0 && i < v.Len() {
+			return v.Index(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// truthy reports whether v is set.
+func truthy(v reflect.Value) bool {
+	v = indirect(v)
+	if !v.IsValid() {
+		return false
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array, reflect.String:
+		return v.Len() > 0
+	}
+	return !v.IsZero()
+}
+
+// format returns the text of v.
+func format(v reflect.Value) string {
+	if !indirect(v).IsValid() {
+		return ""
+	}
+	if v.CanInterface() {
+		switch t := v.Interface().(type) {
+		case fmt.Stringer:
+			return t.String()
+		case encoding.TextMarshaler:
+			if b, err := t.MarshalText(); err == nil {
+				return string(b)
+			}
+		}
+	}
+	v = indirect(v)
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+type renderer struct {
+	errs ValidationErrors
+}
+
+func (r *renderer) add(property, format string, args ...any) {
+	r.errs = append(r.errs, &ValidationError{Property: property, Message: fmt.Sprintf(format, args...)})
+}
+
+// render returns v for the scope, and false if it is removed by a conditional.
+// key is the name of the property of v, and path is the path of v in the result.
+func (r *renderer) render(path, key string, v any, s *scope) (any, bool) {
+	switch t := v.(type) {
+	case map[string]any:
+		if cond, ok := t[ifKey].(string); ok && !r.condition(join(path, ifKey), cond, s) {
+			return nil, false
+		}
+		m := make(map[string]any, len(t))
+		for _, k := range sortedKeys(t) {
+			if k == ifKey || k == eachKey {
+				continue
+			}
+			if value, ok := r.render(join(path, k), k, t[k], s); ok {
+				m[k] = value
+			}
+		}
+		return m, true
+	case []any:
+		a := make([]any, 0, len(t))
+		for _, e := range t {
+			m, ok := e.(map[string]any)
+			if !ok || m[eachKey] == nil {
+				if value, ok := r.render(index(path, len(a)), "", e, s); ok {
+					a = append(a, value)
+				}
+				continue
+			}
+			listPath := m[eachKey].(string)
+			list, found, err := s.lookup(listPath)
+			if err != nil || !found {
+				r.undefined(index(path, len(a)), listPath, err)
+				continue
+			}
+			list = indirect(list)
+			if !list.IsValid() {
+				continue
+			}
+			if list.Kind() != reflect.Slice && list.Kind() != reflect.Array {
+				r.add(index(path, len(a)), "%s must be a slice, got %s", listPath, list.Kind())
+				continue
+			}
+			for i := 0; i < list.Len(); i++ {
+				item := &scope{value: list.Index(i), index: i, parent: s, root: s.root}
+				if value, ok := r.render(index(path, len(a)), "", e, item); ok {
+					a = append(a, value)
+				}
+			}
+		}
+		return a, true
+	case string:
+		if slices.Contains(typedProperties, key) {
+			if segments, _ := parsePlaceholders(t); len(segments) == 1 && segments[0].path != "" && len(segments[0].filters) == 0 {
+				return r.typed(path, segments[0].path, s), true
+			}
+		}
+		return r.text(path, t, s), true
+	}
+	return v, true
+}
+
+func (r *renderer) undefined(path, name string, err error) {
+	if err != nil {
+		r.add(path, "%v", err)
+	} else {
+		r.add(path, "%s is not defined", name)
+	}
+}
+
+func (r *renderer) condition(path, cond string, s *scope) bool {
+	negate := strings.HasPrefix(cond, "!")
+	v, _, err := s.lookup(strings.TrimPrefix(cond, "!"))
+	if err != nil {
+		r.add(path, "%v", err)
+		return false
+	}
+	return truthy(v) != negate
+}
+
+// typed returns the number or boolean at name.
+func (r *renderer) typed(path, name string, s *scope) any {
+	v, found, err := s.lookup(name)
+	if err != nil || !found {
+		r.undefined(path, name, err)
+		return nil
+	}
+	v = indirect(v)
+	if !v.IsValid() {
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return json.Number(format(v))
+	}
+	return format(v)
+}
+
+// text returns s with its placeholders replaced.
+func (r *renderer) text(path, s string, sc *scope) string {
+	segments, err := parsePlaceholders(s)
+	if err != nil {
+		r.add(path, "%v", err)
+		return s
+	}
+	var sb strings.Builder
+	for _, seg := range segments {
+		if seg.path == "" {
+			sb.WriteString(seg.literal)
+			continue
+		}
+		v, found, err := sc.lookup(seg.path)
+		if err != nil || !found {
+			r.undefined(path, seg.path, err)
+			continue
+		}
+		text := format(v)
+		for _, f := range seg.filters {
+			text = filters[f](text)
+		}
+		sb.WriteString(text)
+	}
+	return sb.String()
+}
+
+// templateComponent is a component with the directives of a template.
+type templateComponent struct {
+	each      string
+	cond      string
+	component messaging_api.FlexComponentInterface
+}
+
+func (c *templateComponent) GetType() string {
+	return componentType(c.component)
+}
+
+func (c *templateComponent) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(c.component)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	if c.each != "" {
+		m[eachKey], _ = json.Marshal(c.each)
+	}
+	if c.cond != "" {
+		m[ifKey], _ = json.Marshal(c.cond)
+	}
+	return json.Marshal(m)
+}
+
+type templateBuilder struct {
+	each      string
+	cond      string
+	component Component
+}
+
+func (b *templateBuilder) build(layout messaging_api.FlexBoxLAYOUT) messaging_api.FlexComponentInterface {
+	c := b.component.build(layout)
+	if t, ok := c.(*templateComponent); ok {
+		// Each(path, If(cond, c)) and If(cond, Each(path, c)) both apply.
+		t = &templateComponent{each: t.each, cond: t.cond, component: t.component}
+		if b.each != "" {
+			t.each = b.each
+		}
+		if b.cond != "" {
+			t.cond = b.cond
+		}
+		return t
+	}
+	return &templateComponent{each: b.each, cond: b.cond, component: c}
+}
+
+// Each function
+// Repeats c in a template for each element of the slice at path. See Template.
+func Each(path string, c Component) Component {
+	return &templateBuilder{each: path, component: c}
+}
+
+// If function
+// Keeps c in a template only if the value at cond is set. See Template.
+func If(cond string, c Component) Component {
+	return &templateBuilder{cond: cond, component: c}
+}
+
+// Each method
+// Repeats the bubble of a carousel template for each element of the slice at path. See Template.
+func (b *BubbleBuilder) Each(path string) *BubbleBuilder {
+	b.each = path
+	return b
+}
+
+// If method
+// Keeps the bubble of a carousel template only if the value at cond is set. See Template.
+func (b *BubbleBuilder) If(cond string) *BubbleBuilder {
+	b.cond = cond
+	return b
+}
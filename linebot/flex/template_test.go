@@ -0,0 +1,240 @@
+package flex
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+const receiptTemplate = `{
+  "type": "bubble",
+  "body": {
+    "type": "box",
+    "layout": "vertical",
+    "contents": [
+      {"type": "text", "text": "Order #{{id}} for {{customer.name}}", "weight": "bold"},
+      {
+        "$each": "items",
+        "type": "box",
+        "layout": "horizontal",
+        "contents": [
+          {"type": "text", "text": "{{$index}}. {{name}}", "flex": "{{weight}}"},
+          {"type": "text", "text": "{{currency}}{{price}}", "align": "end"}
+        ]
+      },
+      {"$if": "paid", "type": "text", "text": "Paid"},
+      {"$if": "!paid", "type": "text", "text": "Pay {{\"{{\"}}now}}"}
+    ]
+  },
+  "footer": {
+    "$if": "url",
+    "type": "box",
+    "layout": "vertical",
+    "contents": [
+      {"type": "button", "action": {"type": "uri", "label": "Track", "uri": "https://example.com/track?id={{id | urlquery}}"}}
+    ]
+  }
+}`
+
+type item struct {
+	Name   string  `json:"name"`
+	Price  float64 `json:"price"`
+	Weight int
+}
+
+type receipt struct {
+	ID       string
+	Customer *struct{ Name string } `json:"customer"`
+	Items    []item                 `json:"items"`
+	Currency string                 `json:"currency"`
+	Paid     bool                   `json:"paid"`
+	URL      string                 `json:"url"`
+}
+
+func TestTemplate(t *testing.T) {
+	tmpl, err := ParseTemplate([]byte(receiptTemplate))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := receipt{
+		ID:       "a&b",
+		Customer: &struct{ Name string }{Name: `"Brown"}`},
+		Items:    []item{{Name: "Coffee", Price: 3.5, Weight: 2}, {Name: "Cake", Price: 4, Weight: 3}},
+		Currency: "$",
+		URL:      "https://example.com",
+	}
+	container, err := tmpl.Render(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bubble := container.(messaging_api.FlexBubble)
+	contents := bubble.Body.Contents
+	if len(contents) != 4 {
+		t.Fatalf("got %d components, want 4", len(contents))
+	}
+	if title := contents[0].(messaging_api.FlexText); title.Text != `Order #a&b for "Brown"}` {
+		t.Errorf("unexpected title: %q", title.Text)
+	}
+	row := contents[2].(messaging_api.FlexBox)
+	if name := row.Contents[0].(messaging_api.FlexText); name.Text != "1. Cake" || name.Flex != 3 {
+		t.Errorf("unexpected name: %+v", name)
+	}
+	if price := row.Contents[1].(messaging_api.FlexText); price.Text != "$4" || price.Flex != 1 {
+		t.Errorf("unexpected price: %+v", price)
+	}
+	if pay := contents[3].(messaging_api.FlexText); pay.Text != "Pay {{now}}" {
+		t.Errorf("unexpected text: %q", pay.Text)
+	}
+	button := bubble.Footer.Contents[0].(messaging_api.FlexButton)
+	if uri := button.Action.(messaging_api.UriAction).Uri; uri != "https://example.com/track?id=a%26b" {
+		t.Errorf("unexpected uri: %q", uri)
+	}
+
+	data.URL = ""
+	data.Paid = true
+	container, err = tmpl.Render(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bubble = container.(messaging_api.FlexBubble)
+	if bubble.Footer != nil {
+		t.Errorf("footer should be removed")
+	}
+	if paid := bubble.Body.Contents[3].(messaging_api.FlexText); paid.Text != "Paid" {
+		t.Errorf("unexpected text: %q", paid.Text)
+	}
+}
+
+func TestTemplateMap(t *testing.T) {
+	tmpl, err := ParseTemplate([]byte(`{"type":"carousel","contents":[
+		{"$each":"stops","type":"bubble","body":{"type":"box","layout":"vertical","contents":[
+			{"type":"text","text":"{{.}} ({{$root.line}})"}]}}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := tmpl.Message("{{line}} line", map[string]any{"line": "Yamanote", "stops": []string{"Tokyo", "Ueno"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.AltText != "Yamanote line" {
+		t.Errorf("unexpected altText: %q", msg.AltText)
+	}
+	carousel := msg.Contents.(messaging_api.FlexCarousel)
+	if len(carousel.Contents) != 2 {
+		t.Fatalf("got %d bubbles", len(carousel.Contents))
+	}
+	if text := carousel.Contents[1].Body.Contents[0].(messaging_api.FlexText).Text; text != "Ueno (Yamanote)" {
+		t.Errorf("unexpected text: %q", text)
+	}
+}
+
+func TestTemplateErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"unterminated", `{"type":"bubble","body":{"type":"box","layout":"vertical","contents":[{"type":"text","text":"{{name"}]}}`, "body.contents[0].text: unterminated placeholder"},
+		{"unknown filter", `{"type":"bubble","body":{"type":"box","layout":"vertical","contents":[{"type":"text","text":"{{name | html}}"}]}}`, `body.contents[0].text: unknown filter "html"`},
+		{"each outside array", `{"type":"bubble","$each":"items"}`, "$each: is only allowed for the elements of arrays"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseTemplate([]byte(tc.template))
+			if err == nil || err.Error() != tc.want {
+				t.Errorf("got %v, want %q", err, tc.want)
+			}
+		})
+	}
+
+	tmpl, err := ParseTemplate([]byte(`{"type":"bubble","body":{"type":"box","layout":"vertical","contents":[
+		{"type":"text","text":"{{missing}}"},
+		{"type":"text","text":"{{customer.Age}}"},
+		{"$each":"ID","type":"text","text":"a"}]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = tmpl.Render(receipt{Customer: &struct{ Name string }{}})
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("got %v, want ValidationErrors", err)
+	}
+	want := []string{
+		"body.contents[0].text: missing is not defined",
+		`body.contents[1].text: customer has no field "Age"`,
+		"body.contents[2]: ID must be a slice, got string",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("got %v, want %v", errs, want)
+	}
+	for i, e := range errs {
+		if e.Error() != want[i] {
+			t.Errorf("got %q, want %q", e, want[i])
+		}
+	}
+}
+
+func TestTemplateLimits(t *testing.T) {
+	tmpl, err := ParseTemplate([]byte(`{"type":"carousel","contents":[
+		{"$each":".","type":"bubble","body":{"type":"box","layout":"vertical","contents":[{"type":"text","text":"{{.}}"}]}}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tmpl.Render(make([]string, 13)); err == nil || !strings.Contains(err.Error(), "contents: must have 1 to 12 bubbles, got 13") {
+		t.Errorf("got %v", err)
+	}
+	if _, err := tmpl.Render([]string{""}); err == nil || !strings.Contains(err.Error(), "text: must not be empty") {
+		t.Errorf("got %v", err)
+	}
+	if _, err := tmpl.Render([]string{strings.Repeat("a", MaxBubbleSize)}); err == nil || !strings.Contains(err.Error(), "bytes of JSON") {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestNewTemplate(t *testing.T) {
+	tmpl, err := NewTemplate(Carousel(
+		Bubble().Each("orders").Body(VBox(
+			Text("Order {{id}}").Bold(),
+			Each("lines", HBox(Text("{{name}}"), Text("{{qty}}").Align("end"))),
+			If("note", Text("{{note}}").Wrap()),
+		)),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	type line struct {
+		Name string `json:"name"`
+		Qty  int    `json:"qty"`
+	}
+	type order struct {
+		ID    int    `json:"id"`
+		Lines []line `json:"lines"`
+		Note  string `json:"note"`
+	}
+	container, err := tmpl.Render(map[string][]order{"orders": {
+		{ID: 1, Lines: []line{{"Tea", 2}, {"Scone", 1}}, Note: "No sugar"},
+		{ID: 2, Lines: []line{{"Coffee", 1}}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	carousel := container.(messaging_api.FlexCarousel)
+	if len(carousel.Contents) != 2 {
+		t.Fatalf("got %d bubbles", len(carousel.Contents))
+	}
+	if n := len(carousel.Contents[0].Body.Contents); n != 4 {
+		t.Errorf("got %d components in the first bubble, want 4", n)
+	}
+	if n := len(carousel.Contents[1].Body.Contents); n != 2 {
+		t.Errorf("got %d components in the second bubble, want 2", n)
+	}
+	row := carousel.Contents[0].Body.Contents[2].(messaging_api.FlexBox)
+	if qty := row.Contents[1].(messaging_api.FlexText); qty.Text != "1" || qty.Flex != 1 {
+		t.Errorf("unexpected quantity: %+v", qty)
+	}
+
+	if _, err := Bubble().Body(VBox(If("note", Text("a")))).Build(); err == nil || !strings.Contains(err.Error(), "only allowed in templates") {
+		t.Errorf("got %v", err)
+	}
+}
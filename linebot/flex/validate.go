@@ -0,0 +1,458 @@
+package flex
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+const (
+	// MaxBubbleSize is the maximum size of the JSON of a bubble, in bytes.
+	MaxBubbleSize = 30 * 1024
+	// MaxCarouselSize is the maximum size of the JSON of a carousel, in bytes.
+	MaxCarouselSize = 50 * 1024
+	// MaxCarouselBubbles is the maximum number of bubbles in a carousel.
+	MaxCarouselBubbles = 12
+	// MaxBoxDepth is the maximum number of boxes nested in one another within
+	// a block of a bubble, counting the outermost box.
+	MaxBoxDepth = 10
+)
+
+// ValidationError is a violation of the Flex Message specification.
+type ValidationError struct {
+	// Property is the JSON path of the offending property, relative to the
+	// container, in the format of messaging_api.ErrorDetail.Property, such
+	// as "body.contents[2].size".
+	Property string
+	Message  string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Property == "" {
+		return e.Message
+	}
+	return e.Property + ": " + e.Message
+}
+
+// ValidationErrors holds all the violations found in a container.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Validate checks container against the Flex Message specification: the
+// components allowed in each block and box layout, the keywords and the
+// pixel and percentage formats of sizes, colors, URLs, the number of bubbles
+// in a carousel, the nesting depth of boxes, and the JSON size limits. It returns ValidationErrors, or nil.
+func Validate(container messaging_api.FlexContainerInterface) error {
+	v := &validator{}
+	v.container("", container)
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+type validator struct {
+	errs ValidationErrors
+	// depth is the number of boxes the component being validated is nested in.
+	depth int
+}
+
+func (v *validator) add(property, format string, args ...any) {
+	v.errs = append(v.errs, &ValidationError{Property: property, Message: fmt.Sprintf(format, args...)})
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func index(path string, i int) string {
+	return fmt.Sprintf("%s[%d]", path, i)
+}
+
+// as returns v as *T, whether it holds a T or a *T.
+func as[T any](v any) (*T, bool) {
+	switch t := v.(type) {
+	case T:
+		return &t, true
+	case *T:
+		return t, t != nil
+	}
+	return nil, false
+}
+
+func (v *validator) container(path string, c messaging_api.FlexContainerInterface) {
+	if bubble, ok := as[messaging_api.FlexBubble](c); ok {
+		v.bubble(path, bubble)
+		v.jsonSize(path, bubble, MaxBubbleSize)
+		return
+	}
+	if carousel, ok := as[messaging_api.FlexCarousel](c); ok {
+		contents := join(path, "contents")
+		if n := len(carousel.Contents); n == 0 || n > MaxCarouselBubbles {
+			v.add(contents, "must have 1 to %d bubbles, got %d", MaxCarouselBubbles, n)
+		}
+		for i := range carousel.Contents {
+			v.bubble(index(contents, i), &carousel.Contents[i])
+			v.jsonSize(index(contents, i), &carousel.Contents[i], MaxBubbleSize)
+		}
+		v.jsonSize(path, carousel, MaxCarouselSize)
+		return
+	}
+	if c == nil {
+		v.add(path, "must not be empty")
+		return
+	}
+	v.add(join(path, "type"), "unknown container type %q", c.GetType())
+}
+
+func (v *validator) jsonSize(path string, x any, max int) {
+	b, err := json.Marshal(x)
+	if err != nil {
+		v.add(path, "cannot be marshaled: %v", err)
+		return
+	}
+	if len(b) > max {
+		v.add(path, "must be at most %d bytes of JSON, got %d", max, len(b))
+	}
+}
+
+var bubbleSizes = []string{"nano", "micro", "deca", "hecto", "kilo", "mega", "giga"}
+
+func (v *validator) bubble(path string, b *messaging_api.FlexBubble) {
+	v.enum(join(path, "size"), string(b.Size), bubbleSizes...)
+	v.enum(join(path, "direction"), string(b.Direction), "ltr", "rtl")
+	if b.Header != nil {
+		v.box(join(path, "header"), b.Header)
+	}
+	if b.Body != nil {
+		v.box(join(path, "body"), b.Body)
+	}
+	if b.Footer != nil {
+		v.box(join(path, "footer"), b.Footer)
+	}
+	if b.Hero != nil {
+		hero := join(path, "hero")
+		switch componentType(b.Hero) {
+		case "box", "image":
+			v.component(hero, b.Hero)
+		case "video":
+			if !slices.Contains([]string{"kilo", "mega", "giga"}, string(b.Size)) {
+				v.add(join(path, "size"), "must be kilo, mega or giga when the hero is a video")
+			}
+			v.component(hero, b.Hero)
+		default:
+			v.add(hero, "must be a box, an image or a video, got %s", componentType(b.Hero))
+		}
+	}
+	if b.Styles != nil {
+		for _, block := range []struct {
+			name  string
+			style *messaging_api.FlexBlockStyle
+		}{
+			{"header", b.Styles.Header}, {"hero", b.Styles.Hero}, {"body", b.Styles.Body}, {"footer", b.Styles.Footer},
+		} {
+			if style := block.style; style != nil {
+				stylePath := join(join(path, "styles"), block.name)
+				v.color(join(stylePath, "backgroundColor"), style.BackgroundColor)
+				v.color(join(stylePath, "separatorColor"), style.SeparatorColor)
+			}
+		}
+	}
+}
+
+// allowedChildren lists the components allowed in the boxes of each layout.
+var allowedChildren = map[messaging_api.FlexBoxLAYOUT][]string{
+	messaging_api.FlexBoxLAYOUT_HORIZONTAL: {"box", "button", "image", "text", "separator", "filler"},
+	messaging_api.FlexBoxLAYOUT_VERTICAL:   {"box", "button", "image", "text", "separator", "filler"},
+	messaging_api.FlexBoxLAYOUT_BASELINE:   {"icon", "text", "filler"},
+}
+
+func componentType(c messaging_api.FlexComponentInterface) string {
+	if c == nil {
+		return "nothing"
+	}
+	if t := c.GetType(); t != "" {
+		return t
+	}
+	// Components built in Go have no type until they are marshaled.
+	switch c.(type) {
+	case messaging_api.FlexBox, *messaging_api.FlexBox:
+		return "box"
+	case messaging_api.FlexButton, *messaging_api.FlexButton:
+		return "button"
+	case messaging_api.FlexFiller, *messaging_api.FlexFiller:
+		return "filler"
+	case messaging_api.FlexIcon, *messaging_api.FlexIcon:
+		return "icon"
+	case messaging_api.FlexImage, *messaging_api.FlexImage:
+		return "image"
+	case messaging_api.FlexSeparator, *messaging_api.FlexSeparator:
+		return "separator"
+	case messaging_api.FlexSpan, *messaging_api.FlexSpan:
+		return "span"
+	case messaging_api.FlexText, *messaging_api.FlexText:
+		return "text"
+	case messaging_api.FlexVideo, *messaging_api.FlexVideo:
+		return "video"
+	}
+	return "unknown"
+}
+
+func (v *validator) box(path string, b *messaging_api.FlexBox) {
+	if v.depth == MaxBoxDepth {
+		v.add(path, "boxes must not be nested more than %d deep", MaxBoxDepth)
+		return
+	}
+	v.depth++
+	defer func() { v.depth-- }()
+	allowed, ok := allowedChildren[b.Layout]
+	if !ok {
+		v.add(join(path, "layout"), "must be horizontal, vertical or baseline, got %q", b.Layout)
+	}
+	contents := join(path, "contents")
+	for i, child := range b.Contents {
+		childPath := index(contents, i)
+		if t := componentType(child); ok && !slices.Contains(allowed, t) {
+			v.add(childPath, "%s is not allowed in a %s box", t, b.Layout)
+			continue
+		}
+		v.component(childPath, child)
+	}
+	v.flex(join(path, "flex"), b.Flex)
+	v.size(join(path, "spacing"), b.Spacing, spacingKeywords, true, false)
+	v.size(join(path, "margin"), b.Margin, spacingKeywords, true, false)
+	v.enum(join(path, "position"), string(b.Position), "relative", "absolute")
+	v.offsets(path, b.OffsetTop, b.OffsetBottom, b.OffsetStart, b.OffsetEnd)
+	v.color(join(path, "backgroundColor"), b.BackgroundColor)
+	v.color(join(path, "borderColor"), b.BorderColor)
+	v.size(join(path, "borderWidth"), b.BorderWidth, []string{"none", "light", "normal", "medium", "semi-bold", "bold"}, true, false)
+	v.size(join(path, "cornerRadius"), b.CornerRadius, spacingKeywords, true, false)
+	for _, p := range []property{{"width", b.Width}, {"maxWidth", b.MaxWidth}, {"height", b.Height}, {"maxHeight", b.MaxHeight}} {
+		v.size(join(path, p.name), p.value, nil, true, true)
+	}
+	for _, p := range []property{
+		{"paddingAll", b.PaddingAll}, {"paddingTop", b.PaddingTop}, {"paddingBottom", b.PaddingBottom},
+		{"paddingStart", b.PaddingStart}, {"paddingEnd", b.PaddingEnd},
+	} {
+		v.size(join(path, p.name), p.value, spacingKeywords, true, true)
+	}
+	v.enum(join(path, "justifyContent"), string(b.JustifyContent),
+		"center", "flex-start", "flex-end", "space-between", "space-around", "space-evenly")
+	v.enum(join(path, "alignItems"), string(b.AlignItems), "center", "flex-start", "flex-end")
+	if b.Background != nil {
+		background := join(path, "background")
+		if g, ok := as[messaging_api.FlexBoxLinearGradient](b.Background); ok {
+			if !anglePattern.MatchString(g.Angle) {
+				v.add(join(background, "angle"), "must be in degrees, such as 90deg, got %q", g.Angle)
+			}
+			v.requiredColor(join(background, "startColor"), g.StartColor)
+			v.requiredColor(join(background, "endColor"), g.EndColor)
+			v.color(join(background, "centerColor"), g.CenterColor)
+			v.size(join(background, "centerPosition"), g.CenterPosition, nil, false, true)
+		} else {
+			v.add(join(background, "type"), "unknown background type %q", b.Background.GetType())
+		}
+	}
+}
+
+func (v *validator) component(path string, c messaging_api.FlexComponentInterface) {
+	if b, ok := as[messaging_api.FlexBox](c); ok {
+		v.box(path, b)
+	} else if t, ok := as[messaging_api.FlexText](c); ok {
+		v.text(path, t)
+	} else if b, ok := as[messaging_api.FlexButton](c); ok {
+		if b.Action == nil {
+			v.add(join(path, "action"), "must not be empty")
+		}
+		v.flex(join(path, "flex"), b.Flex)
+		v.color(join(path, "color"), b.Color)
+		v.enum(join(path, "style"), string(b.Style), "primary", "secondary", "link")
+		v.enum(join(path, "gravity"), string(b.Gravity), "top", "bottom", "center")
+		v.enum(join(path, "height"), string(b.Height), "sm", "md")
+		v.size(join(path, "margin"), b.Margin, spacingKeywords, true, false)
+		v.enum(join(path, "position"), string(b.Position), "relative", "absolute")
+		v.offsets(path, b.OffsetTop, b.OffsetBottom, b.OffsetStart, b.OffsetEnd)
+	} else if i, ok := as[messaging_api.FlexImage](c); ok {
+		v.url(join(path, "url"), i.Url)
+		v.flex(join(path, "flex"), i.Flex)
+		v.size(join(path, "size"), i.Size, append(slices.Clone(sizeKeywords), "full"), true, true)
+		v.aspectRatio(join(path, "aspectRatio"), i.AspectRatio)
+		v.enum(join(path, "aspectMode"), string(i.AspectMode), "fit", "cover")
+		v.enum(join(path, "align"), string(i.Align), "start", "end", "center")
+		v.enum(join(path, "gravity"), string(i.Gravity), "top", "bottom", "center")
+		v.color(join(path, "backgroundColor"), i.BackgroundColor)
+		v.size(join(path, "margin"), i.Margin, spacingKeywords, true, false)
+		v.enum(join(path, "position"), string(i.Position), "relative", "absolute")
+		v.offsets(path, i.OffsetTop, i.OffsetBottom, i.OffsetStart, i.OffsetEnd)
+	} else if i, ok := as[messaging_api.FlexIcon](c); ok {
+		v.url(join(path, "url"), i.Url)
+		v.size(join(path, "size"), i.Size, sizeKeywords, true, false)
+		v.aspectRatio(join(path, "aspectRatio"), i.AspectRatio)
+		v.size(join(path, "margin"), i.Margin, spacingKeywords, true, false)
+		v.enum(join(path, "position"), string(i.Position), "relative", "absolute")
+		v.offsets(path, i.OffsetTop, i.OffsetBottom, i.OffsetStart, i.OffsetEnd)
+	} else if s, ok := as[messaging_api.FlexSeparator](c); ok {
+		v.size(join(path, "margin"), s.Margin, spacingKeywords, true, false)
+		v.color(join(path, "color"), s.Color)
+	} else if f, ok := as[messaging_api.FlexFiller](c); ok {
+		v.flex(join(path, "flex"), f.Flex)
+	} else if video, ok := as[messaging_api.FlexVideo](c); ok {
+		v.url(join(path, "url"), video.Url)
+		v.url(join(path, "previewUrl"), video.PreviewUrl)
+		v.aspectRatio(join(path, "aspectRatio"), video.AspectRatio)
+		altContent := join(path, "altContent")
+		switch componentType(video.AltContent) {
+		case "box", "image":
+			v.component(altContent, video.AltContent)
+		default:
+			v.add(altContent, "must be a box or an image, got %s", componentType(video.AltContent))
+		}
+	} else if _, ok := c.(*templateComponent); ok {
+		v.add(path, "Each and If are only allowed in templates")
+	} else if c == nil {
+		v.add(path, "must not be empty")
+	} else {
+		v.add(join(path, "type"), "unknown component type %q", c.GetType())
+	}
+}
+
+func (v *validator) text(path string, t *messaging_api.FlexText) {
+	if t.Text == "" && len(t.Contents) == 0 {
+		v.add(join(path, "text"), "must not be empty")
+	}
+	v.flex(join(path, "flex"), t.Flex)
+	v.size(join(path, "size"), t.Size, sizeKeywords, true, false)
+	v.enum(join(path, "align"), string(t.Align), "start", "end", "center")
+	v.enum(join(path, "gravity"), string(t.Gravity), "top", "bottom", "center")
+	v.color(join(path, "color"), t.Color)
+	v.enum(join(path, "weight"), string(t.Weight), "regular", "bold")
+	v.enum(join(path, "style"), string(t.Style), "normal", "italic")
+	v.enum(join(path, "decoration"), string(t.Decoration), "none", "underline", "line-through")
+	v.size(join(path, "lineSpacing"), t.LineSpacing, nil, true, false)
+	v.size(join(path, "margin"), t.Margin, spacingKeywords, true, false)
+	v.enum(join(path, "position"), string(t.Position), "relative", "absolute")
+	v.offsets(path, t.OffsetTop, t.OffsetBottom, t.OffsetStart, t.OffsetEnd)
+	if t.MaxLines < 0 {
+		v.add(join(path, "maxLines"), "must not be negative")
+	}
+	v.enum(join(path, "adjustMode"), string(t.AdjustMode), "shrink-to-fit")
+	contents := join(path, "contents")
+	for i, span := range t.Contents {
+		spanPath := index(contents, i)
+		v.size(join(spanPath, "size"), span.Size, sizeKeywords, true, false)
+		v.color(join(spanPath, "color"), span.Color)
+		v.enum(join(spanPath, "weight"), string(span.Weight), "regular", "bold")
+		v.enum(join(spanPath, "style"), string(span.Style), "normal", "italic")
+		v.enum(join(spanPath, "decoration"), string(span.Decoration), "none", "underline", "line-through")
+	}
+}
+
+var (
+	sizeKeywords    = []string{"xxs", "xs", "sm", "md", "lg", "xl", "xxl", "3xl", "4xl", "5xl"}
+	spacingKeywords = []string{"none", "xs", "sm", "md", "lg", "xl", "xxl"}
+
+	pixelPattern       = regexp.MustCompile(`^\d+(\.\d+)?px$`)
+	percentPattern     = regexp.MustCompile(`^\d+(\.\d+)?%$`)
+	colorPattern       = regexp.MustCompile(`^#([0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+	aspectRatioPattern = regexp.MustCompile(`^(\d+):(\d+)$`)
+	anglePattern       = regexp.MustCompile(`^-?\d+(\.\d+)?deg$`)
+)
+
+type property struct {
+	name  string
+	value string
+}
+
+// size checks that value is empty, one of keywords, or, when allowed, in pixels or a percentage.
+func (v *validator) size(path, value string, keywords []string, px, percent bool) {
+	if value == "" || slices.Contains(keywords, value) ||
+		(px && pixelPattern.MatchString(value)) || (percent && percentPattern.MatchString(value)) {
+		return
+	}
+	var formats []string
+	if len(keywords) > 0 {
+		formats = append(formats, "one of "+strings.Join(keywords, ", "))
+	}
+	if px {
+		formats = append(formats, "pixels such as 10px")
+	}
+	if percent {
+		formats = append(formats, "a percentage such as 10%")
+	}
+	v.add(path, "must be %s, got %q", strings.Join(formats, " or "), value)
+}
+
+func (v *validator) offsets(path, top, bottom, start, end string) {
+	for _, p := range []property{{"offsetTop", top}, {"offsetBottom", bottom}, {"offsetStart", start}, {"offsetEnd", end}} {
+		v.size(join(path, p.name), p.value, spacingKeywords, true, true)
+	}
+}
+
+func (v *validator) enum(path, value string, values ...string) {
+	if value != "" && !slices.Contains(values, value) {
+		v.add(path, "must be one of %s, got %q", strings.Join(values, ", "), value)
+	}
+}
+
+func (v *validator) flex(path string, flex int32) {
+	if flex < 0 {
+		v.add(path, "must not be negative")
+	}
+}
+
+func (v *validator) color(path, value string) {
+	if value != "" && !colorPattern.MatchString(value) {
+		v.add(path, "must be a hexadecimal color code such as #RRGGBB or #RRGGBBAA, got %q", value)
+	}
+}
+
+func (v *validator) requiredColor(path, value string) {
+	if value == "" {
+		v.add(path, "must not be empty")
+		return
+	}
+	v.color(path, value)
+}
+
+func (v *validator) url(path, value string) {
+	if value == "" {
+		v.add(path, "must not be empty")
+	} else if !strings.HasPrefix(value, "https://") {
+		v.add(path, "must be an HTTPS URL, got %q", value)
+	} else if len(value) > 2000 {
+		v.add(path, "must be at most 2000 characters")
+	}
+}
+
+func (v *validator) aspectRatio(path, value string) {
+	if value == "" {
+		return
+	}
+	m := aspectRatioPattern.FindStringSubmatch(value)
+	if m == nil {
+		v.add(path, "must be {width}:{height}, got %q", value)
+		return
+	}
+	width, _ := strconv.Atoi(m[1])
+	height, _ := strconv.Atoi(m[2])
+	if width < 1 || width > 100000 || height < 1 || height > 100000 {
+		v.add(path, "width and height must be from 1 to 100000, got %q", value)
+	} else if height > 3*width {
+		v.add(path, "height must be at most three times the width, got %q", value)
+	}
+}
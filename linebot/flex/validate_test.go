@@ -0,0 +1,129 @@
+package flex
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		container messaging_api.FlexContainerInterface
+		want      string
+	}{
+		{
+			name: "valid sizes",
+			container: &messaging_api.FlexBubble{Body: &messaging_api.FlexBox{
+				Layout:       messaging_api.FlexBoxLAYOUT_VERTICAL,
+				PaddingAll:   "12.5px",
+				Width:        "50%",
+				CornerRadius: "md",
+				BorderWidth:  "semi-bold",
+				Contents: []messaging_api.FlexComponentInterface{
+					messaging_api.FlexText{Text: "a", Size: "3xl"},
+					messaging_api.FlexImage{Url: "https://example.com/a.png", Size: "full", AspectRatio: "1:3"},
+				},
+			}},
+		},
+		{
+			name: "width in keywords",
+			container: &messaging_api.FlexBubble{Body: &messaging_api.FlexBox{
+				Layout: messaging_api.FlexBoxLAYOUT_VERTICAL,
+				Width:  "md",
+			}},
+			want: "body.width: must be pixels such as 10px or a percentage such as 10%",
+		},
+		{
+			name: "missing layout",
+			container: &messaging_api.FlexBubble{Header: &messaging_api.FlexBox{
+				Contents: []messaging_api.FlexComponentInterface{},
+			}},
+			want: `header.layout: must be horizontal, vertical or baseline, got ""`,
+		},
+		{
+			name: "text in hero",
+			container: &messaging_api.FlexBubble{
+				Hero: &messaging_api.FlexText{Text: "a"},
+			},
+			want: "hero: must be a box, an image or a video, got text",
+		},
+		{
+			name: "insecure image",
+			container: &messaging_api.FlexBubble{
+				Hero: &messaging_api.FlexImage{Url: "http://example.com/a.png"},
+			},
+			want: `hero.url: must be an HTTPS URL`,
+		},
+		{
+			name: "tall aspect ratio",
+			container: &messaging_api.FlexBubble{
+				Hero: &messaging_api.FlexImage{Url: "https://example.com/a.png", AspectRatio: "1:4"},
+			},
+			want: "hero.aspectRatio: height must be at most three times the width",
+		},
+		{
+			name: "separator in baseline box",
+			container: &messaging_api.FlexCarousel{Contents: []messaging_api.FlexBubble{
+				{Body: &messaging_api.FlexBox{
+					Layout:   messaging_api.FlexBoxLAYOUT_BASELINE,
+					Contents: []messaging_api.FlexComponentInterface{&messaging_api.FlexSeparator{}},
+				}},
+			}},
+			want: "contents[0].body.contents[0]: separator is not allowed in a baseline box",
+		},
+		{
+			name:      "empty carousel",
+			container: &messaging_api.FlexCarousel{},
+			want:      "contents: must have 1 to 12 bubbles, got 0",
+		},
+		{
+			name:      "unknown container",
+			container: messaging_api.UnknownFlexContainer{Type: "foo"},
+			want:      `type: unknown container type "foo"`,
+		},
+		{
+			name: "gradient",
+			container: &messaging_api.FlexBubble{Body: &messaging_api.FlexBox{
+				Layout:     messaging_api.FlexBoxLAYOUT_VERTICAL,
+				Background: &messaging_api.FlexBoxLinearGradient{Angle: "90", StartColor: "#000000", EndColor: "#ffffff"},
+			}},
+			want: "body.background.angle: must be in degrees",
+		},
+		{
+			name:      "boxes nested within the limit",
+			container: &messaging_api.FlexBubble{Body: nestedBoxes(MaxBoxDepth)},
+		},
+		{
+			name:      "boxes nested too deep",
+			container: &messaging_api.FlexBubble{Body: nestedBoxes(MaxBoxDepth + 1)},
+			want:      "body" + strings.Repeat(".contents[0]", MaxBoxDepth) + ": boxes must not be nested more than 10 deep",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.container)
+			if tc.want == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("got %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+// nestedBoxes returns depth boxes nested in one another, the innermost holding a text.
+func nestedBoxes(depth int) *messaging_api.FlexBox {
+	box := &messaging_api.FlexBox{
+		Layout:   messaging_api.FlexBoxLAYOUT_VERTICAL,
+		Contents: []messaging_api.FlexComponentInterface{&messaging_api.FlexText{Text: "a"}},
+	}
+	for i := 1; i < depth; i++ {
+		box = &messaging_api.FlexBox{Layout: messaging_api.FlexBoxLAYOUT_VERTICAL, Contents: []messaging_api.FlexComponentInterface{box}}
+	}
+	return box
+}
@@ -0,0 +1,81 @@
+/**
+ * LINE Messaging API(Insight)
+ * This document describes LINE Messaging API(Insight).
+ *
+ * The version of the OpenAPI document: 0.0.1
+ *
+ *
+ * NOTE: This class is auto generated by OpenAPI Generator (https://openapi-generator.tech).
+ * https://openapi-generator.tech
+ * Do not edit the class manually.
+ */
+
+/**
+ * NOTE: This class is auto generated by OpenAPI Generator (https://openapi-generator.tech).
+ * https://openapi-generator.tech
+ * Do not edit the class manually.
+ */
+
+//go:generate python3 ../../generate-code.py
+package insight
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError is returned when the LINE Platform responds with a non-2xx status code.
+// Use errors.As to retrieve it from the error returned by an API method.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// RequestID is the value of the x-line-request-id response header.
+	RequestID string
+
+	// Response is the decoded response body, or nil if the body is not a valid ErrorResponse.
+	Response *ErrorResponse
+
+	// Body is the raw response body.
+	Body []byte
+}
+
+func newAPIError(res *http.Response, body []byte) *APIError {
+	apiError := &APIError{
+		StatusCode: res.StatusCode,
+		RequestID:  res.Header.Get("x-line-request-id"),
+		Body:       body,
+	}
+	var errorResponse ErrorResponse
+	if err := json.Unmarshal(body, &errorResponse); err == nil {
+		apiError.Response = &errorResponse
+	}
+	return apiError
+}
+
+// Error method
+func (e *APIError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d, %s", e.StatusCode, string(e.Body))
+}
+
+// IsRateLimited reports whether err is an APIError with status code 429.
+func IsRateLimited(err error) bool {
+	return hasStatusCode(err, http.StatusTooManyRequests)
+}
+
+// IsNotFound reports whether err is an APIError with status code 404.
+func IsNotFound(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is an APIError with status code 409.
+func IsConflict(err error) bool {
+	return hasStatusCode(err, http.StatusConflict)
+}
+
+func hasStatusCode(err error, statusCode int) bool {
+	var apiError *APIError
+	return errors.As(err, &apiError) && apiError.StatusCode == statusCode
+}
@@ -33,24 +33,27 @@ import (
 	"strings"
 
 	"github.com/line/line-bot-sdk-go/v8/linebot"
+	"github.com/line/line-bot-sdk-go/v8/linebot/channel_access_token"
+	"github.com/line/line-bot-sdk-go/v8/linebot/ratelimit"
+	"github.com/line/line-bot-sdk-go/v8/linebot/retry"
 )
 
 type InsightAPI struct {
 	httpClient   *http.Client
 	endpoint     *url.URL
 	channelToken string
+	tokenSource  channel_access_token.TokenSource
 	ctx          context.Context
+	retryPolicy  *retry.Policy
+	rateLimiter  *ratelimit.Limiter
 }
 
 // InsightAPIOption type
 type InsightAPIOption func(*InsightAPI) error
 
 // New returns a new bot client instance.
+// channelToken may be empty if a TokenSource is given with WithTokenSource.
 func NewInsightAPI(channelToken string, options ...InsightAPIOption) (*InsightAPI, error) {
-	if channelToken == "" {
-		return nil, errors.New("missing channel access token")
-	}
-
 	c := &InsightAPI{
 		channelToken: channelToken,
 		httpClient:   http.DefaultClient,
@@ -68,24 +71,61 @@ func NewInsightAPI(channelToken string, options ...InsightAPIOption) (*InsightAP
 			return nil, err
 		}
 	}
+	if c.channelToken == "" && c.tokenSource == nil {
+		return nil, errors.New("missing channel access token")
+	}
 	return c, nil
 }
 
 // WithContext method
+// The context is stored on the client and shared by every call made through it.
+// To use a different context for each call, use the methods with the Ctx suffix instead.
 func (call *InsightAPI) WithContext(ctx context.Context) *InsightAPI {
 	call.ctx = ctx
 	return call
 }
 
 func (client *InsightAPI) Do(req *http.Request) (*http.Response, error) {
-	if client.channelToken != "" {
+	if client.ctx != nil {
+		req = req.WithContext(client.ctx)
+	}
+	return client.do(req)
+}
+
+// do sends req with the context it already carries.
+func (client *InsightAPI) do(req *http.Request) (*http.Response, error) {
+	if client.retryPolicy != nil {
+		return client.retryPolicy.Do(req, client.send)
+	}
+	return client.send(req)
+}
+
+// send sends a single attempt of req.
+func (client *InsightAPI) send(req *http.Request) (*http.Response, error) {
+	if client.rateLimiter != nil {
+		if err := client.rateLimiter.Wait(req); err != nil {
+			return nil, err
+		}
+	}
+	if client.tokenSource != nil {
+		token, err := client.tokenSource.Token(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else if client.channelToken != "" {
 		req.Header.Set("Authorization", "Bearer "+client.channelToken)
 	}
 	req.Header.Set("User-Agent", "LINE-BotSDK-Go/"+linebot.GetVersion())
+	return client.httpClient.Do(req)
+}
+
+// requestContext returns the context set by WithContext, or context.Background if there is none.
+func (client *InsightAPI) requestContext() context.Context {
 	if client.ctx != nil {
-		req = req.WithContext(client.ctx)
+		return client.ctx
 	}
-	return client.httpClient.Do(req)
+	return context.Background()
 }
 
 func (client *InsightAPI) Url(endpointPath string) string {
@@ -103,6 +143,34 @@ func WithHTTPClient(c *http.Client) InsightAPIOption {
 	}
 }
 
+// WithTokenSource function
+// Every request uses a token obtained from ts instead of the channel access token given to NewInsightAPI.
+func WithTokenSource(ts channel_access_token.TokenSource) InsightAPIOption {
+	return func(client *InsightAPI) error {
+		client.tokenSource = ts
+		return nil
+	}
+}
+
+// WithRetryPolicy function
+// Failed requests are retried according to policy. See the retry package for which requests are retried.
+func WithRetryPolicy(policy *retry.Policy) InsightAPIOption {
+	return func(client *InsightAPI) error {
+		client.retryPolicy = policy
+		return nil
+	}
+}
+
+// WithRateLimiter function
+// Every request, including retries, waits for limiter before it is sent.
+// Share one limiter between the clients of the same channel.
+func WithRateLimiter(limiter *ratelimit.Limiter) InsightAPIOption {
+	return func(client *InsightAPI) error {
+		client.rateLimiter = limiter
+		return nil
+	}
+}
+
 // WithEndpoint function
 func WithEndpoint(endpoint string) InsightAPIOption {
 	return func(client *InsightAPI) error {
@@ -126,6 +194,17 @@ func (client *InsightAPI) GetFriendsDemographics() (*GetFriendsDemographicsRespo
 	return body, error
 }
 
+// GetFriendsDemographicsCtx
+// The same as GetFriendsDemographics, but the request uses ctx instead of the context set by WithContext.
+func (client *InsightAPI) GetFriendsDemographicsCtx(
+	ctx context.Context,
+) (*GetFriendsDemographicsResponse, error) {
+	_, body, error := client.GetFriendsDemographicsWithHttpInfoCtx(
+		ctx,
+	)
+	return body, error
+}
+
 // GetFriendsDemographics
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -134,14 +213,24 @@ func (client *InsightAPI) GetFriendsDemographics() (*GetFriendsDemographicsRespo
 
 // https://developers.line.biz/en/reference/messaging-api/#get-demographic
 func (client *InsightAPI) GetFriendsDemographicsWithHttpInfo() (*http.Response, *GetFriendsDemographicsResponse, error) {
+	return client.GetFriendsDemographicsWithHttpInfoCtx(
+		client.requestContext(),
+	)
+}
+
+// GetFriendsDemographicsWithHttpInfoCtx
+// The same as GetFriendsDemographicsWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *InsightAPI) GetFriendsDemographicsWithHttpInfoCtx(
+	ctx context.Context,
+) (*http.Response, *GetFriendsDemographicsResponse, error) {
 	path := "/v2/bot/insight/demographic"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -154,7 +243,7 @@ func (client *InsightAPI) GetFriendsDemographicsWithHttpInfo() (*http.Response,
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -187,6 +276,20 @@ func (client *InsightAPI) GetMessageEvent(
 	return body, error
 }
 
+// GetMessageEventCtx
+// The same as GetMessageEvent, but the request uses ctx instead of the context set by WithContext.
+func (client *InsightAPI) GetMessageEventCtx(
+	ctx context.Context,
+	requestId string,
+
+) (*GetMessageEventResponse, error) {
+	_, body, error := client.GetMessageEventWithHttpInfoCtx(
+		ctx,
+		requestId,
+	)
+	return body, error
+}
+
 // GetMessageEvent
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 // Get user interaction statistics
@@ -199,10 +302,23 @@ func (client *InsightAPI) GetMessageEventWithHttpInfo(
 
 	requestId string,
 
+) (*http.Response, *GetMessageEventResponse, error) {
+	return client.GetMessageEventWithHttpInfoCtx(
+		client.requestContext(),
+		requestId,
+	)
+}
+
+// GetMessageEventWithHttpInfoCtx
+// The same as GetMessageEventWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *InsightAPI) GetMessageEventWithHttpInfoCtx(
+	ctx context.Context,
+	requestId string,
+
 ) (*http.Response, *GetMessageEventResponse, error) {
 	path := "/v2/bot/insight/message/event"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -212,7 +328,7 @@ func (client *InsightAPI) GetMessageEventWithHttpInfo(
 
 	req.URL.RawQuery = query.Encode()
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -225,7 +341,7 @@ func (client *InsightAPI) GetMessageEventWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -258,6 +374,20 @@ func (client *InsightAPI) GetNumberOfFollowers(
 	return body, error
 }
 
+// GetNumberOfFollowersCtx
+// The same as GetNumberOfFollowers, but the request uses ctx instead of the context set by WithContext.
+func (client *InsightAPI) GetNumberOfFollowersCtx(
+	ctx context.Context,
+	date string,
+
+) (*GetNumberOfFollowersResponse, error) {
+	_, body, error := client.GetNumberOfFollowersWithHttpInfoCtx(
+		ctx,
+		date,
+	)
+	return body, error
+}
+
 // GetNumberOfFollowers
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 // Get number of followers
@@ -270,10 +400,23 @@ func (client *InsightAPI) GetNumberOfFollowersWithHttpInfo(
 
 	date string,
 
+) (*http.Response, *GetNumberOfFollowersResponse, error) {
+	return client.GetNumberOfFollowersWithHttpInfoCtx(
+		client.requestContext(),
+		date,
+	)
+}
+
+// GetNumberOfFollowersWithHttpInfoCtx
+// The same as GetNumberOfFollowersWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *InsightAPI) GetNumberOfFollowersWithHttpInfoCtx(
+	ctx context.Context,
+	date string,
+
 ) (*http.Response, *GetNumberOfFollowersResponse, error) {
 	path := "/v2/bot/insight/followers"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -285,7 +428,7 @@ func (client *InsightAPI) GetNumberOfFollowersWithHttpInfo(
 
 	req.URL.RawQuery = query.Encode()
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -298,7 +441,7 @@ func (client *InsightAPI) GetNumberOfFollowersWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -331,6 +474,20 @@ func (client *InsightAPI) GetNumberOfMessageDeliveries(
 	return body, error
 }
 
+// GetNumberOfMessageDeliveriesCtx
+// The same as GetNumberOfMessageDeliveries, but the request uses ctx instead of the context set by WithContext.
+func (client *InsightAPI) GetNumberOfMessageDeliveriesCtx(
+	ctx context.Context,
+	date string,
+
+) (*GetNumberOfMessageDeliveriesResponse, error) {
+	_, body, error := client.GetNumberOfMessageDeliveriesWithHttpInfoCtx(
+		ctx,
+		date,
+	)
+	return body, error
+}
+
 // GetNumberOfMessageDeliveries
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 // Get number of message deliveries
@@ -343,10 +500,23 @@ func (client *InsightAPI) GetNumberOfMessageDeliveriesWithHttpInfo(
 
 	date string,
 
+) (*http.Response, *GetNumberOfMessageDeliveriesResponse, error) {
+	return client.GetNumberOfMessageDeliveriesWithHttpInfoCtx(
+		client.requestContext(),
+		date,
+	)
+}
+
+// GetNumberOfMessageDeliveriesWithHttpInfoCtx
+// The same as GetNumberOfMessageDeliveriesWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *InsightAPI) GetNumberOfMessageDeliveriesWithHttpInfoCtx(
+	ctx context.Context,
+	date string,
+
 ) (*http.Response, *GetNumberOfMessageDeliveriesResponse, error) {
 	path := "/v2/bot/insight/message/delivery"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -356,7 +526,7 @@ func (client *InsightAPI) GetNumberOfMessageDeliveriesWithHttpInfo(
 
 	req.URL.RawQuery = query.Encode()
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -369,7 +539,7 @@ func (client *InsightAPI) GetNumberOfMessageDeliveriesWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -412,6 +582,28 @@ func (client *InsightAPI) GetRichMenuInsightDaily(
 	return body, error
 }
 
+// GetRichMenuInsightDailyCtx
+// The same as GetRichMenuInsightDaily, but the request uses ctx instead of the context set by WithContext.
+func (client *InsightAPI) GetRichMenuInsightDailyCtx(
+	ctx context.Context,
+	richMenuId string,
+
+	from string,
+
+	to string,
+
+) (*GetRichMenuInsightDailyResponse, error) {
+	_, body, error := client.GetRichMenuInsightDailyWithHttpInfoCtx(
+		ctx,
+		richMenuId,
+
+		from,
+
+		to,
+	)
+	return body, error
+}
+
 // GetRichMenuInsightDaily
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 // Get rich menu insight daily
@@ -430,12 +622,33 @@ func (client *InsightAPI) GetRichMenuInsightDailyWithHttpInfo(
 
 	to string,
 
+) (*http.Response, *GetRichMenuInsightDailyResponse, error) {
+	return client.GetRichMenuInsightDailyWithHttpInfoCtx(
+		client.requestContext(),
+		richMenuId,
+
+		from,
+
+		to,
+	)
+}
+
+// GetRichMenuInsightDailyWithHttpInfoCtx
+// The same as GetRichMenuInsightDailyWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *InsightAPI) GetRichMenuInsightDailyWithHttpInfoCtx(
+	ctx context.Context,
+	richMenuId string,
+
+	from string,
+
+	to string,
+
 ) (*http.Response, *GetRichMenuInsightDailyResponse, error) {
 	path := "/v2/bot/insight/richmenu/{richMenuId}/daily"
 
 	path = strings.Replace(path, "{richMenuId}", richMenuId, -1)
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -446,7 +659,7 @@ func (client *InsightAPI) GetRichMenuInsightDailyWithHttpInfo(
 
 	req.URL.RawQuery = query.Encode()
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -459,7 +672,7 @@ func (client *InsightAPI) GetRichMenuInsightDailyWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -502,6 +715,28 @@ func (client *InsightAPI) GetRichMenuInsightSummary(
 	return body, error
 }
 
+// GetRichMenuInsightSummaryCtx
+// The same as GetRichMenuInsightSummary, but the request uses ctx instead of the context set by WithContext.
+func (client *InsightAPI) GetRichMenuInsightSummaryCtx(
+	ctx context.Context,
+	richMenuId string,
+
+	from string,
+
+	to string,
+
+) (*GetRichMenuInsightSummaryResponse, error) {
+	_, body, error := client.GetRichMenuInsightSummaryWithHttpInfoCtx(
+		ctx,
+		richMenuId,
+
+		from,
+
+		to,
+	)
+	return body, error
+}
+
 // GetRichMenuInsightSummary
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 // Get rich menu insight summary
@@ -520,12 +755,33 @@ func (client *InsightAPI) GetRichMenuInsightSummaryWithHttpInfo(
 
 	to string,
 
+) (*http.Response, *GetRichMenuInsightSummaryResponse, error) {
+	return client.GetRichMenuInsightSummaryWithHttpInfoCtx(
+		client.requestContext(),
+		richMenuId,
+
+		from,
+
+		to,
+	)
+}
+
+// GetRichMenuInsightSummaryWithHttpInfoCtx
+// The same as GetRichMenuInsightSummaryWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *InsightAPI) GetRichMenuInsightSummaryWithHttpInfoCtx(
+	ctx context.Context,
+	richMenuId string,
+
+	from string,
+
+	to string,
+
 ) (*http.Response, *GetRichMenuInsightSummaryResponse, error) {
 	path := "/v2/bot/insight/richmenu/{richMenuId}/summary"
 
 	path = strings.Replace(path, "{richMenuId}", richMenuId, -1)
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -536,7 +792,7 @@ func (client *InsightAPI) GetRichMenuInsightSummaryWithHttpInfo(
 
 	req.URL.RawQuery = query.Encode()
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -549,7 +805,7 @@ func (client *InsightAPI) GetRichMenuInsightSummaryWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -592,6 +848,28 @@ func (client *InsightAPI) GetStatisticsPerUnit(
 	return body, error
 }
 
+// GetStatisticsPerUnitCtx
+// The same as GetStatisticsPerUnit, but the request uses ctx instead of the context set by WithContext.
+func (client *InsightAPI) GetStatisticsPerUnitCtx(
+	ctx context.Context,
+	customAggregationUnit string,
+
+	from string,
+
+	to string,
+
+) (*GetStatisticsPerUnitResponse, error) {
+	_, body, error := client.GetStatisticsPerUnitWithHttpInfoCtx(
+		ctx,
+		customAggregationUnit,
+
+		from,
+
+		to,
+	)
+	return body, error
+}
+
 // GetStatisticsPerUnit
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -610,10 +888,31 @@ func (client *InsightAPI) GetStatisticsPerUnitWithHttpInfo(
 
 	to string,
 
+) (*http.Response, *GetStatisticsPerUnitResponse, error) {
+	return client.GetStatisticsPerUnitWithHttpInfoCtx(
+		client.requestContext(),
+		customAggregationUnit,
+
+		from,
+
+		to,
+	)
+}
+
+// GetStatisticsPerUnitWithHttpInfoCtx
+// The same as GetStatisticsPerUnitWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *InsightAPI) GetStatisticsPerUnitWithHttpInfoCtx(
+	ctx context.Context,
+	customAggregationUnit string,
+
+	from string,
+
+	to string,
+
 ) (*http.Response, *GetStatisticsPerUnitResponse, error) {
 	path := "/v2/bot/insight/message/event/aggregation"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -625,7 +924,7 @@ func (client *InsightAPI) GetStatisticsPerUnitWithHttpInfo(
 
 	req.URL.RawQuery = query.Encode()
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -638,7 +937,7 @@ func (client *InsightAPI) GetStatisticsPerUnitWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -33,24 +33,27 @@ import (
 	"strings"
 
 	"github.com/line/line-bot-sdk-go/v8/linebot"
+	"github.com/line/line-bot-sdk-go/v8/linebot/channel_access_token"
+	"github.com/line/line-bot-sdk-go/v8/linebot/ratelimit"
+	"github.com/line/line-bot-sdk-go/v8/linebot/retry"
 )
 
 type LiffAPI struct {
 	httpClient   *http.Client
 	endpoint     *url.URL
 	channelToken string
+	tokenSource  channel_access_token.TokenSource
 	ctx          context.Context
+	retryPolicy  *retry.Policy
+	rateLimiter  *ratelimit.Limiter
 }
 
 // LiffAPIOption type
 type LiffAPIOption func(*LiffAPI) error
 
 // New returns a new bot client instance.
+// channelToken may be empty if a TokenSource is given with WithTokenSource.
 func NewLiffAPI(channelToken string, options ...LiffAPIOption) (*LiffAPI, error) {
-	if channelToken == "" {
-		return nil, errors.New("missing channel access token")
-	}
-
 	c := &LiffAPI{
 		channelToken: channelToken,
 		httpClient:   http.DefaultClient,
@@ -68,24 +71,61 @@ func NewLiffAPI(channelToken string, options ...LiffAPIOption) (*LiffAPI, error)
 			return nil, err
 		}
 	}
+	if c.channelToken == "" && c.tokenSource == nil {
+		return nil, errors.New("missing channel access token")
+	}
 	return c, nil
 }
 
 // WithContext method
+// The context is stored on the client and shared by every call made through it.
+// To use a different context for each call, use the methods with the Ctx suffix instead.
 func (call *LiffAPI) WithContext(ctx context.Context) *LiffAPI {
 	call.ctx = ctx
 	return call
 }
 
 func (client *LiffAPI) Do(req *http.Request) (*http.Response, error) {
-	if client.channelToken != "" {
+	if client.ctx != nil {
+		req = req.WithContext(client.ctx)
+	}
+	return client.do(req)
+}
+
+// do sends req with the context it already carries.
+func (client *LiffAPI) do(req *http.Request) (*http.Response, error) {
+	if client.retryPolicy != nil {
+		return client.retryPolicy.Do(req, client.send)
+	}
+	return client.send(req)
+}
+
+// send sends a single attempt of req.
+func (client *LiffAPI) send(req *http.Request) (*http.Response, error) {
+	if client.rateLimiter != nil {
+		if err := client.rateLimiter.Wait(req); err != nil {
+			return nil, err
+		}
+	}
+	if client.tokenSource != nil {
+		token, err := client.tokenSource.Token(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else if client.channelToken != "" {
 		req.Header.Set("Authorization", "Bearer "+client.channelToken)
 	}
 	req.Header.Set("User-Agent", "LINE-BotSDK-Go/"+linebot.GetVersion())
+	return client.httpClient.Do(req)
+}
+
+// requestContext returns the context set by WithContext, or context.Background if there is none.
+func (client *LiffAPI) requestContext() context.Context {
 	if client.ctx != nil {
-		req = req.WithContext(client.ctx)
+		return client.ctx
 	}
-	return client.httpClient.Do(req)
+	return context.Background()
 }
 
 func (client *LiffAPI) Url(endpointPath string) string {
@@ -103,6 +143,34 @@ func WithHTTPClient(c *http.Client) LiffAPIOption {
 	}
 }
 
+// WithTokenSource function
+// Every request uses a token obtained from ts instead of the channel access token given to NewLiffAPI.
+func WithTokenSource(ts channel_access_token.TokenSource) LiffAPIOption {
+	return func(client *LiffAPI) error {
+		client.tokenSource = ts
+		return nil
+	}
+}
+
+// WithRetryPolicy function
+// Failed requests are retried according to policy. See the retry package for which requests are retried.
+func WithRetryPolicy(policy *retry.Policy) LiffAPIOption {
+	return func(client *LiffAPI) error {
+		client.retryPolicy = policy
+		return nil
+	}
+}
+
+// WithRateLimiter function
+// Every request, including retries, waits for limiter before it is sent.
+// Share one limiter between the clients of the same channel.
+func WithRateLimiter(limiter *ratelimit.Limiter) LiffAPIOption {
+	return func(client *LiffAPI) error {
+		client.rateLimiter = limiter
+		return nil
+	}
+}
+
 // WithEndpoint function
 func WithEndpoint(endpoint string) LiffAPIOption {
 	return func(client *LiffAPI) error {
@@ -134,6 +202,20 @@ func (client *LiffAPI) AddLIFFApp(
 	return body, error
 }
 
+// AddLIFFAppCtx
+// The same as AddLIFFApp, but the request uses ctx instead of the context set by WithContext.
+func (client *LiffAPI) AddLIFFAppCtx(
+	ctx context.Context,
+	addLiffAppRequest *AddLiffAppRequest,
+
+) (*AddLiffAppResponse, error) {
+	_, body, error := client.AddLIFFAppWithHttpInfoCtx(
+		ctx,
+		addLiffAppRequest,
+	)
+	return body, error
+}
+
 // AddLIFFApp
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 // Create LIFF app
@@ -146,6 +228,19 @@ func (client *LiffAPI) AddLIFFAppWithHttpInfo(
 
 	addLiffAppRequest *AddLiffAppRequest,
 
+) (*http.Response, *AddLiffAppResponse, error) {
+	return client.AddLIFFAppWithHttpInfoCtx(
+		client.requestContext(),
+		addLiffAppRequest,
+	)
+}
+
+// AddLIFFAppWithHttpInfoCtx
+// The same as AddLIFFAppWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *LiffAPI) AddLIFFAppWithHttpInfoCtx(
+	ctx context.Context,
+	addLiffAppRequest *AddLiffAppRequest,
+
 ) (*http.Response, *AddLiffAppResponse, error) {
 	path := "/liff/v1/apps"
 
@@ -154,13 +249,13 @@ func (client *LiffAPI) AddLIFFAppWithHttpInfo(
 	if err := enc.Encode(addLiffAppRequest); err != nil {
 		return nil, nil, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, nil, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -173,7 +268,7 @@ func (client *LiffAPI) AddLIFFAppWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -206,6 +301,20 @@ func (client *LiffAPI) DeleteLIFFApp(
 	return body, error
 }
 
+// DeleteLIFFAppCtx
+// The same as DeleteLIFFApp, but the request uses ctx instead of the context set by WithContext.
+func (client *LiffAPI) DeleteLIFFAppCtx(
+	ctx context.Context,
+	liffId string,
+
+) (struct{}, error) {
+	_, body, error := client.DeleteLIFFAppWithHttpInfoCtx(
+		ctx,
+		liffId,
+	)
+	return body, error
+}
+
 // DeleteLIFFApp
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 // Delete LIFF app from a channel
@@ -218,17 +327,30 @@ func (client *LiffAPI) DeleteLIFFAppWithHttpInfo(
 
 	liffId string,
 
+) (*http.Response, struct{}, error) {
+	return client.DeleteLIFFAppWithHttpInfoCtx(
+		client.requestContext(),
+		liffId,
+	)
+}
+
+// DeleteLIFFAppWithHttpInfoCtx
+// The same as DeleteLIFFAppWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *LiffAPI) DeleteLIFFAppWithHttpInfoCtx(
+	ctx context.Context,
+	liffId string,
+
 ) (*http.Response, struct{}, error) {
 	path := "/liff/v1/apps/{liffId}"
 
 	path = strings.Replace(path, "{liffId}", liffId, -1)
 
-	req, err := http.NewRequest(http.MethodDelete, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, client.Url(path), nil)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -241,7 +363,7 @@ func (client *LiffAPI) DeleteLIFFAppWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -261,6 +383,17 @@ func (client *LiffAPI) GetAllLIFFApps() (*GetAllLiffAppsResponse, error) {
 	return body, error
 }
 
+// GetAllLIFFAppsCtx
+// The same as GetAllLIFFApps, but the request uses ctx instead of the context set by WithContext.
+func (client *LiffAPI) GetAllLIFFAppsCtx(
+	ctx context.Context,
+) (*GetAllLiffAppsResponse, error) {
+	_, body, error := client.GetAllLIFFAppsWithHttpInfoCtx(
+		ctx,
+	)
+	return body, error
+}
+
 // GetAllLIFFApps
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 // Get all LIFF apps
@@ -269,14 +402,24 @@ func (client *LiffAPI) GetAllLIFFApps() (*GetAllLiffAppsResponse, error) {
 
 // https://developers.line.biz/en/reference/liff-server/#get-all-liff-apps
 func (client *LiffAPI) GetAllLIFFAppsWithHttpInfo() (*http.Response, *GetAllLiffAppsResponse, error) {
+	return client.GetAllLIFFAppsWithHttpInfoCtx(
+		client.requestContext(),
+	)
+}
+
+// GetAllLIFFAppsWithHttpInfoCtx
+// The same as GetAllLIFFAppsWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *LiffAPI) GetAllLIFFAppsWithHttpInfoCtx(
+	ctx context.Context,
+) (*http.Response, *GetAllLiffAppsResponse, error) {
 	path := "/liff/v1/apps"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -289,7 +432,7 @@ func (client *LiffAPI) GetAllLIFFAppsWithHttpInfo() (*http.Response, *GetAllLiff
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -327,6 +470,24 @@ func (client *LiffAPI) UpdateLIFFApp(
 	return body, error
 }
 
+// UpdateLIFFAppCtx
+// The same as UpdateLIFFApp, but the request uses ctx instead of the context set by WithContext.
+func (client *LiffAPI) UpdateLIFFAppCtx(
+	ctx context.Context,
+	liffId string,
+
+	updateLiffAppRequest *UpdateLiffAppRequest,
+
+) (struct{}, error) {
+	_, body, error := client.UpdateLIFFAppWithHttpInfoCtx(
+		ctx,
+		liffId,
+
+		updateLiffAppRequest,
+	)
+	return body, error
+}
+
 // UpdateLIFFApp
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 // Update LIFF app from a channel
@@ -342,6 +503,23 @@ func (client *LiffAPI) UpdateLIFFAppWithHttpInfo(
 
 	updateLiffAppRequest *UpdateLiffAppRequest,
 
+) (*http.Response, struct{}, error) {
+	return client.UpdateLIFFAppWithHttpInfoCtx(
+		client.requestContext(),
+		liffId,
+
+		updateLiffAppRequest,
+	)
+}
+
+// UpdateLIFFAppWithHttpInfoCtx
+// The same as UpdateLIFFAppWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *LiffAPI) UpdateLIFFAppWithHttpInfoCtx(
+	ctx context.Context,
+	liffId string,
+
+	updateLiffAppRequest *UpdateLiffAppRequest,
+
 ) (*http.Response, struct{}, error) {
 	path := "/liff/v1/apps/{liffId}"
 
@@ -352,13 +530,13 @@ func (client *LiffAPI) UpdateLIFFAppWithHttpInfo(
 	if err := enc.Encode(updateLiffAppRequest); err != nil {
 		return nil, struct{}{}, err
 	}
-	req, err := http.NewRequest(http.MethodPut, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, client.Url(path), &buf)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -371,7 +549,7 @@ func (client *LiffAPI) UpdateLIFFAppWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -0,0 +1,431 @@
+package linetest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+	"github.com/line/line-bot-sdk-go/v8/linebot/validation"
+)
+
+// Kind is the way messages were sent.
+type Kind string
+
+// Kind constants
+const (
+	KindPush       Kind = "push"
+	KindReply      Kind = "reply"
+	KindMulticast  Kind = "multicast"
+	KindBroadcast  Kind = "broadcast"
+	KindNarrowcast Kind = "narrowcast"
+)
+
+// Sent is a request that sent messages.
+type Sent struct {
+	Kind Kind
+	// To are the recipients of a push or a multicast, or the chat a reply
+	// token was issued for. It is empty for broadcasts and narrowcasts.
+	To         []string
+	ReplyToken string
+	Messages   []messaging_api.MessageInterface
+	// NotificationDisabled tells whether the users were not notified.
+	NotificationDisabled bool
+	// RetryKey is the X-Line-Retry-Key header of the request.
+	RetryKey  string
+	RequestId string
+	Time      time.Time
+}
+
+// MessageContent is the content of a message sent by a user, served by the blob endpoints.
+type MessageContent struct {
+	ContentType string
+	Data        []byte
+	// Preview is the preview image of an image or a video.
+	Preview []byte
+	// Transcoding is the status of the preparation of a video or an audio.
+	// While it is processing, the content cannot be downloaded. The default is succeeded.
+	Transcoding messaging_api.GetMessageContentTranscodingResponseSTATUS
+}
+
+type replyToken struct {
+	to        string
+	expiresAt time.Time
+	used      bool
+}
+
+type messaging struct {
+	sent        []Sent
+	replyTokens map[string]*replyToken
+	// retryKeys maps the accepted retry keys to the request IDs of their requests.
+	retryKeys map[string]string
+	users     []messaging_api.UserProfileResponse
+	botInfo   messaging_api.BotInfoResponse
+	quota     int64
+	contents  map[string]MessageContent
+}
+
+func newMessaging() messaging {
+	return messaging{
+		replyTokens: map[string]*replyToken{},
+		retryKeys:   map[string]string{},
+		botInfo: messaging_api.BotInfoResponse{
+			UserId:         "Ufakebot",
+			BasicId:        "@fakebot",
+			DisplayName:    "Fake bot",
+			ChatMode:       messaging_api.BotInfoResponseCHAT_MODE_BOT,
+			MarkAsReadMode: messaging_api.BotInfoResponseMARK_AS_READ_MODE_AUTO,
+		},
+		quota:    DefaultQuota,
+		contents: map[string]MessageContent{},
+	}
+}
+
+// NewReplyToken method
+// Issues a reply token for the chat to, a user, group or room ID.
+func (s *Server) NewReplyToken(to string) string {
+	token := newId("")
+	s.AddReplyToken(token, to)
+	return token
+}
+
+// AddReplyToken method
+// Accepts the reply token, such as the one of an event built with the
+// webhooktest package, for the chat to, a user, group or room ID.
+func (s *Server) AddReplyToken(token, to string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.replyTokens[token] = &replyToken{to: to, expiresAt: s.now().Add(s.replyTokenTTL)}
+}
+
+// AddUser method
+// Adds a user who follows the bot, returned by GetProfile and GetFollowers.
+func (s *Server) AddUser(profile messaging_api.UserProfileResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users = slices.DeleteFunc(s.users, func(u messaging_api.UserProfileResponse) bool {
+		return u.UserId == profile.UserId
+	})
+	s.users = append(s.users, profile)
+}
+
+// SetMessageContent method
+// Sets the content of the message sent by a user.
+func (s *Server) SetMessageContent(messageId string, content MessageContent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.contents[messageId] = content
+}
+
+// Sent method
+// Returns the requests that sent messages, in order.
+func (s *Server) Sent() []Sent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.sent)
+}
+
+// SentTo method
+// Returns the messages pushed, multicast or replied to the chat, in order.
+func (s *Server) SentTo(to string) []messaging_api.MessageInterface {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var messages []messaging_api.MessageInterface
+	for _, sent := range s.sent {
+		if slices.Contains(sent.To, to) {
+			messages = append(messages, sent.Messages...)
+		}
+	}
+	return messages
+}
+
+// ClearSent method
+func (s *Server) ClearSent() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = nil
+}
+
+// usage returns the number of messages counted against the quota this month.
+func (s *Server) usage() int64 {
+	now := s.now()
+	var usage int64
+	for _, sent := range s.sent {
+		if sent.Kind == KindReply || sent.Time.Year() != now.Year() || sent.Time.Month() != now.Month() {
+			continue
+		}
+		usage += s.recipients(sent)
+	}
+	return usage
+}
+
+func (s *Server) recipients(sent Sent) int64 {
+	switch sent.Kind {
+	case KindBroadcast, KindNarrowcast:
+		return int64(len(s.users))
+	}
+	return int64(len(sent.To))
+}
+
+func (s *Server) routeMessaging(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v2/bot/message/push", func(w http.ResponseWriter, r *http.Request) {
+		var req messaging_api.PushMessageRequest
+		if readJSON(w, r, &req) {
+			s.send(w, r, validation.PushMessageRequest(&req), Sent{Kind: KindPush, To: []string{req.To}, Messages: req.Messages, NotificationDisabled: req.NotificationDisabled})
+		}
+	})
+	mux.HandleFunc("POST /v2/bot/message/multicast", func(w http.ResponseWriter, r *http.Request) {
+		var req messaging_api.MulticastRequest
+		if readJSON(w, r, &req) {
+			s.send(w, r, validation.MulticastRequest(&req), Sent{Kind: KindMulticast, To: req.To, Messages: req.Messages, NotificationDisabled: req.NotificationDisabled})
+		}
+	})
+	mux.HandleFunc("POST /v2/bot/message/broadcast", func(w http.ResponseWriter, r *http.Request) {
+		var req messaging_api.BroadcastRequest
+		if readJSON(w, r, &req) {
+			s.send(w, r, validation.BroadcastRequest(&req), Sent{Kind: KindBroadcast, Messages: req.Messages, NotificationDisabled: req.NotificationDisabled})
+		}
+	})
+	mux.HandleFunc("POST /v2/bot/message/narrowcast", func(w http.ResponseWriter, r *http.Request) {
+		var req messaging_api.NarrowcastRequest
+		if readJSON(w, r, &req) {
+			s.send(w, r, validation.NarrowcastRequest(&req), Sent{Kind: KindNarrowcast, Messages: req.Messages, NotificationDisabled: req.NotificationDisabled})
+		}
+	})
+	mux.HandleFunc("POST /v2/bot/message/reply", func(w http.ResponseWriter, r *http.Request) {
+		var req messaging_api.ReplyMessageRequest
+		if readJSON(w, r, &req) {
+			s.send(w, r, validation.ReplyMessageRequest(&req), Sent{Kind: KindReply, ReplyToken: req.ReplyToken, Messages: req.Messages, NotificationDisabled: req.NotificationDisabled})
+		}
+	})
+	mux.HandleFunc("POST /v2/bot/message/validate/{kind}", func(w http.ResponseWriter, r *http.Request) {
+		var req messaging_api.ValidateMessageRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if err := validation.Messages(req.Messages); err != nil {
+			writeValidationError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, struct{}{})
+	})
+	mux.HandleFunc("GET /v2/bot/message/progress/narrowcast", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, sent := range s.sent {
+			if sent.Kind == KindNarrowcast && sent.RequestId == r.URL.Query().Get("requestId") {
+				writeJSON(w, http.StatusOK, messaging_api.NarrowcastProgressResponse{
+					Phase:         messaging_api.NarrowcastProgressResponsePHASE_SUCCEEDED,
+					SuccessCount:  int64(len(s.users)),
+					TargetCount:   int64(len(s.users)),
+					AcceptedTime:  sent.Time,
+					CompletedTime: sent.Time,
+				})
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "Not found")
+	})
+	for _, kind := range []Kind{KindPush, KindReply, KindMulticast, KindBroadcast} {
+		// The kinds are listed since /v2/bot/message/delivery/{kind}
+		// conflicts with /v2/bot/message/{messageId}/content.
+		mux.HandleFunc("GET /v2/bot/message/delivery/"+string(kind), func(w http.ResponseWriter, r *http.Request) {
+			s.countDelivered(w, r, kind)
+		})
+	}
+	mux.HandleFunc("GET /v2/bot/message/quota", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.quota < 0 {
+			writeJSON(w, http.StatusOK, messaging_api.MessageQuotaResponse{Type: messaging_api.QuotaType_NONE})
+			return
+		}
+		writeJSON(w, http.StatusOK, messaging_api.MessageQuotaResponse{Type: messaging_api.QuotaType_LIMITED, Value: s.quota})
+	})
+	mux.HandleFunc("GET /v2/bot/message/quota/consumption", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		writeJSON(w, http.StatusOK, messaging_api.QuotaConsumptionResponse{TotalUsage: s.usage()})
+	})
+	mux.HandleFunc("GET /v2/bot/info", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		writeJSON(w, http.StatusOK, s.botInfo)
+	})
+	mux.HandleFunc("GET /v2/bot/profile/{userId}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, u := range s.users {
+			if u.UserId == r.PathValue("userId") {
+				writeJSON(w, http.StatusOK, u)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "Not found")
+	})
+	mux.HandleFunc("GET /v2/bot/followers/ids", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ids := make([]string, len(s.users))
+		for i, u := range s.users {
+			ids[i] = u.UserId
+		}
+		page, next, ok := paginate(ids, r.URL.Query().Get("start"), r.URL.Query().Get("limit"), 1000)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "Invalid start or limit")
+			return
+		}
+		writeJSON(w, http.StatusOK, messaging_api.GetFollowersResponse{UserIds: page, Next: next})
+	})
+
+	mux.HandleFunc("GET /v2/bot/message/{messageId}/content", func(w http.ResponseWriter, r *http.Request) {
+		s.serveContent(w, r, func(c MessageContent) []byte { return c.Data })
+	})
+	mux.HandleFunc("GET /v2/bot/message/{messageId}/content/preview", func(w http.ResponseWriter, r *http.Request) {
+		s.serveContent(w, r, func(c MessageContent) []byte { return c.Preview })
+	})
+	mux.HandleFunc("GET /v2/bot/message/{messageId}/content/transcoding", func(w http.ResponseWriter, r *http.Request) {
+		content, ok := s.content(r.PathValue("messageId"))
+		if !ok {
+			writeError(w, http.StatusNotFound, "Not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, messaging_api.GetMessageContentTranscodingResponse{Status: content.Transcoding})
+	})
+}
+
+// send records the messages, unless the request is invalid, exceeds the quota,
+// or repeats an accepted retry key.
+func (s *Server) send(w http.ResponseWriter, r *http.Request, invalid error, sent Sent) {
+	if invalid != nil {
+		writeValidationError(w, invalid)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sent.RequestId = w.Header().Get("X-Line-Request-Id")
+	sent.RetryKey = r.Header.Get("X-Line-Retry-Key")
+	sent.Time = s.now()
+
+	if sent.Kind == KindReply {
+		token, ok := s.replyTokens[sent.ReplyToken]
+		if !ok || token.used || !sent.Time.Before(token.expiresAt) {
+			writeError(w, http.StatusBadRequest, "Invalid reply token")
+			return
+		}
+		token.used = true
+		if token.to != "" {
+			sent.To = []string{token.to}
+		}
+	} else {
+		if accepted, ok := s.retryKeys[sent.RetryKey]; ok && sent.RetryKey != "" {
+			w.Header().Set("X-Line-Accepted-Request-Id", accepted)
+			writeError(w, http.StatusConflict, "The retry key is already accepted")
+			return
+		}
+		if s.quota >= 0 && s.usage()+s.recipients(sent) > s.quota {
+			writeError(w, http.StatusTooManyRequests, "You have reached your monthly limit.")
+			return
+		}
+		if sent.RetryKey != "" {
+			s.retryKeys[sent.RetryKey] = sent.RequestId
+		}
+	}
+	s.sent = append(s.sent, sent)
+
+	switch sent.Kind {
+	case KindPush, KindReply:
+		sentMessages := make([]messaging_api.SentMessage, len(sent.Messages))
+		for i := range sentMessages {
+			sentMessages[i] = messaging_api.SentMessage{Id: strconv.FormatInt(s.now().UnixNano()+int64(i), 10)}
+		}
+		if sent.Kind == KindPush {
+			writeJSON(w, http.StatusOK, messaging_api.PushMessageResponse{SentMessages: sentMessages})
+		} else {
+			writeJSON(w, http.StatusOK, messaging_api.ReplyMessageResponse{SentMessages: sentMessages})
+		}
+	case KindNarrowcast:
+		writeJSON(w, http.StatusAccepted, struct{}{})
+	default:
+		writeJSON(w, http.StatusOK, struct{}{})
+	}
+}
+
+// countDelivered writes the number of messages sent the way kind on the date of the request.
+func (s *Server) countDelivered(w http.ResponseWriter, r *http.Request, kind Kind) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var count int64
+	for _, sent := range s.sent {
+		if sent.Kind == kind && sent.Time.Format("20060102") == r.URL.Query().Get("date") {
+			count += s.recipients(sent)
+		}
+	}
+	writeJSON(w, http.StatusOK, messaging_api.NumberOfMessagesResponse{Status: messaging_api.NumberOfMessagesResponseSTATUS_READY, Success: count})
+}
+
+func writeValidationError(w http.ResponseWriter, err error) {
+	var errs validation.Errors
+	if errors.As(err, &errs) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("The request body has %d error(s)", len(errs)), errs.Details()...)
+		return
+	}
+	writeError(w, http.StatusBadRequest, err.Error())
+}
+
+func (s *Server) content(messageId string) (MessageContent, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	content, ok := s.contents[messageId]
+	if ok && content.Transcoding == "" {
+		content.Transcoding = messaging_api.GetMessageContentTranscodingResponseSTATUS_SUCCEEDED
+	}
+	return content, ok
+}
+
+// serveContent serves the data of a message content, with support for range requests.
+func (s *Server) serveContent(w http.ResponseWriter, r *http.Request, data func(MessageContent) []byte) {
+	content, ok := s.content(r.PathValue("messageId"))
+	if !ok || data(content) == nil {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	switch content.Transcoding {
+	case messaging_api.GetMessageContentTranscodingResponseSTATUS_PROCESSING:
+		w.WriteHeader(http.StatusAccepted)
+		return
+	case messaging_api.GetMessageContentTranscodingResponseSTATUS_FAILED:
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	if content.ContentType != "" {
+		w.Header().Set("Content-Type", content.ContentType)
+	}
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data(content)))
+}
+
+// paginate returns the page of items from the continuation token start, and the token of the next page.
+func paginate[T any](items []T, start, limit string, maxLimit int) ([]T, string, bool) {
+	offset, size := 0, maxLimit
+	var err error
+	if start != "" {
+		if offset, err = strconv.Atoi(start); err != nil || offset < 0 || offset > len(items) {
+			return nil, "", false
+		}
+	}
+	if limit != "" && limit != "0" {
+		if size, err = strconv.Atoi(limit); err != nil || size <= 0 || size > maxLimit {
+			return nil, "", false
+		}
+	}
+	end := min(offset+size, len(items))
+	var next string
+	if end < len(items) {
+		next = strconv.Itoa(end)
+	}
+	return items[offset:end], next, true
+}
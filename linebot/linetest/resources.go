@@ -0,0 +1,431 @@
+package linetest
+
+import (
+	"bufio"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/insight"
+	"github.com/line/line-bot-sdk-go/v8/linebot/liff"
+	"github.com/line/line-bot-sdk-go/v8/linebot/manage_audience"
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+type audience struct {
+	manage_audience.AudienceGroup
+	ids []string
+}
+
+type resources struct {
+	// coupons are kept as JSON objects, since the rewards and the acquisition
+	// conditions of the requests are not the types of the responses.
+	coupons   []map[string]any
+	audiences []*audience
+	liffApps  []liff.LiffApp
+	nextId    int64
+}
+
+func newResources() resources {
+	return resources{nextId: 1}
+}
+
+// AudienceIds method
+// Returns the user IDs or IFAs uploaded to the audience, or nil if it does not exist.
+func (s *Server) AudienceIds(audienceGroupId int64) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if a := s.audience(audienceGroupId); a != nil {
+		return slices.Clone(a.ids)
+	}
+	return nil
+}
+
+func (s *Server) audience(audienceGroupId int64) *audience {
+	for _, a := range s.audiences {
+		if a.AudienceGroupId == audienceGroupId {
+			return a
+		}
+	}
+	return nil
+}
+
+func (s *Server) routeResources(mux *http.ServeMux) {
+	s.routeCoupons(mux)
+	s.routeAudiences(mux)
+	s.routeLiff(mux)
+	s.routeInsight(mux)
+}
+
+func (s *Server) routeCoupons(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v2/bot/coupon", func(w http.ResponseWriter, r *http.Request) {
+		var coupon map[string]any
+		if !readJSON(w, r, &coupon) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		couponId := newId("")
+		coupon["couponId"] = couponId
+		coupon["status"] = messaging_api.CouponResponseSTATUS_RUNNING
+		coupon["createdTimestamp"] = s.now().UnixMilli()
+		s.coupons = append(s.coupons, coupon)
+		writeJSON(w, http.StatusOK, messaging_api.CouponCreateResponse{CouponId: couponId})
+	})
+	mux.HandleFunc("GET /v2/bot/coupon", func(w http.ResponseWriter, r *http.Request) {
+		statuses := r.URL.Query()["status"]
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		items := []messaging_api.CouponListResponse{}
+		for _, c := range s.coupons {
+			if len(statuses) == 0 || slices.Contains(statuses, string(c["status"].(messaging_api.CouponResponseSTATUS))) {
+				title, _ := c["title"].(string)
+				items = append(items, messaging_api.CouponListResponse{CouponId: c["couponId"].(string), Title: title})
+			}
+		}
+		page, next, ok := paginate(items, r.URL.Query().Get("start"), r.URL.Query().Get("limit"), 100)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "Invalid start or limit")
+			return
+		}
+		writeJSON(w, http.StatusOK, messaging_api.MessagingApiPagerCouponListResponse{Items: page, Next: next})
+	})
+	mux.HandleFunc("GET /v2/bot/coupon/{couponId}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if c := s.coupon(r.PathValue("couponId")); c != nil {
+			writeJSON(w, http.StatusOK, c)
+			return
+		}
+		writeError(w, http.StatusNotFound, "coupon not found")
+	})
+	mux.HandleFunc("PUT /v2/bot/coupon/{couponId}/close", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		c := s.coupon(r.PathValue("couponId"))
+		if c == nil {
+			writeError(w, http.StatusNotFound, "coupon not found")
+			return
+		}
+		if c["status"] == messaging_api.CouponResponseSTATUS_CLOSED {
+			writeError(w, http.StatusBadRequest, "coupon is already closed")
+			return
+		}
+		c["status"] = messaging_api.CouponResponseSTATUS_CLOSED
+		writeJSON(w, http.StatusOK, struct{}{})
+	})
+}
+
+func (s *Server) coupon(couponId string) map[string]any {
+	for _, c := range s.coupons {
+		if c["couponId"] == couponId {
+			return c
+		}
+	}
+	return nil
+}
+
+func (s *Server) routeAudiences(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v2/bot/audienceGroup/upload", func(w http.ResponseWriter, r *http.Request) {
+		var req manage_audience.CreateAudienceGroupRequest
+		if readJSON(w, r, &req) {
+			s.createAudience(w, req.Description, req.IsIfaAudience, audienceIds(req.Audiences))
+		}
+	})
+	mux.HandleFunc("PUT /v2/bot/audienceGroup/upload", func(w http.ResponseWriter, r *http.Request) {
+		var req manage_audience.AddAudienceToAudienceGroupRequest
+		if readJSON(w, r, &req) {
+			s.addAudience(w, req.AudienceGroupId, audienceIds(req.Audiences))
+		}
+	})
+	mux.HandleFunc("POST /v2/bot/audienceGroup/upload/byFile", func(w http.ResponseWriter, r *http.Request) {
+		ids, ok := readAudienceFile(w, r)
+		if ok {
+			s.createAudience(w, r.FormValue("description"), r.FormValue("isIfaAudience") == "true", ids)
+		}
+	})
+	mux.HandleFunc("PUT /v2/bot/audienceGroup/upload/byFile", func(w http.ResponseWriter, r *http.Request) {
+		ids, ok := readAudienceFile(w, r)
+		if !ok {
+			return
+		}
+		audienceGroupId, err := strconv.ParseInt(r.FormValue("audienceGroupId"), 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid audienceGroupId")
+			return
+		}
+		s.addAudience(w, audienceGroupId, ids)
+	})
+	mux.HandleFunc("GET /v2/bot/audienceGroup/list", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		page, err := strconv.ParseInt(query.Get("page"), 10, 64)
+		if err != nil || page < 1 {
+			writeError(w, http.StatusBadRequest, "Invalid page")
+			return
+		}
+		size, err := strconv.ParseInt(query.Get("size"), 10, 64)
+		if err != nil || size < 1 || size > 40 {
+			size = 20
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		var groups []manage_audience.AudienceGroup
+		for _, a := range s.audiences {
+			if strings.Contains(a.Description, query.Get("description")) &&
+				(query.Get("status") == "" || string(a.Status) == query.Get("status")) {
+				groups = append(groups, a.AudienceGroup)
+			}
+		}
+		start, end := min((page-1)*size, int64(len(groups))), min(page*size, int64(len(groups)))
+		writeJSON(w, http.StatusOK, manage_audience.GetAudienceGroupsResponse{
+			AudienceGroups:                   groups[start:end],
+			HasNextPage:                      end < int64(len(groups)),
+			TotalCount:                       int64(len(groups)),
+			ReadWriteAudienceGroupTotalCount: int64(len(groups)),
+			Page:                             page,
+			Size:                             size,
+		})
+	})
+	mux.HandleFunc("GET /v2/bot/audienceGroup/{audienceGroupId}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if a := s.audienceFromPath(w, r); a != nil {
+			writeJSON(w, http.StatusOK, manage_audience.GetAudienceDataResponse{AudienceGroup: &a.AudienceGroup})
+		}
+	})
+	mux.HandleFunc("DELETE /v2/bot/audienceGroup/{audienceGroupId}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if a := s.audienceFromPath(w, r); a != nil {
+			s.audiences = slices.DeleteFunc(s.audiences, func(b *audience) bool { return a == b })
+			writeJSON(w, http.StatusAccepted, struct{}{})
+		}
+	})
+	mux.HandleFunc("PUT /v2/bot/audienceGroup/{audienceGroupId}/updateDescription", func(w http.ResponseWriter, r *http.Request) {
+		var req manage_audience.UpdateAudienceGroupDescriptionRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if a := s.audienceFromPath(w, r); a != nil {
+			a.Description = req.Description
+			writeJSON(w, http.StatusOK, struct{}{})
+		}
+	})
+}
+
+func (s *Server) createAudience(w http.ResponseWriter, description string, isIfaAudience bool, ids []string) {
+	if description == "" || len(description) > 120 {
+		writeError(w, http.StatusBadRequest, "description must have 1 to 120 characters")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a := &audience{
+		AudienceGroup: manage_audience.AudienceGroup{
+			AudienceGroupId: s.nextId,
+			Type:            manage_audience.AudienceGroupType_UPLOAD,
+			Description:     description,
+			Status:          manage_audience.AudienceGroupStatus_READY,
+			AudienceCount:   int64(len(ids)),
+			Created:         s.now().Unix(),
+			IsIfaAudience:   isIfaAudience,
+			Permission:      manage_audience.AudienceGroupPermission_READ_WRITE,
+			CreateRoute:     manage_audience.AudienceGroupCreateRoute_MESSAGING_API,
+		},
+		ids: ids,
+	}
+	s.nextId++
+	s.audiences = append(s.audiences, a)
+	writeJSON(w, http.StatusAccepted, manage_audience.CreateAudienceGroupResponse{
+		AudienceGroupId: a.AudienceGroupId,
+		CreateRoute:     manage_audience.CreateAudienceGroupResponseCREATE_ROUTE_MESSAGING_API,
+		Type:            a.Type,
+		Description:     a.Description,
+		Created:         a.Created,
+		Permission:      manage_audience.CreateAudienceGroupResponsePERMISSION_READ_WRITE,
+		IsIfaAudience:   a.IsIfaAudience,
+	})
+}
+
+func (s *Server) addAudience(w http.ResponseWriter, audienceGroupId int64, ids []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a := s.audience(audienceGroupId)
+	if a == nil {
+		writeError(w, http.StatusNotFound, "audience group not found")
+		return
+	}
+	for _, id := range ids {
+		if !slices.Contains(a.ids, id) {
+			a.ids = append(a.ids, id)
+		}
+	}
+	a.AudienceCount = int64(len(a.ids))
+	writeJSON(w, http.StatusAccepted, struct{}{})
+}
+
+func (s *Server) audienceFromPath(w http.ResponseWriter, r *http.Request) *audience {
+	audienceGroupId, err := strconv.ParseInt(r.PathValue("audienceGroupId"), 10, 64)
+	if err == nil {
+		if a := s.audience(audienceGroupId); a != nil {
+			return a
+		}
+	}
+	writeError(w, http.StatusNotFound, "audience group not found")
+	return nil
+}
+
+func audienceIds(audiences []manage_audience.Audience) []string {
+	ids := make([]string, len(audiences))
+	for i, a := range audiences {
+		ids[i] = a.Id
+	}
+	return ids
+}
+
+// readAudienceFile reads the user IDs or IFAs of the file of a multipart
+// request, one per line, or writes a 400 response and returns false.
+func readAudienceFile(w http.ResponseWriter, r *http.Request) ([]string, bool) {
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "file is required")
+		return nil, false
+	}
+	defer file.Close()
+	var ids []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if id := strings.TrimSpace(scanner.Text()); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+	return ids, true
+}
+
+func (s *Server) routeLiff(mux *http.ServeMux) {
+	mux.HandleFunc("POST /liff/v1/apps", func(w http.ResponseWriter, r *http.Request) {
+		var req liff.AddLiffAppRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if req.View == nil || req.View.Url == "" {
+			writeError(w, http.StatusBadRequest, "view.url is required")
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		app := liff.LiffApp{
+			LiffId:               strconv.FormatInt(s.nextId, 10) + "-" + newId("")[:8],
+			View:                 req.View,
+			Description:          req.Description,
+			Features:             req.Features,
+			PermanentLinkPattern: req.PermanentLinkPattern,
+			Scope:                req.Scope,
+			BotPrompt:            req.BotPrompt,
+		}
+		s.nextId++
+		s.liffApps = append(s.liffApps, app)
+		writeJSON(w, http.StatusOK, liff.AddLiffAppResponse{LiffId: app.LiffId})
+	})
+	mux.HandleFunc("GET /liff/v1/apps", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if len(s.liffApps) == 0 {
+			writeError(w, http.StatusNotFound, "There is no LIFF app on the channel")
+			return
+		}
+		writeJSON(w, http.StatusOK, liff.GetAllLiffAppsResponse{Apps: s.liffApps})
+	})
+	mux.HandleFunc("PUT /liff/v1/apps/{liffId}", func(w http.ResponseWriter, r *http.Request) {
+		var req liff.UpdateLiffAppRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		i := slices.IndexFunc(s.liffApps, func(app liff.LiffApp) bool { return app.LiffId == r.PathValue("liffId") })
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "LIFF app not found")
+			return
+		}
+		app := &s.liffApps[i]
+		if req.View != nil {
+			view := *app.View
+			if req.View.Type != "" {
+				view.Type = liff.LiffViewTYPE(req.View.Type)
+			}
+			if req.View.Url != "" {
+				view.Url = req.View.Url
+			}
+			view.ModuleMode = req.View.ModuleMode
+			app.View = &view
+		}
+		if req.Description != "" {
+			app.Description = req.Description
+		}
+		if req.Features != nil {
+			app.Features = req.Features
+		}
+		if req.PermanentLinkPattern != "" {
+			app.PermanentLinkPattern = req.PermanentLinkPattern
+		}
+		if req.Scope != nil {
+			app.Scope = req.Scope
+		}
+		if req.BotPrompt != "" {
+			app.BotPrompt = req.BotPrompt
+		}
+		writeJSON(w, http.StatusOK, struct{}{})
+	})
+	mux.HandleFunc("DELETE /liff/v1/apps/{liffId}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		n := len(s.liffApps)
+		s.liffApps = slices.DeleteFunc(s.liffApps, func(app liff.LiffApp) bool { return app.LiffId == r.PathValue("liffId") })
+		if len(s.liffApps) == n {
+			writeError(w, http.StatusNotFound, "LIFF app not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, struct{}{})
+	})
+}
+
+func (s *Server) routeInsight(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v2/bot/insight/message/delivery", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		res := insight.GetNumberOfMessageDeliveriesResponse{Status: insight.GetNumberOfMessageDeliveriesResponseSTATUS_READY}
+		counts := map[Kind]*int64{
+			KindPush:       &res.ApiPush,
+			KindReply:      &res.ApiReply,
+			KindMulticast:  &res.ApiMulticast,
+			KindBroadcast:  &res.ApiBroadcast,
+			KindNarrowcast: &res.ApiNarrowcast,
+		}
+		for _, sent := range s.sent {
+			if sent.Time.Format("20060102") == r.URL.Query().Get("date") {
+				*counts[sent.Kind] += s.recipients(sent)
+			}
+		}
+		writeJSON(w, http.StatusOK, res)
+	})
+	mux.HandleFunc("GET /v2/bot/insight/followers", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		writeJSON(w, http.StatusOK, insight.GetNumberOfFollowersResponse{
+			Status:          insight.GetNumberOfFollowersResponseSTATUS_READY,
+			Followers:       int64(len(s.users)),
+			TargetedReaches: int64(len(s.users)),
+		})
+	})
+	mux.HandleFunc("GET /v2/bot/insight/demographic", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, insight.GetFriendsDemographicsResponse{Available: false})
+	})
+}
@@ -0,0 +1,459 @@
+package linetest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+	"github.com/line/line-bot-sdk-go/v8/linebot/richmenu"
+)
+
+var richMenuAliasIdRe = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+
+type richMenu struct {
+	messaging_api.RichMenuResponse
+	image       []byte
+	contentType string
+}
+
+type richMenus struct {
+	menus []*richMenu
+	// aliases maps the rich menu alias IDs to the rich menu IDs.
+	aliases     map[string]string
+	defaultMenu string
+	// links maps the user IDs to the rich menu IDs linked to them.
+	links map[string]string
+	// batches maps the request IDs of the batch controls to the times they were accepted.
+	batches map[string]time.Time
+}
+
+func newRichMenus() richMenus {
+	return richMenus{
+		aliases: map[string]string{},
+		links:   map[string]string{},
+		batches: map[string]time.Time{},
+	}
+}
+
+// LinkedRichMenu method
+// Returns the ID of the rich menu linked to the user, not the default one, or "".
+func (s *Server) LinkedRichMenu(userId string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.links[userId]
+}
+
+// DefaultRichMenu method
+// Returns the ID of the default rich menu, or "".
+func (s *Server) DefaultRichMenu() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.defaultMenu
+}
+
+func (s *Server) richMenu(richMenuId string) *richMenu {
+	for _, m := range s.menus {
+		if m.RichMenuId == richMenuId {
+			return m
+		}
+	}
+	return nil
+}
+
+// usableRichMenu returns the rich menu if it exists and has an image, or writes an error.
+func (s *Server) usableRichMenu(w http.ResponseWriter, richMenuId string) bool {
+	m := s.richMenu(richMenuId)
+	if m == nil {
+		writeError(w, http.StatusNotFound, "richmenu not found")
+		return false
+	}
+	if m.image == nil {
+		writeError(w, http.StatusBadRequest, "must upload richmenu image before applying it to user")
+		return false
+	}
+	return true
+}
+
+func (s *Server) routeRichMenus(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v2/bot/richmenu", func(w http.ResponseWriter, r *http.Request) {
+		var req messaging_api.RichMenuRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if err := richmenu.ValidateRequest(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		m := &richMenu{RichMenuResponse: messaging_api.RichMenuResponse{
+			RichMenuId:  newId("richmenu-"),
+			Size:        req.Size,
+			Selected:    req.Selected,
+			Name:        req.Name,
+			ChatBarText: req.ChatBarText,
+			Areas:       req.Areas,
+		}}
+		s.menus = append(s.menus, m)
+		writeJSON(w, http.StatusOK, messaging_api.RichMenuIdResponse{RichMenuId: m.RichMenuId})
+	})
+	mux.HandleFunc("POST /v2/bot/richmenu/validate", func(w http.ResponseWriter, r *http.Request) {
+		var req messaging_api.RichMenuRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if err := richmenu.ValidateRequest(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, struct{}{})
+	})
+	mux.HandleFunc("GET /v2/bot/richmenu/list", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		list := messaging_api.RichMenuListResponse{Richmenus: []messaging_api.RichMenuResponse{}}
+		for _, m := range s.menus {
+			list.Richmenus = append(list.Richmenus, m.RichMenuResponse)
+		}
+		writeJSON(w, http.StatusOK, list)
+	})
+	mux.HandleFunc("GET /v2/bot/richmenu/{richMenuId}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		m := s.richMenu(r.PathValue("richMenuId"))
+		if m == nil {
+			writeError(w, http.StatusNotFound, "richmenu not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, m.RichMenuResponse)
+	})
+	mux.HandleFunc("DELETE /v2/bot/richmenu/{richMenuId}", func(w http.ResponseWriter, r *http.Request) {
+		richMenuId := r.PathValue("richMenuId")
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.richMenu(richMenuId) == nil {
+			writeError(w, http.StatusNotFound, "richmenu not found")
+			return
+		}
+		s.menus = slices.DeleteFunc(s.menus, func(m *richMenu) bool { return m.RichMenuId == richMenuId })
+		for aliasId, id := range s.aliases {
+			if id == richMenuId {
+				delete(s.aliases, aliasId)
+			}
+		}
+		for userId, id := range s.links {
+			if id == richMenuId {
+				delete(s.links, userId)
+			}
+		}
+		if s.defaultMenu == richMenuId {
+			s.defaultMenu = ""
+		}
+		writeJSON(w, http.StatusOK, struct{}{})
+	})
+
+	// The alias and the image endpoints share one pattern, since
+	// /v2/bot/richmenu/alias/{richMenuAliasId} conflicts with
+	// /v2/bot/richmenu/{richMenuId}/content.
+	mux.HandleFunc("GET /v2/bot/richmenu/{id}/{sub}", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.PathValue("id") == "alias" && r.PathValue("sub") == "list":
+			s.listRichMenuAliases(w)
+		case r.PathValue("id") == "alias":
+			s.getRichMenuAlias(w, r.PathValue("sub"))
+		case r.PathValue("sub") == "content":
+			s.getRichMenuImage(w, r.PathValue("id"))
+		default:
+			writeError(w, http.StatusNotFound, "Not found")
+		}
+	})
+	mux.HandleFunc("POST /v2/bot/richmenu/{id}/{sub}", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.PathValue("id") == "alias":
+			s.updateRichMenuAlias(w, r, r.PathValue("sub"))
+		case r.PathValue("sub") == "content":
+			s.setRichMenuImage(w, r, r.PathValue("id"))
+		default:
+			writeError(w, http.StatusNotFound, "Not found")
+		}
+	})
+	mux.HandleFunc("POST /v2/bot/richmenu/alias", func(w http.ResponseWriter, r *http.Request) {
+		var req messaging_api.CreateRichMenuAliasRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if !richMenuAliasIdRe.MatchString(req.RichMenuAliasId) {
+			writeError(w, http.StatusBadRequest, "richMenuAliasId must match "+richMenuAliasIdRe.String())
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if _, ok := s.aliases[req.RichMenuAliasId]; ok {
+			writeError(w, http.StatusBadRequest, "conflict richmenu alias id")
+			return
+		}
+		if s.usableRichMenu(w, req.RichMenuId) {
+			s.aliases[req.RichMenuAliasId] = req.RichMenuId
+			writeJSON(w, http.StatusOK, struct{}{})
+		}
+	})
+	mux.HandleFunc("DELETE /v2/bot/richmenu/alias/{richMenuAliasId}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if _, ok := s.aliases[r.PathValue("richMenuAliasId")]; !ok {
+			writeError(w, http.StatusNotFound, "richmenu alias not found")
+			return
+		}
+		delete(s.aliases, r.PathValue("richMenuAliasId"))
+		writeJSON(w, http.StatusOK, struct{}{})
+	})
+
+	mux.HandleFunc("GET /v2/bot/user/all/richmenu", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.defaultMenu == "" {
+			writeError(w, http.StatusNotFound, "no default richmenu")
+			return
+		}
+		writeJSON(w, http.StatusOK, messaging_api.RichMenuIdResponse{RichMenuId: s.defaultMenu})
+	})
+	mux.HandleFunc("POST /v2/bot/user/all/richmenu/{richMenuId}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.usableRichMenu(w, r.PathValue("richMenuId")) {
+			s.defaultMenu = r.PathValue("richMenuId")
+			writeJSON(w, http.StatusOK, struct{}{})
+		}
+	})
+	mux.HandleFunc("DELETE /v2/bot/user/all/richmenu", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.defaultMenu = ""
+		writeJSON(w, http.StatusOK, struct{}{})
+	})
+	mux.HandleFunc("GET /v2/bot/user/{userId}/richmenu", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		richMenuId, ok := s.links[r.PathValue("userId")]
+		if !ok {
+			writeError(w, http.StatusNotFound, "the user has no richmenu")
+			return
+		}
+		writeJSON(w, http.StatusOK, messaging_api.RichMenuIdResponse{RichMenuId: richMenuId})
+	})
+	mux.HandleFunc("POST /v2/bot/user/{userId}/richmenu/{richMenuId}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.usableRichMenu(w, r.PathValue("richMenuId")) {
+			s.links[r.PathValue("userId")] = r.PathValue("richMenuId")
+			writeJSON(w, http.StatusOK, struct{}{})
+		}
+	})
+	mux.HandleFunc("DELETE /v2/bot/user/{userId}/richmenu", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.links, r.PathValue("userId"))
+		writeJSON(w, http.StatusOK, struct{}{})
+	})
+	mux.HandleFunc("POST /v2/bot/richmenu/bulk/link", func(w http.ResponseWriter, r *http.Request) {
+		var req messaging_api.RichMenuBulkLinkRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.usableRichMenu(w, req.RichMenuId) {
+			for _, userId := range req.UserIds {
+				s.links[userId] = req.RichMenuId
+			}
+			writeJSON(w, http.StatusAccepted, struct{}{})
+		}
+	})
+	mux.HandleFunc("POST /v2/bot/richmenu/bulk/unlink", func(w http.ResponseWriter, r *http.Request) {
+		var req messaging_api.RichMenuBulkUnlinkRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, userId := range req.UserIds {
+			delete(s.links, userId)
+		}
+		writeJSON(w, http.StatusAccepted, struct{}{})
+	})
+	mux.HandleFunc("POST /v2/bot/richmenu/batch", func(w http.ResponseWriter, r *http.Request) {
+		var req messaging_api.RichMenuBatchRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if err := s.validateBatch(req.Operations); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		for _, op := range req.Operations {
+			s.applyBatchOperation(op)
+		}
+		s.batches[w.Header().Get("X-Line-Request-Id")] = s.now()
+		writeJSON(w, http.StatusAccepted, struct{}{})
+	})
+	mux.HandleFunc("POST /v2/bot/richmenu/validate/batch", func(w http.ResponseWriter, r *http.Request) {
+		var req messaging_api.RichMenuBatchRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if err := s.validateBatch(req.Operations); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, struct{}{})
+	})
+	mux.HandleFunc("GET /v2/bot/richmenu/progress/batch", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		accepted, ok := s.batches[r.URL.Query().Get("requestId")]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, messaging_api.RichMenuBatchProgressResponse{
+			Phase:         messaging_api.RichMenuBatchProgressPhase_SUCCEEDED,
+			AcceptedTime:  accepted,
+			CompletedTime: accepted,
+		})
+	})
+}
+
+func (s *Server) listRichMenuAliases(w http.ResponseWriter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := messaging_api.RichMenuAliasListResponse{Aliases: []messaging_api.RichMenuAliasResponse{}}
+	for _, aliasId := range sortedKeys(s.aliases) {
+		list.Aliases = append(list.Aliases, messaging_api.RichMenuAliasResponse{RichMenuAliasId: aliasId, RichMenuId: s.aliases[aliasId]})
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) getRichMenuAlias(w http.ResponseWriter, aliasId string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	richMenuId, ok := s.aliases[aliasId]
+	if !ok {
+		writeError(w, http.StatusNotFound, "richmenu alias not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, messaging_api.RichMenuAliasResponse{RichMenuAliasId: aliasId, RichMenuId: richMenuId})
+}
+
+func (s *Server) updateRichMenuAlias(w http.ResponseWriter, r *http.Request, aliasId string) {
+	var req messaging_api.UpdateRichMenuAliasRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.aliases[aliasId]; !ok {
+		writeError(w, http.StatusNotFound, "richmenu alias not found")
+		return
+	}
+	if s.usableRichMenu(w, req.RichMenuId) {
+		s.aliases[aliasId] = req.RichMenuId
+		writeJSON(w, http.StatusOK, struct{}{})
+	}
+}
+
+func (s *Server) getRichMenuImage(w http.ResponseWriter, richMenuId string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m := s.richMenu(richMenuId)
+	if m == nil || m.image == nil {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	w.Header().Set("Content-Type", m.contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(m.image)
+}
+
+func (s *Server) setRichMenuImage(w http.ResponseWriter, r *http.Request, richMenuId string) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m := s.richMenu(richMenuId)
+	if m == nil {
+		writeError(w, http.StatusNotFound, "richmenu not found")
+		return
+	}
+	if m.image != nil {
+		writeError(w, http.StatusBadRequest, "An image has already been uploaded to the richmenu")
+		return
+	}
+	contentType, err := richmenu.ValidateImage(data, *m.Size)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	m.image, m.contentType = data, contentType
+	writeJSON(w, http.StatusOK, struct{}{})
+}
+
+func (s *Server) validateBatch(operations []messaging_api.RichMenuBatchOperationInterface) error {
+	if len(operations) == 0 || len(operations) > richmenu.MaxBatchOperations {
+		return fmt.Errorf("operations: must have 1 to %d operations, got %d", richmenu.MaxBatchOperations, len(operations))
+	}
+	for i, op := range operations {
+		var ids []string
+		switch op := op.(type) {
+		case messaging_api.RichMenuBatchLinkOperation:
+			ids = []string{op.From, op.To}
+		case messaging_api.RichMenuBatchUnlinkOperation:
+			ids = []string{op.From}
+		case messaging_api.RichMenuBatchUnlinkAllOperation:
+		default:
+			return fmt.Errorf("operations[%d]: unknown type %q", i, op.GetType())
+		}
+		for _, id := range ids {
+			if s.richMenu(id) == nil {
+				return fmt.Errorf("operations[%d]: richmenu %s not found", i, id)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Server) applyBatchOperation(op messaging_api.RichMenuBatchOperationInterface) {
+	for userId, richMenuId := range s.links {
+		switch op := op.(type) {
+		case messaging_api.RichMenuBatchLinkOperation:
+			if richMenuId == op.From {
+				s.links[userId] = op.To
+			}
+		case messaging_api.RichMenuBatchUnlinkOperation:
+			if richMenuId == op.From {
+				delete(s.links, userId)
+			}
+		case messaging_api.RichMenuBatchUnlinkAllOperation:
+			delete(s.links, userId)
+		}
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,271 @@
+// Package linetest provides a fake LINE Platform for integration tests.
+//
+// A Server serves the Messaging API, its blob endpoints, and the insight,
+// LIFF, audience and channel access token endpoints on one httptest server.
+// Point the clients at it with WithEndpoint and WithBlobEndpoint:
+//
+//	server := linetest.NewServer()
+//	defer server.Close()
+//	bot, err := messaging_api.NewMessagingApiAPI("token", messaging_api.WithEndpoint(server.URL))
+//
+// It keeps rich menus, aliases, audiences, LIFF apps, coupons and channel
+// access tokens in memory, records the messages sent, and can inject faults.
+package linetest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+const (
+	// DefaultReplyTokenTTL is how long a reply token can be used by default.
+	DefaultReplyTokenTTL = time.Minute
+	// DefaultQuota is the default number of messages that can be sent in a month.
+	DefaultQuota = 1000
+)
+
+// Server is a fake LINE Platform.
+type Server struct {
+	// URL is the endpoint of all the APIs, of the form http://ipaddr:port with no trailing slash.
+	URL string
+
+	server        *httptest.Server
+	now           func() time.Time
+	replyTokenTTL time.Duration
+	strictAuth    bool
+	channels      map[string]string
+
+	mu       sync.Mutex
+	faults   []*fault
+	requests int
+	messaging
+	richMenus
+	resources
+	tokens
+}
+
+// ServerOption type
+type ServerOption func(*Server)
+
+// WithClock function
+// Sets the function that returns the current time, used for the expiry of reply tokens and channel access tokens.
+func WithClock(now func() time.Time) ServerOption {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// WithReplyTokenTTL function
+// Sets how long a reply token can be used. The default is DefaultReplyTokenTTL.
+func WithReplyTokenTTL(ttl time.Duration) ServerOption {
+	return func(s *Server) {
+		s.replyTokenTTL = ttl
+	}
+}
+
+// WithQuota function
+// Sets the number of messages that can be sent in a month. Replies are not counted.
+// A negative quota means no limit.
+func WithQuota(quota int64) ServerOption {
+	return func(s *Server) {
+		s.quota = quota
+	}
+}
+
+// WithBotInfo function
+// Sets the bot returned by GetBotInfo.
+func WithBotInfo(info messaging_api.BotInfoResponse) ServerOption {
+	return func(s *Server) {
+		s.botInfo = info
+	}
+}
+
+// WithChannel function
+// Registers a channel whose ID and secret must be used to issue channel access tokens.
+// If no channel is registered, any credentials are accepted.
+func WithChannel(channelId, channelSecret string) ServerOption {
+	return func(s *Server) {
+		s.channels[channelId] = channelSecret
+	}
+}
+
+// WithStrictAuth function
+// Rejects the requests whose channel access token was not issued by the server.
+// By default, any channel access token is accepted unless it was issued by
+// the server and then revoked or expired.
+func WithStrictAuth() ServerOption {
+	return func(s *Server) {
+		s.strictAuth = true
+	}
+}
+
+// NewServer function
+// Starts a fake LINE Platform. Close it when done.
+func NewServer(options ...ServerOption) *Server {
+	s := &Server{
+		now:           time.Now,
+		replyTokenTTL: DefaultReplyTokenTTL,
+		channels:      map[string]string{},
+		messaging:     newMessaging(),
+		richMenus:     newRichMenus(),
+		resources:     newResources(),
+		tokens:        newTokens(),
+	}
+	for _, option := range options {
+		option(s)
+	}
+	mux := http.NewServeMux()
+	s.routeMessaging(mux)
+	s.routeRichMenus(mux)
+	s.routeResources(mux)
+	s.routeTokens(mux)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "Not found")
+	})
+	s.server = httptest.NewServer(s.handler(mux))
+	s.URL = s.server.URL
+	return s
+}
+
+// Close method
+// Shuts the server down.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Fault is a failure injected into the responses of a Server.
+type Fault struct {
+	// StatusCode is the status of the responses, such as 429 or 500. If it
+	// is zero, the requests are handled normally after the latency.
+	StatusCode int
+	// RetryAfter sets the Retry-After header of the responses.
+	RetryAfter time.Duration
+	// Latency delays the responses.
+	Latency time.Duration
+	// Times is the number of requests affected. If it is zero, all the requests are affected until ClearFaults.
+	Times int
+}
+
+type fault struct {
+	method string
+	path   string
+	Fault
+}
+
+// InjectFault method
+// Applies the fault to the requests matching the pattern, which is a path
+// prefix optionally preceded by a method, such as "POST /v2/bot/message/push"
+// or "/v2/bot/richmenu". Faults are matched in the order they were injected.
+func (s *Server) InjectFault(pattern string, f Fault) {
+	method, path, ok := strings.Cut(pattern, " ")
+	if !ok {
+		method, path = "", pattern
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault{method: method, path: path, Fault: f})
+}
+
+// ClearFaults method
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// matchFault returns the fault to apply to r, if any.
+func (s *Server) matchFault(r *http.Request) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, f := range s.faults {
+		if (f.method == "" || f.method == r.Method) && strings.HasPrefix(r.URL.Path, f.path) {
+			applied := f.Fault
+			if f.Times > 0 {
+				f.Times--
+				if f.Times == 0 {
+					s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+				}
+			}
+			return &applied
+		}
+	}
+	return nil
+}
+
+func (s *Server) handler(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests++
+		w.Header().Set("X-Line-Request-Id", fmt.Sprintf("fake-%08d", s.requests))
+		s.mu.Unlock()
+
+		if f := s.matchFault(r); f != nil {
+			if f.Latency > 0 {
+				select {
+				case <-time.After(f.Latency):
+				case <-r.Context().Done():
+					return
+				}
+			}
+			if f.StatusCode != 0 {
+				if f.RetryAfter > 0 {
+					w.Header().Set("Retry-After", strconv.Itoa(int((f.RetryAfter+time.Second-1)/time.Second)))
+				}
+				message := http.StatusText(f.StatusCode)
+				if f.StatusCode == http.StatusTooManyRequests {
+					message = "The API rate limit has been exceeded. Try again later."
+				}
+				writeError(w, f.StatusCode, message)
+				return
+			}
+		}
+		if !isTokenEndpoint(r.URL.Path) {
+			if status, message := s.authenticate(r); status != 0 {
+				writeError(w, status, message)
+				return
+			}
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// errorResponse is the body of the error responses of the LINE Platform.
+type errorResponse struct {
+	Message string                      `json:"message"`
+	Details []messaging_api.ErrorDetail `json:"details,omitempty"`
+}
+
+func writeError(w http.ResponseWriter, status int, message string, details ...messaging_api.ErrorDetail) {
+	writeJSON(w, status, errorResponse{Message: message, Details: details})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// readJSON decodes the body of r into v, or writes a 400 response and returns false.
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "The request body has 1 error(s)", messaging_api.ErrorDetail{Message: err.Error()})
+		return false
+	}
+	return true
+}
+
+func newId(prefix string) string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return prefix + hex.EncodeToString(b)
+}
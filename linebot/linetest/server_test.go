@@ -0,0 +1,348 @@
+package linetest
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/channel_access_token"
+	"github.com/line/line-bot-sdk-go/v8/linebot/liff"
+	"github.com/line/line-bot-sdk-go/v8/linebot/manage_audience"
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+	"github.com/line/line-bot-sdk-go/v8/linebot/retry"
+	"github.com/line/line-bot-sdk-go/v8/linebot/richmenu"
+)
+
+func newClients(t *testing.T, s *Server, token string) (*messaging_api.MessagingApiAPI, *messaging_api.MessagingApiBlobAPI) {
+	t.Helper()
+	bot, err := messaging_api.NewMessagingApiAPI(token, messaging_api.WithEndpoint(s.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob, err := messaging_api.NewMessagingApiBlobAPI(token, messaging_api.WithBlobEndpoint(s.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bot, blob
+}
+
+func statusCode(err error) int {
+	var apiErr *messaging_api.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+func text(s string) []messaging_api.MessageInterface {
+	return []messaging_api.MessageInterface{messaging_api.TextMessage{Text: s}}
+}
+
+func TestMessaging(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	s := NewServer(WithClock(func() time.Time { return now }), WithQuota(3))
+	defer s.Close()
+	bot, _ := newClients(t, s, "token")
+
+	if _, err := bot.PushMessage(&messaging_api.PushMessageRequest{To: "U1", Messages: text("hello")}, "key"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bot.PushMessage(&messaging_api.PushMessageRequest{To: "U1", Messages: text("hello")}, "key"); statusCode(err) != http.StatusConflict {
+		t.Errorf("got %v, want a conflict for a repeated retry key", err)
+	}
+	_, err := bot.PushMessage(&messaging_api.PushMessageRequest{To: "U1", Messages: text("")}, "")
+	var apiErr *messaging_api.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || len(apiErr.Response.Details) != 1 {
+		t.Errorf("got %v, want a validation error", err)
+	}
+
+	token := s.NewReplyToken("U2")
+	if _, err := bot.ReplyMessage(&messaging_api.ReplyMessageRequest{ReplyToken: token, Messages: text("hi")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bot.ReplyMessage(&messaging_api.ReplyMessageRequest{ReplyToken: token, Messages: text("hi")}); statusCode(err) != http.StatusBadRequest {
+		t.Errorf("got %v, want an invalid reply token", err)
+	}
+	expired := s.NewReplyToken("U2")
+	now = now.Add(DefaultReplyTokenTTL)
+	if _, err := bot.ReplyMessage(&messaging_api.ReplyMessageRequest{ReplyToken: expired, Messages: text("hi")}); statusCode(err) != http.StatusBadRequest {
+		t.Errorf("got %v, want an expired reply token", err)
+	}
+
+	if _, err := bot.Multicast(&messaging_api.MulticastRequest{To: []string{"U1", "U2"}, Messages: text("all")}, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bot.PushMessage(&messaging_api.PushMessageRequest{To: "U3", Messages: text("over")}, ""); statusCode(err) != http.StatusTooManyRequests {
+		t.Errorf("got %v, want the quota to be exceeded", err)
+	}
+	if consumption, err := bot.GetMessageQuotaConsumption(); err != nil || consumption.TotalUsage != 3 {
+		t.Errorf("got %+v, %v, want a usage of 3", consumption, err)
+	}
+
+	sent := s.Sent()
+	if len(sent) != 3 || sent[0].Kind != KindPush || sent[0].RetryKey != "key" || sent[1].Kind != KindReply || sent[2].Kind != KindMulticast {
+		t.Fatalf("unexpected sent: %+v", sent)
+	}
+	if messages := s.SentTo("U2"); len(messages) != 2 || messages[0].(messaging_api.TextMessage).Text != "hi" {
+		t.Errorf("unexpected messages to U2: %+v", messages)
+	}
+}
+
+func TestUsers(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	bot, _ := newClients(t, s, "token")
+
+	for _, id := range []string{"U1", "U2", "U3"} {
+		s.AddUser(messaging_api.UserProfileResponse{UserId: id, DisplayName: "user " + id})
+	}
+	if profile, err := bot.GetProfile("U2"); err != nil || profile.DisplayName != "user U2" {
+		t.Errorf("got %+v, %v", profile, err)
+	}
+	if _, err := bot.GetProfile("U4"); !messaging_api.IsNotFound(err) {
+		t.Errorf("got %v, want not found", err)
+	}
+	page, err := bot.GetFollowers("", 2)
+	if err != nil || len(page.UserIds) != 2 || page.Next == "" {
+		t.Fatalf("got %+v, %v", page, err)
+	}
+	page, err = bot.GetFollowers(page.Next, 2)
+	if err != nil || len(page.UserIds) != 1 || page.UserIds[0] != "U3" || page.Next != "" {
+		t.Errorf("got %+v, %v", page, err)
+	}
+}
+
+func TestMessageContent(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	_, blob := newClients(t, s, "token")
+
+	s.SetMessageContent("1", MessageContent{ContentType: "video/mp4", Data: []byte("0123456789"), Transcoding: messaging_api.GetMessageContentTranscodingResponseSTATUS_PROCESSING})
+	if status, err := blob.GetMessageContentTranscodingByMessageId("1"); err != nil || status.Status != messaging_api.GetMessageContentTranscodingResponseSTATUS_PROCESSING {
+		t.Errorf("got %+v, %v", status, err)
+	}
+	if res, err := blob.GetMessageContent("1"); err != nil || res.StatusCode != http.StatusAccepted {
+		t.Errorf("got %v, %v, want 202 while processing", res, err)
+	}
+
+	s.SetMessageContent("1", MessageContent{ContentType: "video/mp4", Data: []byte("0123456789")})
+	req, _ := http.NewRequest(http.MethodGet, s.URL+"/v2/bot/message/1/content", nil)
+	req.Header.Set("Range", "bytes=4-")
+	res, err := blob.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	body, _ := io.ReadAll(res.Body)
+	if res.StatusCode != http.StatusPartialContent || string(body) != "456789" || res.Header.Get("Content-Type") != "video/mp4" {
+		t.Errorf("got %d %q %s", res.StatusCode, body, res.Header.Get("Content-Type"))
+	}
+	if _, err := blob.GetMessageContentPreview("1"); !messaging_api.IsNotFound(err) {
+		t.Errorf("got %v, want no preview", err)
+	}
+}
+
+func TestRichMenus(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	bot, blob := newClients(t, s, "token")
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 2500, 843))); err != nil {
+		t.Fatal(err)
+	}
+	areas, err := richmenu.TemplateColumns2.Areas(richmenu.SizeCompact, &messaging_api.PostbackAction{Data: "a"}, &messaging_api.PostbackAction{Data: "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	menu := func(name string) richmenu.Menu {
+		return richmenu.Menu{
+			Key: name,
+			Request: messaging_api.RichMenuRequest{
+				Size:        &richmenu.SizeCompact,
+				Name:        name,
+				ChatBarText: "Menu",
+				Areas:       areas,
+			},
+			Image: buf.Bytes(),
+		}
+	}
+	spec := &richmenu.Spec{
+		Menus:   []richmenu.Menu{menu("main"), menu("sub")},
+		Aliases: map[string]string{"main": "main", "sub": "sub"},
+		Default: "main",
+	}
+	ctx := context.Background()
+	plan, err := richmenu.NewReconciler(bot, blob).Reconcile(ctx, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.DefaultRichMenu() != plan.RichMenuIds["main"] {
+		t.Errorf("got default %s, want %s", s.DefaultRichMenu(), plan.RichMenuIds["main"])
+	}
+	if again, err := richmenu.NewReconciler(bot, blob).Plan(ctx, spec); err != nil || !again.Empty() {
+		t.Errorf("got %v, %v, want an empty plan once reconciled", again, err)
+	}
+	if alias, err := bot.GetRichMenuAlias("sub"); err != nil || alias.RichMenuId != plan.RichMenuIds["sub"] {
+		t.Errorf("got %+v, %v", alias, err)
+	}
+	if _, err := blob.SetRichMenuImage(plan.RichMenuIds["sub"], "image/png", bytes.NewReader(buf.Bytes())); statusCode(err) != http.StatusBadRequest {
+		t.Errorf("got %v, want an error for a second image", err)
+	}
+
+	main, sub := plan.RichMenuIds["main"], plan.RichMenuIds["sub"]
+	if _, err := bot.LinkRichMenuIdToUser("U1", main); err != nil {
+		t.Fatal(err)
+	}
+	migrator := richmenu.NewMigrator(bot, richmenu.WithPollInterval(time.Millisecond, time.Millisecond))
+	if _, err := migrator.LinkUsers(ctx, main, []string{"U2"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Migrate(ctx, &messaging_api.RichMenuBatchLinkOperation{From: main, To: sub}); err != nil {
+		t.Fatal(err)
+	}
+	if s.LinkedRichMenu("U1") != sub || s.LinkedRichMenu("U2") != sub {
+		t.Errorf("got %s and %s, want both users on %s", s.LinkedRichMenu("U1"), s.LinkedRichMenu("U2"), sub)
+	}
+	if _, err := bot.DeleteRichMenu(sub); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bot.GetRichMenuIdOfUser("U1"); !messaging_api.IsNotFound(err) {
+		t.Errorf("got %v, want the user unlinked from a deleted menu", err)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	bot, _ := newClients(t, s, "token")
+
+	s.InjectFault("GET /v2/bot/info", Fault{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Second, Times: 1})
+	_, err := bot.GetBotInfo()
+	if !messaging_api.IsRateLimited(err) {
+		t.Fatalf("got %v, want rate limited", err)
+	}
+	if info, err := bot.GetBotInfo(); err != nil || info.BasicId != "@fakebot" {
+		t.Errorf("got %+v, %v, want the fault to be applied once", info, err)
+	}
+
+	s.InjectFault("/v2/bot/info", Fault{StatusCode: http.StatusInternalServerError})
+	retrying, err := messaging_api.NewMessagingApiAPI("token", messaging_api.WithEndpoint(s.URL),
+		messaging_api.WithRetryPolicy(&retry.Policy{MaxAttempts: 2, InitialBackoff: time.Millisecond}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := retrying.GetBotInfo(); statusCode(err) != http.StatusInternalServerError {
+		t.Errorf("got %v, want a server error", err)
+	}
+	s.ClearFaults()
+	if _, err := retrying.GetBotInfo(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTokens(t *testing.T) {
+	s := NewServer(WithChannel("1234", "secret"), WithStrictAuth())
+	defer s.Close()
+	client, err := channel_access_token.NewChannelAccessTokenAPI(channel_access_token.WithEndpoint(s.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.IssueChannelToken("client_credentials", "1234", "wrong"); err == nil {
+		t.Error("expected an error for a wrong channel secret")
+	}
+	issued, err := client.IssueChannelToken("client_credentials", "1234", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bot, _ := newClients(t, s, issued.AccessToken)
+	if _, err := bot.GetBotInfo(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.RevokeChannelToken(issued.AccessToken); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bot.GetBotInfo(); statusCode(err) != http.StatusUnauthorized {
+		t.Errorf("got %v, want a revoked token to be rejected", err)
+	}
+	unknown, _ := newClients(t, s, "unknown")
+	if _, err := unknown.GetBotInfo(); statusCode(err) != http.StatusUnauthorized {
+		t.Errorf("got %v, want an unknown token to be rejected", err)
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manager, err := channel_access_token.NewTokenManager(client, "1234", "kid", key, channel_access_token.WithChannelSecret("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := manager.Token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verified, err := client.VerifyChannelTokenByJWT(token); err != nil || verified.ClientId != "1234" {
+		t.Errorf("got %+v, %v", verified, err)
+	}
+	if err := manager.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.VerifyChannelTokenByJWT(token); err == nil {
+		t.Error("expected a revoked token to fail verification")
+	}
+}
+
+func TestResources(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	audiences, err := manage_audience.NewManageAudienceAPI("token", manage_audience.WithEndpoint(s.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	created, err := audiences.CreateAudienceGroup(&manage_audience.CreateAudienceGroupRequest{
+		Description: "vip",
+		Audiences:   []manage_audience.Audience{{Id: "U1"}, {Id: "U2"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := audiences.AddAudienceToAudienceGroup(&manage_audience.AddAudienceToAudienceGroupRequest{
+		AudienceGroupId: created.AudienceGroupId,
+		Audiences:       []manage_audience.Audience{{Id: "U2"}, {Id: "U3"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := audiences.GetAudienceData(created.AudienceGroupId)
+	if err != nil || data.AudienceGroup.AudienceCount != 3 || len(s.AudienceIds(created.AudienceGroupId)) != 3 {
+		t.Errorf("got %+v, %v", data, err)
+	}
+
+	apps, err := liff.NewLiffAPI("token", liff.WithEndpoint(s.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := apps.GetAllLIFFApps(); !liff.IsNotFound(err) {
+		t.Errorf("got %v, want not found with no apps", err)
+	}
+	added, err := apps.AddLIFFApp(&liff.AddLiffAppRequest{View: &liff.LiffView{Type: liff.LiffViewTYPE_FULL, Url: "https://example.com"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := apps.UpdateLIFFApp(added.LiffId, &liff.UpdateLiffAppRequest{Description: "app"}); err != nil {
+		t.Fatal(err)
+	}
+	if all, err := apps.GetAllLIFFApps(); err != nil || len(all.Apps) != 1 || all.Apps[0].Description != "app" || all.Apps[0].View.Url != "https://example.com" {
+		t.Errorf("got %+v, %v", all, err)
+	}
+}
@@ -0,0 +1,233 @@
+package linetest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/channel_access_token"
+)
+
+const (
+	shortLivedTokenTTL = 30 * 24 * time.Hour
+	statelessTokenTTL  = 15 * time.Minute
+)
+
+type token struct {
+	channelId string
+	// keyId is the key ID of a channel access token v2.1.
+	keyId     string
+	expiresAt time.Time
+	revoked   bool
+}
+
+type tokens struct {
+	issued map[string]*token
+}
+
+func newTokens() tokens {
+	return tokens{issued: map[string]*token{}}
+}
+
+// NewChannelAccessToken method
+// Issues a short-lived channel access token for the channel, as the v2 token endpoint does.
+func (s *Server) NewChannelAccessToken(channelId string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.issue(channelId, "", shortLivedTokenTTL)
+}
+
+func (s *Server) issue(channelId, keyId string, ttl time.Duration) string {
+	accessToken := newId("")
+	s.issued[accessToken] = &token{channelId: channelId, keyId: keyId, expiresAt: s.now().Add(ttl)}
+	return accessToken
+}
+
+// valid returns the channel access token if it was issued, is not revoked and has not expired.
+func (s *Server) valid(accessToken string) *token {
+	t, ok := s.issued[accessToken]
+	if !ok || t.revoked || !s.now().Before(t.expiresAt) {
+		return nil
+	}
+	return t
+}
+
+func isTokenEndpoint(path string) bool {
+	return strings.HasPrefix(path, "/v2/oauth/") || strings.HasPrefix(path, "/oauth2/")
+}
+
+// authenticate returns the status and the message of the error response if
+// the request has no valid channel access token.
+func (s *Server) authenticate(r *http.Request) (int, string) {
+	accessToken, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || accessToken == "" {
+		return http.StatusUnauthorized, "Authentication failed. Confirm that the access token in the authorization header is valid."
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, issued := s.issued[accessToken]; (issued || s.strictAuth) && s.valid(accessToken) == nil {
+		return http.StatusUnauthorized, "The access token expired"
+	}
+	return 0, ""
+}
+
+// checkChannel tells whether the credentials match a channel given with
+// WithChannel. Any credentials match if no channel was given.
+func (s *Server) checkChannel(channelId, channelSecret string, checkSecret bool) bool {
+	if len(s.channels) == 0 {
+		return true
+	}
+	secret, ok := s.channels[channelId]
+	return ok && (!checkSecret || secret == channelSecret)
+}
+
+func writeOAuthError(w http.ResponseWriter, status int, code, description string) {
+	writeJSON(w, status, channel_access_token.ErrorResponse{Error: code, ErrorDescription: description})
+}
+
+// assertion is the payload of the JWT assertion used to issue channel access tokens v2.1.
+type assertion struct {
+	Iss      string `json:"iss"`
+	TokenExp int64  `json:"token_exp"`
+	Kid      string `json:"-"`
+}
+
+// parseAssertion decodes the JWT assertion without verifying its signature.
+func parseAssertion(jwt string) (*assertion, bool) {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return nil, false
+	}
+	var header struct {
+		Kid string `json:"kid"`
+	}
+	var a assertion
+	for i, v := range []any{&header, &a} {
+		data, err := base64.RawURLEncoding.DecodeString(parts[i])
+		if err != nil || json.Unmarshal(data, v) != nil {
+			return nil, false
+		}
+	}
+	a.Kid = header.Kid
+	return &a, a.Iss != ""
+}
+
+func (s *Server) routeTokens(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v2/oauth/accessToken", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("grant_type") != "client_credentials" {
+			writeOAuthError(w, http.StatusBadRequest, "unsupported_grant_type", "invalid grant_type")
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if !s.checkChannel(r.FormValue("client_id"), r.FormValue("client_secret"), true) {
+			writeOAuthError(w, http.StatusBadRequest, "invalid_client", "invalid client_id or client_secret")
+			return
+		}
+		writeJSON(w, http.StatusOK, channel_access_token.IssueShortLivedChannelAccessTokenResponse{
+			AccessToken: s.issue(r.FormValue("client_id"), "", shortLivedTokenTTL),
+			ExpiresIn:   int32(shortLivedTokenTTL / time.Second),
+			TokenType:   "Bearer",
+		})
+	})
+	mux.HandleFunc("POST /oauth2/v2.1/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("grant_type") != "client_credentials" {
+			writeOAuthError(w, http.StatusBadRequest, "unsupported_grant_type", "invalid grant_type")
+			return
+		}
+		a, ok := parseAssertion(r.FormValue("client_assertion"))
+		if !ok {
+			writeOAuthError(w, http.StatusBadRequest, "invalid_request", "invalid client_assertion")
+			return
+		}
+		ttl := shortLivedTokenTTL
+		if a.TokenExp > 0 {
+			ttl = min(time.Duration(a.TokenExp)*time.Second, ttl)
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if !s.checkChannel(a.Iss, "", false) {
+			writeOAuthError(w, http.StatusBadRequest, "invalid_client", "invalid iss")
+			return
+		}
+		keyId := newId("")
+		writeJSON(w, http.StatusOK, channel_access_token.IssueChannelAccessTokenResponse{
+			AccessToken: s.issue(a.Iss, keyId, ttl),
+			ExpiresIn:   int32(ttl / time.Second),
+			TokenType:   "Bearer",
+			KeyId:       keyId,
+		})
+	})
+	mux.HandleFunc("POST /oauth2/v3/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("grant_type") != "client_credentials" {
+			writeOAuthError(w, http.StatusBadRequest, "unsupported_grant_type", "invalid grant_type")
+			return
+		}
+		channelId, channelSecret, checkSecret := r.FormValue("client_id"), r.FormValue("client_secret"), true
+		if r.FormValue("client_assertion") != "" {
+			a, ok := parseAssertion(r.FormValue("client_assertion"))
+			if !ok {
+				writeOAuthError(w, http.StatusBadRequest, "invalid_request", "invalid client_assertion")
+				return
+			}
+			channelId, checkSecret = a.Iss, false
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if !s.checkChannel(channelId, channelSecret, checkSecret) {
+			writeOAuthError(w, http.StatusBadRequest, "invalid_client", "invalid client_id or client_secret")
+			return
+		}
+		writeJSON(w, http.StatusOK, channel_access_token.IssueStatelessChannelAccessTokenResponse{
+			AccessToken: s.issue(channelId, "", statelessTokenTTL),
+			ExpiresIn:   int32(statelessTokenTTL / time.Second),
+			TokenType:   "Bearer",
+		})
+	})
+	mux.HandleFunc("GET /oauth2/v2.1/tokens/kid", func(w http.ResponseWriter, r *http.Request) {
+		a, ok := parseAssertion(r.URL.Query().Get("client_assertion"))
+		if !ok {
+			writeOAuthError(w, http.StatusBadRequest, "invalid_request", "invalid client_assertion")
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		kids := []string{}
+		for accessToken, t := range s.issued {
+			if t.channelId == a.Iss && t.keyId != "" && s.valid(accessToken) != nil {
+				kids = append(kids, t.keyId)
+			}
+		}
+		slices.Sort(kids)
+		writeJSON(w, http.StatusOK, channel_access_token.ChannelAccessTokenKeyIdsResponse{Kids: kids})
+	})
+	revoke := func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if t, ok := s.issued[r.FormValue("access_token")]; ok {
+			t.revoked = true
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+	mux.HandleFunc("POST /v2/oauth/revoke", revoke)
+	mux.HandleFunc("POST /oauth2/v2.1/revoke", revoke)
+	verify := func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		t := s.valid(r.FormValue("access_token"))
+		if t == nil {
+			writeOAuthError(w, http.StatusBadRequest, "invalid_request", "access token expired")
+			return
+		}
+		writeJSON(w, http.StatusOK, channel_access_token.VerifyChannelAccessTokenResponse{
+			ClientId:  t.channelId,
+			ExpiresIn: int64(t.expiresAt.Sub(s.now()) / time.Second),
+			Scope:     "profile chat_message.write",
+		})
+	}
+	mux.HandleFunc("POST /v2/oauth/verify", verify)
+	mux.HandleFunc("GET /oauth2/v2.1/verify", verify)
+}
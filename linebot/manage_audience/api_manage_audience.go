@@ -34,24 +34,27 @@ import (
 	"strings"
 
 	"github.com/line/line-bot-sdk-go/v8/linebot"
+	"github.com/line/line-bot-sdk-go/v8/linebot/channel_access_token"
+	"github.com/line/line-bot-sdk-go/v8/linebot/ratelimit"
+	"github.com/line/line-bot-sdk-go/v8/linebot/retry"
 )
 
 type ManageAudienceAPI struct {
 	httpClient   *http.Client
 	endpoint     *url.URL
 	channelToken string
+	tokenSource  channel_access_token.TokenSource
 	ctx          context.Context
+	retryPolicy  *retry.Policy
+	rateLimiter  *ratelimit.Limiter
 }
 
 // ManageAudienceAPIOption type
 type ManageAudienceAPIOption func(*ManageAudienceAPI) error
 
 // New returns a new bot client instance.
+// channelToken may be empty if a TokenSource is given with WithTokenSource.
 func NewManageAudienceAPI(channelToken string, options ...ManageAudienceAPIOption) (*ManageAudienceAPI, error) {
-	if channelToken == "" {
-		return nil, errors.New("missing channel access token")
-	}
-
 	c := &ManageAudienceAPI{
 		channelToken: channelToken,
 		httpClient:   http.DefaultClient,
@@ -69,24 +72,61 @@ func NewManageAudienceAPI(channelToken string, options ...ManageAudienceAPIOptio
 			return nil, err
 		}
 	}
+	if c.channelToken == "" && c.tokenSource == nil {
+		return nil, errors.New("missing channel access token")
+	}
 	return c, nil
 }
 
 // WithContext method
+// The context is stored on the client and shared by every call made through it.
+// To use a different context for each call, use the methods with the Ctx suffix instead.
 func (call *ManageAudienceAPI) WithContext(ctx context.Context) *ManageAudienceAPI {
 	call.ctx = ctx
 	return call
 }
 
 func (client *ManageAudienceAPI) Do(req *http.Request) (*http.Response, error) {
-	if client.channelToken != "" {
+	if client.ctx != nil {
+		req = req.WithContext(client.ctx)
+	}
+	return client.do(req)
+}
+
+// do sends req with the context it already carries.
+func (client *ManageAudienceAPI) do(req *http.Request) (*http.Response, error) {
+	if client.retryPolicy != nil {
+		return client.retryPolicy.Do(req, client.send)
+	}
+	return client.send(req)
+}
+
+// send sends a single attempt of req.
+func (client *ManageAudienceAPI) send(req *http.Request) (*http.Response, error) {
+	if client.rateLimiter != nil {
+		if err := client.rateLimiter.Wait(req); err != nil {
+			return nil, err
+		}
+	}
+	if client.tokenSource != nil {
+		token, err := client.tokenSource.Token(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else if client.channelToken != "" {
 		req.Header.Set("Authorization", "Bearer "+client.channelToken)
 	}
 	req.Header.Set("User-Agent", "LINE-BotSDK-Go/"+linebot.GetVersion())
+	return client.httpClient.Do(req)
+}
+
+// requestContext returns the context set by WithContext, or context.Background if there is none.
+func (client *ManageAudienceAPI) requestContext() context.Context {
 	if client.ctx != nil {
-		req = req.WithContext(client.ctx)
+		return client.ctx
 	}
-	return client.httpClient.Do(req)
+	return context.Background()
 }
 
 func (client *ManageAudienceAPI) Url(endpointPath string) string {
@@ -104,6 +144,34 @@ func WithHTTPClient(c *http.Client) ManageAudienceAPIOption {
 	}
 }
 
+// WithTokenSource function
+// Every request uses a token obtained from ts instead of the channel access token given to NewManageAudienceAPI.
+func WithTokenSource(ts channel_access_token.TokenSource) ManageAudienceAPIOption {
+	return func(client *ManageAudienceAPI) error {
+		client.tokenSource = ts
+		return nil
+	}
+}
+
+// WithRetryPolicy function
+// Failed requests are retried according to policy. See the retry package for which requests are retried.
+func WithRetryPolicy(policy *retry.Policy) ManageAudienceAPIOption {
+	return func(client *ManageAudienceAPI) error {
+		client.retryPolicy = policy
+		return nil
+	}
+}
+
+// WithRateLimiter function
+// Every request, including retries, waits for limiter before it is sent.
+// Share one limiter between the clients of the same channel.
+func WithRateLimiter(limiter *ratelimit.Limiter) ManageAudienceAPIOption {
+	return func(client *ManageAudienceAPI) error {
+		client.rateLimiter = limiter
+		return nil
+	}
+}
+
 // WithEndpoint function
 func WithEndpoint(endpoint string) ManageAudienceAPIOption {
 	return func(client *ManageAudienceAPI) error {
@@ -135,6 +203,20 @@ func (client *ManageAudienceAPI) AddAudienceToAudienceGroup(
 	return body, error
 }
 
+// AddAudienceToAudienceGroupCtx
+// The same as AddAudienceToAudienceGroup, but the request uses ctx instead of the context set by WithContext.
+func (client *ManageAudienceAPI) AddAudienceToAudienceGroupCtx(
+	ctx context.Context,
+	addAudienceToAudienceGroupRequest *AddAudienceToAudienceGroupRequest,
+
+) (struct{}, error) {
+	_, body, error := client.AddAudienceToAudienceGroupWithHttpInfoCtx(
+		ctx,
+		addAudienceToAudienceGroupRequest,
+	)
+	return body, error
+}
+
 // AddAudienceToAudienceGroup
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -147,6 +229,19 @@ func (client *ManageAudienceAPI) AddAudienceToAudienceGroupWithHttpInfo(
 
 	addAudienceToAudienceGroupRequest *AddAudienceToAudienceGroupRequest,
 
+) (*http.Response, struct{}, error) {
+	return client.AddAudienceToAudienceGroupWithHttpInfoCtx(
+		client.requestContext(),
+		addAudienceToAudienceGroupRequest,
+	)
+}
+
+// AddAudienceToAudienceGroupWithHttpInfoCtx
+// The same as AddAudienceToAudienceGroupWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *ManageAudienceAPI) AddAudienceToAudienceGroupWithHttpInfoCtx(
+	ctx context.Context,
+	addAudienceToAudienceGroupRequest *AddAudienceToAudienceGroupRequest,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/audienceGroup/upload"
 
@@ -155,13 +250,13 @@ func (client *ManageAudienceAPI) AddAudienceToAudienceGroupWithHttpInfo(
 	if err := enc.Encode(addAudienceToAudienceGroupRequest); err != nil {
 		return nil, struct{}{}, err
 	}
-	req, err := http.NewRequest(http.MethodPut, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, client.Url(path), &buf)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -174,7 +269,7 @@ func (client *ManageAudienceAPI) AddAudienceToAudienceGroupWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -202,6 +297,20 @@ func (client *ManageAudienceAPI) CreateAudienceGroup(
 	return body, error
 }
 
+// CreateAudienceGroupCtx
+// The same as CreateAudienceGroup, but the request uses ctx instead of the context set by WithContext.
+func (client *ManageAudienceAPI) CreateAudienceGroupCtx(
+	ctx context.Context,
+	createAudienceGroupRequest *CreateAudienceGroupRequest,
+
+) (*CreateAudienceGroupResponse, error) {
+	_, body, error := client.CreateAudienceGroupWithHttpInfoCtx(
+		ctx,
+		createAudienceGroupRequest,
+	)
+	return body, error
+}
+
 // CreateAudienceGroup
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -214,6 +323,19 @@ func (client *ManageAudienceAPI) CreateAudienceGroupWithHttpInfo(
 
 	createAudienceGroupRequest *CreateAudienceGroupRequest,
 
+) (*http.Response, *CreateAudienceGroupResponse, error) {
+	return client.CreateAudienceGroupWithHttpInfoCtx(
+		client.requestContext(),
+		createAudienceGroupRequest,
+	)
+}
+
+// CreateAudienceGroupWithHttpInfoCtx
+// The same as CreateAudienceGroupWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *ManageAudienceAPI) CreateAudienceGroupWithHttpInfoCtx(
+	ctx context.Context,
+	createAudienceGroupRequest *CreateAudienceGroupRequest,
+
 ) (*http.Response, *CreateAudienceGroupResponse, error) {
 	path := "/v2/bot/audienceGroup/upload"
 
@@ -222,13 +344,13 @@ func (client *ManageAudienceAPI) CreateAudienceGroupWithHttpInfo(
 	if err := enc.Encode(createAudienceGroupRequest); err != nil {
 		return nil, nil, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, nil, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -241,7 +363,7 @@ func (client *ManageAudienceAPI) CreateAudienceGroupWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -274,6 +396,20 @@ func (client *ManageAudienceAPI) CreateClickBasedAudienceGroup(
 	return body, error
 }
 
+// CreateClickBasedAudienceGroupCtx
+// The same as CreateClickBasedAudienceGroup, but the request uses ctx instead of the context set by WithContext.
+func (client *ManageAudienceAPI) CreateClickBasedAudienceGroupCtx(
+	ctx context.Context,
+	createClickBasedAudienceGroupRequest *CreateClickBasedAudienceGroupRequest,
+
+) (*CreateClickBasedAudienceGroupResponse, error) {
+	_, body, error := client.CreateClickBasedAudienceGroupWithHttpInfoCtx(
+		ctx,
+		createClickBasedAudienceGroupRequest,
+	)
+	return body, error
+}
+
 // CreateClickBasedAudienceGroup
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -286,6 +422,19 @@ func (client *ManageAudienceAPI) CreateClickBasedAudienceGroupWithHttpInfo(
 
 	createClickBasedAudienceGroupRequest *CreateClickBasedAudienceGroupRequest,
 
+) (*http.Response, *CreateClickBasedAudienceGroupResponse, error) {
+	return client.CreateClickBasedAudienceGroupWithHttpInfoCtx(
+		client.requestContext(),
+		createClickBasedAudienceGroupRequest,
+	)
+}
+
+// CreateClickBasedAudienceGroupWithHttpInfoCtx
+// The same as CreateClickBasedAudienceGroupWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *ManageAudienceAPI) CreateClickBasedAudienceGroupWithHttpInfoCtx(
+	ctx context.Context,
+	createClickBasedAudienceGroupRequest *CreateClickBasedAudienceGroupRequest,
+
 ) (*http.Response, *CreateClickBasedAudienceGroupResponse, error) {
 	path := "/v2/bot/audienceGroup/click"
 
@@ -294,13 +443,13 @@ func (client *ManageAudienceAPI) CreateClickBasedAudienceGroupWithHttpInfo(
 	if err := enc.Encode(createClickBasedAudienceGroupRequest); err != nil {
 		return nil, nil, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, nil, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -313,7 +462,7 @@ func (client *ManageAudienceAPI) CreateClickBasedAudienceGroupWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -346,6 +495,20 @@ func (client *ManageAudienceAPI) CreateImpBasedAudienceGroup(
 	return body, error
 }
 
+// CreateImpBasedAudienceGroupCtx
+// The same as CreateImpBasedAudienceGroup, but the request uses ctx instead of the context set by WithContext.
+func (client *ManageAudienceAPI) CreateImpBasedAudienceGroupCtx(
+	ctx context.Context,
+	createImpBasedAudienceGroupRequest *CreateImpBasedAudienceGroupRequest,
+
+) (*CreateImpBasedAudienceGroupResponse, error) {
+	_, body, error := client.CreateImpBasedAudienceGroupWithHttpInfoCtx(
+		ctx,
+		createImpBasedAudienceGroupRequest,
+	)
+	return body, error
+}
+
 // CreateImpBasedAudienceGroup
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -358,6 +521,19 @@ func (client *ManageAudienceAPI) CreateImpBasedAudienceGroupWithHttpInfo(
 
 	createImpBasedAudienceGroupRequest *CreateImpBasedAudienceGroupRequest,
 
+) (*http.Response, *CreateImpBasedAudienceGroupResponse, error) {
+	return client.CreateImpBasedAudienceGroupWithHttpInfoCtx(
+		client.requestContext(),
+		createImpBasedAudienceGroupRequest,
+	)
+}
+
+// CreateImpBasedAudienceGroupWithHttpInfoCtx
+// The same as CreateImpBasedAudienceGroupWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *ManageAudienceAPI) CreateImpBasedAudienceGroupWithHttpInfoCtx(
+	ctx context.Context,
+	createImpBasedAudienceGroupRequest *CreateImpBasedAudienceGroupRequest,
+
 ) (*http.Response, *CreateImpBasedAudienceGroupResponse, error) {
 	path := "/v2/bot/audienceGroup/imp"
 
@@ -366,13 +542,13 @@ func (client *ManageAudienceAPI) CreateImpBasedAudienceGroupWithHttpInfo(
 	if err := enc.Encode(createImpBasedAudienceGroupRequest); err != nil {
 		return nil, nil, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, nil, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -385,7 +561,7 @@ func (client *ManageAudienceAPI) CreateImpBasedAudienceGroupWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -418,6 +594,20 @@ func (client *ManageAudienceAPI) DeleteAudienceGroup(
 	return body, error
 }
 
+// DeleteAudienceGroupCtx
+// The same as DeleteAudienceGroup, but the request uses ctx instead of the context set by WithContext.
+func (client *ManageAudienceAPI) DeleteAudienceGroupCtx(
+	ctx context.Context,
+	audienceGroupId int64,
+
+) (struct{}, error) {
+	_, body, error := client.DeleteAudienceGroupWithHttpInfoCtx(
+		ctx,
+		audienceGroupId,
+	)
+	return body, error
+}
+
 // DeleteAudienceGroup
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -430,17 +620,30 @@ func (client *ManageAudienceAPI) DeleteAudienceGroupWithHttpInfo(
 
 	audienceGroupId int64,
 
+) (*http.Response, struct{}, error) {
+	return client.DeleteAudienceGroupWithHttpInfoCtx(
+		client.requestContext(),
+		audienceGroupId,
+	)
+}
+
+// DeleteAudienceGroupWithHttpInfoCtx
+// The same as DeleteAudienceGroupWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *ManageAudienceAPI) DeleteAudienceGroupWithHttpInfoCtx(
+	ctx context.Context,
+	audienceGroupId int64,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/audienceGroup/{audienceGroupId}"
 
 	path = strings.Replace(path, "{audienceGroupId}", strconv.FormatInt(audienceGroupId, 10), -1)
 
-	req, err := http.NewRequest(http.MethodDelete, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, client.Url(path), nil)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -453,7 +656,7 @@ func (client *ManageAudienceAPI) DeleteAudienceGroupWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -481,6 +684,20 @@ func (client *ManageAudienceAPI) GetAudienceData(
 	return body, error
 }
 
+// GetAudienceDataCtx
+// The same as GetAudienceData, but the request uses ctx instead of the context set by WithContext.
+func (client *ManageAudienceAPI) GetAudienceDataCtx(
+	ctx context.Context,
+	audienceGroupId int64,
+
+) (*GetAudienceDataResponse, error) {
+	_, body, error := client.GetAudienceDataWithHttpInfoCtx(
+		ctx,
+		audienceGroupId,
+	)
+	return body, error
+}
+
 // GetAudienceData
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -493,17 +710,30 @@ func (client *ManageAudienceAPI) GetAudienceDataWithHttpInfo(
 
 	audienceGroupId int64,
 
+) (*http.Response, *GetAudienceDataResponse, error) {
+	return client.GetAudienceDataWithHttpInfoCtx(
+		client.requestContext(),
+		audienceGroupId,
+	)
+}
+
+// GetAudienceDataWithHttpInfoCtx
+// The same as GetAudienceDataWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *ManageAudienceAPI) GetAudienceDataWithHttpInfoCtx(
+	ctx context.Context,
+	audienceGroupId int64,
+
 ) (*http.Response, *GetAudienceDataResponse, error) {
 	path := "/v2/bot/audienceGroup/{audienceGroupId}"
 
 	path = strings.Replace(path, "{audienceGroupId}", strconv.FormatInt(audienceGroupId, 10), -1)
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -516,7 +746,7 @@ func (client *ManageAudienceAPI) GetAudienceDataWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -574,6 +804,40 @@ func (client *ManageAudienceAPI) GetAudienceGroups(
 	return body, error
 }
 
+// GetAudienceGroupsCtx
+// The same as GetAudienceGroups, but the request uses ctx instead of the context set by WithContext.
+func (client *ManageAudienceAPI) GetAudienceGroupsCtx(
+	ctx context.Context,
+	page int64,
+
+	description string,
+
+	status AudienceGroupStatus,
+
+	size int64,
+
+	includesExternalPublicGroups bool,
+
+	createRoute AudienceGroupCreateRoute,
+
+) (*GetAudienceGroupsResponse, error) {
+	_, body, error := client.GetAudienceGroupsWithHttpInfoCtx(
+		ctx,
+		page,
+
+		description,
+
+		status,
+
+		size,
+
+		includesExternalPublicGroups,
+
+		createRoute,
+	)
+	return body, error
+}
+
 // GetAudienceGroups
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -601,10 +865,43 @@ func (client *ManageAudienceAPI) GetAudienceGroupsWithHttpInfo(
 
 	createRoute AudienceGroupCreateRoute,
 
+) (*http.Response, *GetAudienceGroupsResponse, error) {
+	return client.GetAudienceGroupsWithHttpInfoCtx(
+		client.requestContext(),
+		page,
+
+		description,
+
+		status,
+
+		size,
+
+		includesExternalPublicGroups,
+
+		createRoute,
+	)
+}
+
+// GetAudienceGroupsWithHttpInfoCtx
+// The same as GetAudienceGroupsWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *ManageAudienceAPI) GetAudienceGroupsWithHttpInfoCtx(
+	ctx context.Context,
+	page int64,
+
+	description string,
+
+	status AudienceGroupStatus,
+
+	size int64,
+
+	includesExternalPublicGroups bool,
+
+	createRoute AudienceGroupCreateRoute,
+
 ) (*http.Response, *GetAudienceGroupsResponse, error) {
 	path := "/v2/bot/audienceGroup/list"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -621,7 +918,7 @@ func (client *ManageAudienceAPI) GetAudienceGroupsWithHttpInfo(
 
 	req.URL.RawQuery = query.Encode()
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -634,7 +931,7 @@ func (client *ManageAudienceAPI) GetAudienceGroupsWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -667,6 +964,20 @@ func (client *ManageAudienceAPI) GetSharedAudienceData(
 	return body, error
 }
 
+// GetSharedAudienceDataCtx
+// The same as GetSharedAudienceData, but the request uses ctx instead of the context set by WithContext.
+func (client *ManageAudienceAPI) GetSharedAudienceDataCtx(
+	ctx context.Context,
+	audienceGroupId int64,
+
+) (*GetSharedAudienceDataResponse, error) {
+	_, body, error := client.GetSharedAudienceDataWithHttpInfoCtx(
+		ctx,
+		audienceGroupId,
+	)
+	return body, error
+}
+
 // GetSharedAudienceData
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -679,17 +990,30 @@ func (client *ManageAudienceAPI) GetSharedAudienceDataWithHttpInfo(
 
 	audienceGroupId int64,
 
+) (*http.Response, *GetSharedAudienceDataResponse, error) {
+	return client.GetSharedAudienceDataWithHttpInfoCtx(
+		client.requestContext(),
+		audienceGroupId,
+	)
+}
+
+// GetSharedAudienceDataWithHttpInfoCtx
+// The same as GetSharedAudienceDataWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *ManageAudienceAPI) GetSharedAudienceDataWithHttpInfoCtx(
+	ctx context.Context,
+	audienceGroupId int64,
+
 ) (*http.Response, *GetSharedAudienceDataResponse, error) {
 	path := "/v2/bot/audienceGroup/shared/{audienceGroupId}"
 
 	path = strings.Replace(path, "{audienceGroupId}", strconv.FormatInt(audienceGroupId, 10), -1)
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -702,7 +1026,7 @@ func (client *ManageAudienceAPI) GetSharedAudienceDataWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -760,6 +1084,40 @@ func (client *ManageAudienceAPI) GetSharedAudienceGroups(
 	return body, error
 }
 
+// GetSharedAudienceGroupsCtx
+// The same as GetSharedAudienceGroups, but the request uses ctx instead of the context set by WithContext.
+func (client *ManageAudienceAPI) GetSharedAudienceGroupsCtx(
+	ctx context.Context,
+	page int64,
+
+	description string,
+
+	status AudienceGroupStatus,
+
+	size int64,
+
+	createRoute AudienceGroupCreateRoute,
+
+	includesOwnedAudienceGroups bool,
+
+) (*GetSharedAudienceGroupsResponse, error) {
+	_, body, error := client.GetSharedAudienceGroupsWithHttpInfoCtx(
+		ctx,
+		page,
+
+		description,
+
+		status,
+
+		size,
+
+		createRoute,
+
+		includesOwnedAudienceGroups,
+	)
+	return body, error
+}
+
 // GetSharedAudienceGroups
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -787,10 +1145,43 @@ func (client *ManageAudienceAPI) GetSharedAudienceGroupsWithHttpInfo(
 
 	includesOwnedAudienceGroups bool,
 
+) (*http.Response, *GetSharedAudienceGroupsResponse, error) {
+	return client.GetSharedAudienceGroupsWithHttpInfoCtx(
+		client.requestContext(),
+		page,
+
+		description,
+
+		status,
+
+		size,
+
+		createRoute,
+
+		includesOwnedAudienceGroups,
+	)
+}
+
+// GetSharedAudienceGroupsWithHttpInfoCtx
+// The same as GetSharedAudienceGroupsWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *ManageAudienceAPI) GetSharedAudienceGroupsWithHttpInfoCtx(
+	ctx context.Context,
+	page int64,
+
+	description string,
+
+	status AudienceGroupStatus,
+
+	size int64,
+
+	createRoute AudienceGroupCreateRoute,
+
+	includesOwnedAudienceGroups bool,
+
 ) (*http.Response, *GetSharedAudienceGroupsResponse, error) {
 	path := "/v2/bot/audienceGroup/shared/list"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -807,7 +1198,7 @@ func (client *ManageAudienceAPI) GetSharedAudienceGroupsWithHttpInfo(
 
 	req.URL.RawQuery = query.Encode()
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -820,7 +1211,7 @@ func (client *ManageAudienceAPI) GetSharedAudienceGroupsWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -858,6 +1249,24 @@ func (client *ManageAudienceAPI) UpdateAudienceGroupDescription(
 	return body, error
 }
 
+// UpdateAudienceGroupDescriptionCtx
+// The same as UpdateAudienceGroupDescription, but the request uses ctx instead of the context set by WithContext.
+func (client *ManageAudienceAPI) UpdateAudienceGroupDescriptionCtx(
+	ctx context.Context,
+	audienceGroupId int64,
+
+	updateAudienceGroupDescriptionRequest *UpdateAudienceGroupDescriptionRequest,
+
+) (struct{}, error) {
+	_, body, error := client.UpdateAudienceGroupDescriptionWithHttpInfoCtx(
+		ctx,
+		audienceGroupId,
+
+		updateAudienceGroupDescriptionRequest,
+	)
+	return body, error
+}
+
 // UpdateAudienceGroupDescription
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -873,6 +1282,23 @@ func (client *ManageAudienceAPI) UpdateAudienceGroupDescriptionWithHttpInfo(
 
 	updateAudienceGroupDescriptionRequest *UpdateAudienceGroupDescriptionRequest,
 
+) (*http.Response, struct{}, error) {
+	return client.UpdateAudienceGroupDescriptionWithHttpInfoCtx(
+		client.requestContext(),
+		audienceGroupId,
+
+		updateAudienceGroupDescriptionRequest,
+	)
+}
+
+// UpdateAudienceGroupDescriptionWithHttpInfoCtx
+// The same as UpdateAudienceGroupDescriptionWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *ManageAudienceAPI) UpdateAudienceGroupDescriptionWithHttpInfoCtx(
+	ctx context.Context,
+	audienceGroupId int64,
+
+	updateAudienceGroupDescriptionRequest *UpdateAudienceGroupDescriptionRequest,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/audienceGroup/{audienceGroupId}/updateDescription"
 
@@ -883,13 +1309,13 @@ func (client *ManageAudienceAPI) UpdateAudienceGroupDescriptionWithHttpInfo(
 	if err := enc.Encode(updateAudienceGroupDescriptionRequest); err != nil {
 		return nil, struct{}{}, err
 	}
-	req, err := http.NewRequest(http.MethodPut, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, client.Url(path), &buf)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -902,7 +1328,7 @@ func (client *ManageAudienceAPI) UpdateAudienceGroupDescriptionWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -35,24 +35,27 @@ import (
 	"strconv"
 
 	"github.com/line/line-bot-sdk-go/v8/linebot"
+	"github.com/line/line-bot-sdk-go/v8/linebot/channel_access_token"
+	"github.com/line/line-bot-sdk-go/v8/linebot/ratelimit"
+	"github.com/line/line-bot-sdk-go/v8/linebot/retry"
 )
 
 type ManageAudienceBlobAPI struct {
 	httpClient   *http.Client
 	endpoint     *url.URL
 	channelToken string
+	tokenSource  channel_access_token.TokenSource
 	ctx          context.Context
+	retryPolicy  *retry.Policy
+	rateLimiter  *ratelimit.Limiter
 }
 
 // ManageAudienceBlobAPIOption type
 type ManageAudienceBlobAPIOption func(*ManageAudienceBlobAPI) error
 
 // New returns a new bot client instance.
+// channelToken may be empty if a TokenSource is given with WithBlobTokenSource.
 func NewManageAudienceBlobAPI(channelToken string, options ...ManageAudienceBlobAPIOption) (*ManageAudienceBlobAPI, error) {
-	if channelToken == "" {
-		return nil, errors.New("missing channel access token")
-	}
-
 	c := &ManageAudienceBlobAPI{
 		channelToken: channelToken,
 		httpClient:   http.DefaultClient,
@@ -70,24 +73,61 @@ func NewManageAudienceBlobAPI(channelToken string, options ...ManageAudienceBlob
 			return nil, err
 		}
 	}
+	if c.channelToken == "" && c.tokenSource == nil {
+		return nil, errors.New("missing channel access token")
+	}
 	return c, nil
 }
 
 // WithContext method
+// The context is stored on the client and shared by every call made through it.
+// To use a different context for each call, use the methods with the Ctx suffix instead.
 func (call *ManageAudienceBlobAPI) WithContext(ctx context.Context) *ManageAudienceBlobAPI {
 	call.ctx = ctx
 	return call
 }
 
 func (client *ManageAudienceBlobAPI) Do(req *http.Request) (*http.Response, error) {
-	if client.channelToken != "" {
+	if client.ctx != nil {
+		req = req.WithContext(client.ctx)
+	}
+	return client.do(req)
+}
+
+// do sends req with the context it already carries.
+func (client *ManageAudienceBlobAPI) do(req *http.Request) (*http.Response, error) {
+	if client.retryPolicy != nil {
+		return client.retryPolicy.Do(req, client.send)
+	}
+	return client.send(req)
+}
+
+// send sends a single attempt of req.
+func (client *ManageAudienceBlobAPI) send(req *http.Request) (*http.Response, error) {
+	if client.rateLimiter != nil {
+		if err := client.rateLimiter.Wait(req); err != nil {
+			return nil, err
+		}
+	}
+	if client.tokenSource != nil {
+		token, err := client.tokenSource.Token(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else if client.channelToken != "" {
 		req.Header.Set("Authorization", "Bearer "+client.channelToken)
 	}
 	req.Header.Set("User-Agent", "LINE-BotSDK-Go/"+linebot.GetVersion())
+	return client.httpClient.Do(req)
+}
+
+// requestContext returns the context set by WithContext, or context.Background if there is none.
+func (client *ManageAudienceBlobAPI) requestContext() context.Context {
 	if client.ctx != nil {
-		req = req.WithContext(client.ctx)
+		return client.ctx
 	}
-	return client.httpClient.Do(req)
+	return context.Background()
 }
 
 func (client *ManageAudienceBlobAPI) Url(endpointPath string) string {
@@ -105,6 +145,34 @@ func WithBlobHTTPClient(c *http.Client) ManageAudienceBlobAPIOption {
 	}
 }
 
+// WithBlobTokenSource function
+// Every request uses a token obtained from ts instead of the channel access token given to NewManageAudienceBlobAPI.
+func WithBlobTokenSource(ts channel_access_token.TokenSource) ManageAudienceBlobAPIOption {
+	return func(client *ManageAudienceBlobAPI) error {
+		client.tokenSource = ts
+		return nil
+	}
+}
+
+// WithBlobRetryPolicy function
+// Failed requests are retried according to policy. See the retry package for which requests are retried.
+func WithBlobRetryPolicy(policy *retry.Policy) ManageAudienceBlobAPIOption {
+	return func(client *ManageAudienceBlobAPI) error {
+		client.retryPolicy = policy
+		return nil
+	}
+}
+
+// WithBlobRateLimiter function
+// Every request, including retries, waits for limiter before it is sent.
+// Share one limiter between the clients of the same channel.
+func WithBlobRateLimiter(limiter *ratelimit.Limiter) ManageAudienceBlobAPIOption {
+	return func(client *ManageAudienceBlobAPI) error {
+		client.rateLimiter = limiter
+		return nil
+	}
+}
+
 // WithBlobEndpoint function
 func WithBlobEndpoint(endpoint string) ManageAudienceBlobAPIOption {
 	return func(client *ManageAudienceBlobAPI) error {
@@ -146,6 +214,28 @@ func (client *ManageAudienceBlobAPI) AddUserIdsToAudience(
 	return body, error
 }
 
+// AddUserIdsToAudienceCtx
+// The same as AddUserIdsToAudience, but the request uses ctx instead of the context set by WithContext.
+func (client *ManageAudienceBlobAPI) AddUserIdsToAudienceCtx(
+	ctx context.Context,
+	file *os.File,
+
+	audienceGroupId int64,
+
+	uploadDescription string,
+
+) (struct{}, error) {
+	_, body, error := client.AddUserIdsToAudienceWithHttpInfoCtx(
+		ctx,
+		file,
+
+		audienceGroupId,
+
+		uploadDescription,
+	)
+	return body, error
+}
+
 // AddUserIdsToAudience
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -164,6 +254,27 @@ func (client *ManageAudienceBlobAPI) AddUserIdsToAudienceWithHttpInfo(
 
 	uploadDescription string,
 
+) (*http.Response, struct{}, error) {
+	return client.AddUserIdsToAudienceWithHttpInfoCtx(
+		client.requestContext(),
+		file,
+
+		audienceGroupId,
+
+		uploadDescription,
+	)
+}
+
+// AddUserIdsToAudienceWithHttpInfoCtx
+// The same as AddUserIdsToAudienceWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *ManageAudienceBlobAPI) AddUserIdsToAudienceWithHttpInfoCtx(
+	ctx context.Context,
+	file *os.File,
+
+	audienceGroupId int64,
+
+	uploadDescription string,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/audienceGroup/upload/byFile"
 
@@ -185,13 +296,13 @@ func (client *ManageAudienceBlobAPI) AddUserIdsToAudienceWithHttpInfo(
 		return nil, struct{}{}, err
 	}
 
-	req, err := http.NewRequest(http.MethodPut, client.Url(path), body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, client.Url(path), body)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -204,7 +315,7 @@ func (client *ManageAudienceBlobAPI) AddUserIdsToAudienceWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -247,6 +358,32 @@ func (client *ManageAudienceBlobAPI) CreateAudienceForUploadingUserIds(
 	return body, error
 }
 
+// CreateAudienceForUploadingUserIdsCtx
+// The same as CreateAudienceForUploadingUserIds, but the request uses ctx instead of the context set by WithContext.
+func (client *ManageAudienceBlobAPI) CreateAudienceForUploadingUserIdsCtx(
+	ctx context.Context,
+	file *os.File,
+
+	description string,
+
+	isIfaAudience bool,
+
+	uploadDescription string,
+
+) (*CreateAudienceGroupResponse, error) {
+	_, body, error := client.CreateAudienceForUploadingUserIdsWithHttpInfoCtx(
+		ctx,
+		file,
+
+		description,
+
+		isIfaAudience,
+
+		uploadDescription,
+	)
+	return body, error
+}
+
 // CreateAudienceForUploadingUserIds
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -268,6 +405,31 @@ func (client *ManageAudienceBlobAPI) CreateAudienceForUploadingUserIdsWithHttpIn
 
 	uploadDescription string,
 
+) (*http.Response, *CreateAudienceGroupResponse, error) {
+	return client.CreateAudienceForUploadingUserIdsWithHttpInfoCtx(
+		client.requestContext(),
+		file,
+
+		description,
+
+		isIfaAudience,
+
+		uploadDescription,
+	)
+}
+
+// CreateAudienceForUploadingUserIdsWithHttpInfoCtx
+// The same as CreateAudienceForUploadingUserIdsWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *ManageAudienceBlobAPI) CreateAudienceForUploadingUserIdsWithHttpInfoCtx(
+	ctx context.Context,
+	file *os.File,
+
+	description string,
+
+	isIfaAudience bool,
+
+	uploadDescription string,
+
 ) (*http.Response, *CreateAudienceGroupResponse, error) {
 	path := "/v2/bot/audienceGroup/upload/byFile"
 
@@ -291,13 +453,13 @@ func (client *ManageAudienceBlobAPI) CreateAudienceForUploadingUserIdsWithHttpIn
 		return nil, nil, err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), body)
 	if err != nil {
 		return nil, nil, err
 	}
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -310,7 +472,7 @@ func (client *ManageAudienceBlobAPI) CreateAudienceForUploadingUserIdsWithHttpIn
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
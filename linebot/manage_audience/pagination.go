@@ -0,0 +1,84 @@
+package manage_audience
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/pagination"
+)
+
+// AudienceGroupsFilter holds the filters of GetAudienceGroups. Zero values do not filter.
+type AudienceGroupsFilter struct {
+	// Description matches the audiences whose name contains it.
+	Description string
+	Status      AudienceGroupStatus
+	// IncludesExternalPublicGroups includes the audiences shared in Business Manager.
+	IncludesExternalPublicGroups bool
+	CreateRoute                  AudienceGroupCreateRoute
+}
+
+// AudienceGroups returns an iterator over the audiences that match filter.
+// See GetAudienceGroups. The default page size is 20 and the maximum is 40.
+func (client *ManageAudienceAPI) AudienceGroups(ctx context.Context, filter AudienceGroupsFilter, options ...pagination.Option) iter.Seq2[AudienceGroup, error] {
+	config := pagination.NewConfig(options...)
+	size := int64(config.PageSizeOr(20))
+	return pagination.Seq(ctx, config, int64(1), func(ctx context.Context, page int64) (pagination.Page[AudienceGroup, int64], error) {
+		resp, err := client.getAudienceGroups(ctx, page, size, filter)
+		if err != nil {
+			return pagination.Page[AudienceGroup, int64]{}, err
+		}
+		return pagination.Page[AudienceGroup, int64]{Items: resp.AudienceGroups, Next: page + 1, HasNext: resp.HasNextPage}, nil
+	})
+}
+
+// getAudienceGroups is GetAudienceGroupsCtx, but it leaves out the empty
+// status and create route, which GetAudienceGroupsCtx sends as is.
+func (client *ManageAudienceAPI) getAudienceGroups(ctx context.Context, page, size int64, filter AudienceGroupsFilter) (*GetAudienceGroupsResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url("/v2/bot/audienceGroup/list"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Add("page", strconv.FormatInt(page, 10))
+	if filter.Description != "" {
+		query.Add("description", filter.Description)
+	}
+	if filter.Status != "" {
+		query.Add("status", string(filter.Status))
+	}
+	query.Add("size", strconv.FormatInt(size, 10))
+	query.Add("includesExternalPublicGroups", strconv.FormatBool(filter.IncludesExternalPublicGroups))
+	if filter.CreateRoute != "" {
+		query.Add("createRoute", string(filter.CreateRoute))
+	}
+	req.URL.RawQuery = query.Encode()
+
+	res, err := client.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		bodyBytes, err := io.ReadAll(res.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		res.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+		return nil, newAPIError(res, bodyBytes)
+	}
+
+	result := GetAudienceGroupsResponse{}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,47 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/manage_audience"
+)
+
+func TestAudienceGroupsFilter(t *testing.T) {
+	var queries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		w.Write([]byte(`{"audienceGroups":[{"audienceGroupId":1}],"hasNextPage":false}`))
+	}))
+	defer server.Close()
+	client, err := manage_audience.NewManageAudienceAPI("channelToken", manage_audience.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, filter := range []manage_audience.AudienceGroupsFilter{
+		{},
+		{Status: manage_audience.AudienceGroupStatus_READY, CreateRoute: manage_audience.AudienceGroupCreateRoute_MESSAGING_API},
+	} {
+		for _, err := range client.AudienceGroups(context.Background(), filter) {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	if len(queries) != 2 {
+		t.Fatalf("got %d requests, want 2", len(queries))
+	}
+	for _, key := range []string{"description", "status", "createRoute"} {
+		if queries[0].Has(key) {
+			t.Errorf("the zero filter sent %s=%q", key, queries[0].Get(key))
+		}
+	}
+	if queries[1].Get("status") != "READY" || queries[1].Get("createRoute") != "MESSAGING_API" {
+		t.Errorf("got %v, want the status and the create route", queries[1])
+	}
+}
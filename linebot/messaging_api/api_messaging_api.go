@@ -34,24 +34,27 @@ import (
 	"strings"
 
 	"github.com/line/line-bot-sdk-go/v8/linebot"
+	"github.com/line/line-bot-sdk-go/v8/linebot/channel_access_token"
+	"github.com/line/line-bot-sdk-go/v8/linebot/ratelimit"
+	"github.com/line/line-bot-sdk-go/v8/linebot/retry"
 )
 
 type MessagingApiAPI struct {
 	httpClient   *http.Client
 	endpoint     *url.URL
 	channelToken string
+	tokenSource  channel_access_token.TokenSource
 	ctx          context.Context
+	retryPolicy  *retry.Policy
+	rateLimiter  *ratelimit.Limiter
 }
 
 // MessagingApiAPIOption type
 type MessagingApiAPIOption func(*MessagingApiAPI) error
 
 // New returns a new bot client instance.
+// channelToken may be empty if a TokenSource is given with WithTokenSource.
 func NewMessagingApiAPI(channelToken string, options ...MessagingApiAPIOption) (*MessagingApiAPI, error) {
-	if channelToken == "" {
-		return nil, errors.New("missing channel access token")
-	}
-
 	c := &MessagingApiAPI{
 		channelToken: channelToken,
 		httpClient:   http.DefaultClient,
@@ -69,24 +72,61 @@ func NewMessagingApiAPI(channelToken string, options ...MessagingApiAPIOption) (
 			return nil, err
 		}
 	}
+	if c.channelToken == "" && c.tokenSource == nil {
+		return nil, errors.New("missing channel access token")
+	}
 	return c, nil
 }
 
 // WithContext method
+// The context is stored on the client and shared by every call made through it.
+// To use a different context for each call, use the methods with the Ctx suffix instead.
 func (call *MessagingApiAPI) WithContext(ctx context.Context) *MessagingApiAPI {
 	call.ctx = ctx
 	return call
 }
 
 func (client *MessagingApiAPI) Do(req *http.Request) (*http.Response, error) {
-	if client.channelToken != "" {
+	if client.ctx != nil {
+		req = req.WithContext(client.ctx)
+	}
+	return client.do(req)
+}
+
+// do sends req with the context it already carries.
+func (client *MessagingApiAPI) do(req *http.Request) (*http.Response, error) {
+	if client.retryPolicy != nil {
+		return client.retryPolicy.Do(req, client.send)
+	}
+	return client.send(req)
+}
+
+// send sends a single attempt of req.
+func (client *MessagingApiAPI) send(req *http.Request) (*http.Response, error) {
+	if client.rateLimiter != nil {
+		if err := client.rateLimiter.Wait(req); err != nil {
+			return nil, err
+		}
+	}
+	if client.tokenSource != nil {
+		token, err := client.tokenSource.Token(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else if client.channelToken != "" {
 		req.Header.Set("Authorization", "Bearer "+client.channelToken)
 	}
 	req.Header.Set("User-Agent", "LINE-BotSDK-Go/"+linebot.GetVersion())
+	return client.httpClient.Do(req)
+}
+
+// requestContext returns the context set by WithContext, or context.Background if there is none.
+func (client *MessagingApiAPI) requestContext() context.Context {
 	if client.ctx != nil {
-		req = req.WithContext(client.ctx)
+		return client.ctx
 	}
-	return client.httpClient.Do(req)
+	return context.Background()
 }
 
 func (client *MessagingApiAPI) Url(endpointPath string) string {
@@ -104,6 +144,34 @@ func WithHTTPClient(c *http.Client) MessagingApiAPIOption {
 	}
 }
 
+// WithTokenSource function
+// Every request uses a token obtained from ts instead of the channel access token given to NewMessagingApiAPI.
+func WithTokenSource(ts channel_access_token.TokenSource) MessagingApiAPIOption {
+	return func(client *MessagingApiAPI) error {
+		client.tokenSource = ts
+		return nil
+	}
+}
+
+// WithRetryPolicy function
+// Failed requests are retried according to policy. See the retry package for which requests are retried.
+func WithRetryPolicy(policy *retry.Policy) MessagingApiAPIOption {
+	return func(client *MessagingApiAPI) error {
+		client.retryPolicy = policy
+		return nil
+	}
+}
+
+// WithRateLimiter function
+// Every request, including retries, waits for limiter before it is sent.
+// Share one limiter between the clients of the same channel.
+func WithRateLimiter(limiter *ratelimit.Limiter) MessagingApiAPIOption {
+	return func(client *MessagingApiAPI) error {
+		client.rateLimiter = limiter
+		return nil
+	}
+}
+
 // WithEndpoint function
 func WithEndpoint(endpoint string) MessagingApiAPIOption {
 	return func(client *MessagingApiAPI) error {
@@ -140,6 +208,24 @@ func (client *MessagingApiAPI) Broadcast(
 	return body, error
 }
 
+// BroadcastCtx
+// The same as Broadcast, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) BroadcastCtx(
+	ctx context.Context,
+	broadcastRequest *BroadcastRequest,
+
+	xLineRetryKey string,
+
+) (*map[string]interface{}, error) {
+	_, body, error := client.BroadcastWithHttpInfoCtx(
+		ctx,
+		broadcastRequest,
+
+		xLineRetryKey,
+	)
+	return body, error
+}
+
 // Broadcast
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -155,6 +241,23 @@ func (client *MessagingApiAPI) BroadcastWithHttpInfo(
 
 	xLineRetryKey string,
 
+) (*http.Response, *map[string]interface{}, error) {
+	return client.BroadcastWithHttpInfoCtx(
+		client.requestContext(),
+		broadcastRequest,
+
+		xLineRetryKey,
+	)
+}
+
+// BroadcastWithHttpInfoCtx
+// The same as BroadcastWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) BroadcastWithHttpInfoCtx(
+	ctx context.Context,
+	broadcastRequest *BroadcastRequest,
+
+	xLineRetryKey string,
+
 ) (*http.Response, *map[string]interface{}, error) {
 	path := "/v2/bot/message/broadcast"
 
@@ -163,7 +266,7 @@ func (client *MessagingApiAPI) BroadcastWithHttpInfo(
 	if err := enc.Encode(broadcastRequest); err != nil {
 		return nil, nil, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -171,7 +274,7 @@ func (client *MessagingApiAPI) BroadcastWithHttpInfo(
 
 	req.Header.Set("X-Line-Retry-Key", xLineRetryKey)
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -184,7 +287,7 @@ func (client *MessagingApiAPI) BroadcastWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -209,6 +312,17 @@ func (client *MessagingApiAPI) CancelDefaultRichMenu() (struct{}, error) {
 	return body, error
 }
 
+// CancelDefaultRichMenuCtx
+// The same as CancelDefaultRichMenu, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) CancelDefaultRichMenuCtx(
+	ctx context.Context,
+) (struct{}, error) {
+	_, body, error := client.CancelDefaultRichMenuWithHttpInfoCtx(
+		ctx,
+	)
+	return body, error
+}
+
 // CancelDefaultRichMenu
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -217,14 +331,24 @@ func (client *MessagingApiAPI) CancelDefaultRichMenu() (struct{}, error) {
 
 // https://developers.line.biz/en/reference/messaging-api/#cancel-default-rich-menu
 func (client *MessagingApiAPI) CancelDefaultRichMenuWithHttpInfo() (*http.Response, struct{}, error) {
+	return client.CancelDefaultRichMenuWithHttpInfoCtx(
+		client.requestContext(),
+	)
+}
+
+// CancelDefaultRichMenuWithHttpInfoCtx
+// The same as CancelDefaultRichMenuWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) CancelDefaultRichMenuWithHttpInfoCtx(
+	ctx context.Context,
+) (*http.Response, struct{}, error) {
 	path := "/v2/bot/user/all/richmenu"
 
-	req, err := http.NewRequest(http.MethodDelete, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, client.Url(path), nil)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -237,7 +361,7 @@ func (client *MessagingApiAPI) CancelDefaultRichMenuWithHttpInfo() (*http.Respon
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -265,6 +389,20 @@ func (client *MessagingApiAPI) CloseCoupon(
 	return body, error
 }
 
+// CloseCouponCtx
+// The same as CloseCoupon, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) CloseCouponCtx(
+	ctx context.Context,
+	couponId string,
+
+) (struct{}, error) {
+	_, body, error := client.CloseCouponWithHttpInfoCtx(
+		ctx,
+		couponId,
+	)
+	return body, error
+}
+
 // CloseCoupon
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -277,17 +415,30 @@ func (client *MessagingApiAPI) CloseCouponWithHttpInfo(
 
 	couponId string,
 
+) (*http.Response, struct{}, error) {
+	return client.CloseCouponWithHttpInfoCtx(
+		client.requestContext(),
+		couponId,
+	)
+}
+
+// CloseCouponWithHttpInfoCtx
+// The same as CloseCouponWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) CloseCouponWithHttpInfoCtx(
+	ctx context.Context,
+	couponId string,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/coupon/{couponId}/close"
 
 	path = strings.Replace(path, "{couponId}", couponId, -1)
 
-	req, err := http.NewRequest(http.MethodPut, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, client.Url(path), nil)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -300,7 +451,7 @@ func (client *MessagingApiAPI) CloseCouponWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -328,6 +479,20 @@ func (client *MessagingApiAPI) CreateCoupon(
 	return body, error
 }
 
+// CreateCouponCtx
+// The same as CreateCoupon, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) CreateCouponCtx(
+	ctx context.Context,
+	couponCreateRequest *CouponCreateRequest,
+
+) (*CouponCreateResponse, error) {
+	_, body, error := client.CreateCouponWithHttpInfoCtx(
+		ctx,
+		couponCreateRequest,
+	)
+	return body, error
+}
+
 // CreateCoupon
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -340,6 +505,19 @@ func (client *MessagingApiAPI) CreateCouponWithHttpInfo(
 
 	couponCreateRequest *CouponCreateRequest,
 
+) (*http.Response, *CouponCreateResponse, error) {
+	return client.CreateCouponWithHttpInfoCtx(
+		client.requestContext(),
+		couponCreateRequest,
+	)
+}
+
+// CreateCouponWithHttpInfoCtx
+// The same as CreateCouponWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) CreateCouponWithHttpInfoCtx(
+	ctx context.Context,
+	couponCreateRequest *CouponCreateRequest,
+
 ) (*http.Response, *CouponCreateResponse, error) {
 	path := "/v2/bot/coupon"
 
@@ -348,13 +526,13 @@ func (client *MessagingApiAPI) CreateCouponWithHttpInfo(
 	if err := enc.Encode(couponCreateRequest); err != nil {
 		return nil, nil, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, nil, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -367,7 +545,7 @@ func (client *MessagingApiAPI) CreateCouponWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -400,6 +578,20 @@ func (client *MessagingApiAPI) CreateRichMenu(
 	return body, error
 }
 
+// CreateRichMenuCtx
+// The same as CreateRichMenu, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) CreateRichMenuCtx(
+	ctx context.Context,
+	richMenuRequest *RichMenuRequest,
+
+) (*RichMenuIdResponse, error) {
+	_, body, error := client.CreateRichMenuWithHttpInfoCtx(
+		ctx,
+		richMenuRequest,
+	)
+	return body, error
+}
+
 // CreateRichMenu
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -412,6 +604,19 @@ func (client *MessagingApiAPI) CreateRichMenuWithHttpInfo(
 
 	richMenuRequest *RichMenuRequest,
 
+) (*http.Response, *RichMenuIdResponse, error) {
+	return client.CreateRichMenuWithHttpInfoCtx(
+		client.requestContext(),
+		richMenuRequest,
+	)
+}
+
+// CreateRichMenuWithHttpInfoCtx
+// The same as CreateRichMenuWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) CreateRichMenuWithHttpInfoCtx(
+	ctx context.Context,
+	richMenuRequest *RichMenuRequest,
+
 ) (*http.Response, *RichMenuIdResponse, error) {
 	path := "/v2/bot/richmenu"
 
@@ -420,13 +625,13 @@ func (client *MessagingApiAPI) CreateRichMenuWithHttpInfo(
 	if err := enc.Encode(richMenuRequest); err != nil {
 		return nil, nil, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, nil, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -439,7 +644,7 @@ func (client *MessagingApiAPI) CreateRichMenuWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -472,6 +677,20 @@ func (client *MessagingApiAPI) CreateRichMenuAlias(
 	return body, error
 }
 
+// CreateRichMenuAliasCtx
+// The same as CreateRichMenuAlias, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) CreateRichMenuAliasCtx(
+	ctx context.Context,
+	createRichMenuAliasRequest *CreateRichMenuAliasRequest,
+
+) (struct{}, error) {
+	_, body, error := client.CreateRichMenuAliasWithHttpInfoCtx(
+		ctx,
+		createRichMenuAliasRequest,
+	)
+	return body, error
+}
+
 // CreateRichMenuAlias
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -484,6 +703,19 @@ func (client *MessagingApiAPI) CreateRichMenuAliasWithHttpInfo(
 
 	createRichMenuAliasRequest *CreateRichMenuAliasRequest,
 
+) (*http.Response, struct{}, error) {
+	return client.CreateRichMenuAliasWithHttpInfoCtx(
+		client.requestContext(),
+		createRichMenuAliasRequest,
+	)
+}
+
+// CreateRichMenuAliasWithHttpInfoCtx
+// The same as CreateRichMenuAliasWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) CreateRichMenuAliasWithHttpInfoCtx(
+	ctx context.Context,
+	createRichMenuAliasRequest *CreateRichMenuAliasRequest,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/richmenu/alias"
 
@@ -492,13 +724,13 @@ func (client *MessagingApiAPI) CreateRichMenuAliasWithHttpInfo(
 	if err := enc.Encode(createRichMenuAliasRequest); err != nil {
 		return nil, struct{}{}, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -511,7 +743,7 @@ func (client *MessagingApiAPI) CreateRichMenuAliasWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -539,6 +771,20 @@ func (client *MessagingApiAPI) DeleteRichMenu(
 	return body, error
 }
 
+// DeleteRichMenuCtx
+// The same as DeleteRichMenu, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) DeleteRichMenuCtx(
+	ctx context.Context,
+	richMenuId string,
+
+) (struct{}, error) {
+	_, body, error := client.DeleteRichMenuWithHttpInfoCtx(
+		ctx,
+		richMenuId,
+	)
+	return body, error
+}
+
 // DeleteRichMenu
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -551,17 +797,30 @@ func (client *MessagingApiAPI) DeleteRichMenuWithHttpInfo(
 
 	richMenuId string,
 
+) (*http.Response, struct{}, error) {
+	return client.DeleteRichMenuWithHttpInfoCtx(
+		client.requestContext(),
+		richMenuId,
+	)
+}
+
+// DeleteRichMenuWithHttpInfoCtx
+// The same as DeleteRichMenuWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) DeleteRichMenuWithHttpInfoCtx(
+	ctx context.Context,
+	richMenuId string,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/richmenu/{richMenuId}"
 
 	path = strings.Replace(path, "{richMenuId}", richMenuId, -1)
 
-	req, err := http.NewRequest(http.MethodDelete, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, client.Url(path), nil)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -574,7 +833,7 @@ func (client *MessagingApiAPI) DeleteRichMenuWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -602,6 +861,20 @@ func (client *MessagingApiAPI) DeleteRichMenuAlias(
 	return body, error
 }
 
+// DeleteRichMenuAliasCtx
+// The same as DeleteRichMenuAlias, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) DeleteRichMenuAliasCtx(
+	ctx context.Context,
+	richMenuAliasId string,
+
+) (struct{}, error) {
+	_, body, error := client.DeleteRichMenuAliasWithHttpInfoCtx(
+		ctx,
+		richMenuAliasId,
+	)
+	return body, error
+}
+
 // DeleteRichMenuAlias
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -614,17 +887,30 @@ func (client *MessagingApiAPI) DeleteRichMenuAliasWithHttpInfo(
 
 	richMenuAliasId string,
 
+) (*http.Response, struct{}, error) {
+	return client.DeleteRichMenuAliasWithHttpInfoCtx(
+		client.requestContext(),
+		richMenuAliasId,
+	)
+}
+
+// DeleteRichMenuAliasWithHttpInfoCtx
+// The same as DeleteRichMenuAliasWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) DeleteRichMenuAliasWithHttpInfoCtx(
+	ctx context.Context,
+	richMenuAliasId string,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/richmenu/alias/{richMenuAliasId}"
 
 	path = strings.Replace(path, "{richMenuAliasId}", richMenuAliasId, -1)
 
-	req, err := http.NewRequest(http.MethodDelete, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, client.Url(path), nil)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -637,7 +923,7 @@ func (client *MessagingApiAPI) DeleteRichMenuAliasWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -670,6 +956,24 @@ func (client *MessagingApiAPI) GetAggregationUnitNameList(
 	return body, error
 }
 
+// GetAggregationUnitNameListCtx
+// The same as GetAggregationUnitNameList, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetAggregationUnitNameListCtx(
+	ctx context.Context,
+	limit string,
+
+	start string,
+
+) (*GetAggregationUnitNameListResponse, error) {
+	_, body, error := client.GetAggregationUnitNameListWithHttpInfoCtx(
+		ctx,
+		limit,
+
+		start,
+	)
+	return body, error
+}
+
 // GetAggregationUnitNameList
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -685,10 +989,27 @@ func (client *MessagingApiAPI) GetAggregationUnitNameListWithHttpInfo(
 
 	start string,
 
+) (*http.Response, *GetAggregationUnitNameListResponse, error) {
+	return client.GetAggregationUnitNameListWithHttpInfoCtx(
+		client.requestContext(),
+		limit,
+
+		start,
+	)
+}
+
+// GetAggregationUnitNameListWithHttpInfoCtx
+// The same as GetAggregationUnitNameListWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetAggregationUnitNameListWithHttpInfoCtx(
+	ctx context.Context,
+	limit string,
+
+	start string,
+
 ) (*http.Response, *GetAggregationUnitNameListResponse, error) {
 	path := "/v2/bot/message/aggregation/list"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -703,7 +1024,7 @@ func (client *MessagingApiAPI) GetAggregationUnitNameListWithHttpInfo(
 
 	req.URL.RawQuery = query.Encode()
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -716,7 +1037,7 @@ func (client *MessagingApiAPI) GetAggregationUnitNameListWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -741,6 +1062,17 @@ func (client *MessagingApiAPI) GetAggregationUnitUsage() (*GetAggregationUnitUsa
 	return body, error
 }
 
+// GetAggregationUnitUsageCtx
+// The same as GetAggregationUnitUsage, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetAggregationUnitUsageCtx(
+	ctx context.Context,
+) (*GetAggregationUnitUsageResponse, error) {
+	_, body, error := client.GetAggregationUnitUsageWithHttpInfoCtx(
+		ctx,
+	)
+	return body, error
+}
+
 // GetAggregationUnitUsage
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -749,14 +1081,24 @@ func (client *MessagingApiAPI) GetAggregationUnitUsage() (*GetAggregationUnitUsa
 
 // https://developers.line.biz/en/reference/messaging-api/#get-number-of-units-used-this-month
 func (client *MessagingApiAPI) GetAggregationUnitUsageWithHttpInfo() (*http.Response, *GetAggregationUnitUsageResponse, error) {
+	return client.GetAggregationUnitUsageWithHttpInfoCtx(
+		client.requestContext(),
+	)
+}
+
+// GetAggregationUnitUsageWithHttpInfoCtx
+// The same as GetAggregationUnitUsageWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetAggregationUnitUsageWithHttpInfoCtx(
+	ctx context.Context,
+) (*http.Response, *GetAggregationUnitUsageResponse, error) {
 	path := "/v2/bot/message/aggregation/info"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -769,7 +1111,7 @@ func (client *MessagingApiAPI) GetAggregationUnitUsageWithHttpInfo() (*http.Resp
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -794,6 +1136,17 @@ func (client *MessagingApiAPI) GetBotInfo() (*BotInfoResponse, error) {
 	return body, error
 }
 
+// GetBotInfoCtx
+// The same as GetBotInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetBotInfoCtx(
+	ctx context.Context,
+) (*BotInfoResponse, error) {
+	_, body, error := client.GetBotInfoWithHttpInfoCtx(
+		ctx,
+	)
+	return body, error
+}
+
 // GetBotInfo
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -802,14 +1155,24 @@ func (client *MessagingApiAPI) GetBotInfo() (*BotInfoResponse, error) {
 
 // https://developers.line.biz/en/reference/messaging-api/#get-bot-info
 func (client *MessagingApiAPI) GetBotInfoWithHttpInfo() (*http.Response, *BotInfoResponse, error) {
+	return client.GetBotInfoWithHttpInfoCtx(
+		client.requestContext(),
+	)
+}
+
+// GetBotInfoWithHttpInfoCtx
+// The same as GetBotInfoWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetBotInfoWithHttpInfoCtx(
+	ctx context.Context,
+) (*http.Response, *BotInfoResponse, error) {
 	path := "/v2/bot/info"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -822,7 +1185,7 @@ func (client *MessagingApiAPI) GetBotInfoWithHttpInfo() (*http.Response, *BotInf
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -855,6 +1218,20 @@ func (client *MessagingApiAPI) GetCouponDetail(
 	return body, error
 }
 
+// GetCouponDetailCtx
+// The same as GetCouponDetail, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetCouponDetailCtx(
+	ctx context.Context,
+	couponId string,
+
+) (*CouponResponse, error) {
+	_, body, error := client.GetCouponDetailWithHttpInfoCtx(
+		ctx,
+		couponId,
+	)
+	return body, error
+}
+
 // GetCouponDetail
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -867,17 +1244,30 @@ func (client *MessagingApiAPI) GetCouponDetailWithHttpInfo(
 
 	couponId string,
 
+) (*http.Response, *CouponResponse, error) {
+	return client.GetCouponDetailWithHttpInfoCtx(
+		client.requestContext(),
+		couponId,
+	)
+}
+
+// GetCouponDetailWithHttpInfoCtx
+// The same as GetCouponDetailWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetCouponDetailWithHttpInfoCtx(
+	ctx context.Context,
+	couponId string,
+
 ) (*http.Response, *CouponResponse, error) {
 	path := "/v2/bot/coupon/{couponId}"
 
 	path = strings.Replace(path, "{couponId}", couponId, -1)
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -890,7 +1280,7 @@ func (client *MessagingApiAPI) GetCouponDetailWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -915,6 +1305,17 @@ func (client *MessagingApiAPI) GetDefaultRichMenuId() (*RichMenuIdResponse, erro
 	return body, error
 }
 
+// GetDefaultRichMenuIdCtx
+// The same as GetDefaultRichMenuId, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetDefaultRichMenuIdCtx(
+	ctx context.Context,
+) (*RichMenuIdResponse, error) {
+	_, body, error := client.GetDefaultRichMenuIdWithHttpInfoCtx(
+		ctx,
+	)
+	return body, error
+}
+
 // GetDefaultRichMenuId
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -923,14 +1324,24 @@ func (client *MessagingApiAPI) GetDefaultRichMenuId() (*RichMenuIdResponse, erro
 
 // https://developers.line.biz/en/reference/messaging-api/#get-default-rich-menu-id
 func (client *MessagingApiAPI) GetDefaultRichMenuIdWithHttpInfo() (*http.Response, *RichMenuIdResponse, error) {
+	return client.GetDefaultRichMenuIdWithHttpInfoCtx(
+		client.requestContext(),
+	)
+}
+
+// GetDefaultRichMenuIdWithHttpInfoCtx
+// The same as GetDefaultRichMenuIdWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetDefaultRichMenuIdWithHttpInfoCtx(
+	ctx context.Context,
+) (*http.Response, *RichMenuIdResponse, error) {
 	path := "/v2/bot/user/all/richmenu"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -943,7 +1354,7 @@ func (client *MessagingApiAPI) GetDefaultRichMenuIdWithHttpInfo() (*http.Respons
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -981,6 +1392,24 @@ func (client *MessagingApiAPI) GetFollowers(
 	return body, error
 }
 
+// GetFollowersCtx
+// The same as GetFollowers, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetFollowersCtx(
+	ctx context.Context,
+	start string,
+
+	limit int32,
+
+) (*GetFollowersResponse, error) {
+	_, body, error := client.GetFollowersWithHttpInfoCtx(
+		ctx,
+		start,
+
+		limit,
+	)
+	return body, error
+}
+
 // GetFollowers
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -996,10 +1425,27 @@ func (client *MessagingApiAPI) GetFollowersWithHttpInfo(
 
 	limit int32,
 
+) (*http.Response, *GetFollowersResponse, error) {
+	return client.GetFollowersWithHttpInfoCtx(
+		client.requestContext(),
+		start,
+
+		limit,
+	)
+}
+
+// GetFollowersWithHttpInfoCtx
+// The same as GetFollowersWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetFollowersWithHttpInfoCtx(
+	ctx context.Context,
+	start string,
+
+	limit int32,
+
 ) (*http.Response, *GetFollowersResponse, error) {
 	path := "/v2/bot/followers/ids"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1012,7 +1458,7 @@ func (client *MessagingApiAPI) GetFollowersWithHttpInfo(
 
 	req.URL.RawQuery = query.Encode()
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -1025,7 +1471,7 @@ func (client *MessagingApiAPI) GetFollowersWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -1058,6 +1504,20 @@ func (client *MessagingApiAPI) GetGroupMemberCount(
 	return body, error
 }
 
+// GetGroupMemberCountCtx
+// The same as GetGroupMemberCount, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetGroupMemberCountCtx(
+	ctx context.Context,
+	groupId string,
+
+) (*GroupMemberCountResponse, error) {
+	_, body, error := client.GetGroupMemberCountWithHttpInfoCtx(
+		ctx,
+		groupId,
+	)
+	return body, error
+}
+
 // GetGroupMemberCount
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -1071,16 +1531,29 @@ func (client *MessagingApiAPI) GetGroupMemberCountWithHttpInfo(
 	groupId string,
 
 ) (*http.Response, *GroupMemberCountResponse, error) {
-	path := "/v2/bot/group/{groupId}/members/count"
-
-	path = strings.Replace(path, "{groupId}", groupId, -1)
+	return client.GetGroupMemberCountWithHttpInfoCtx(
+		client.requestContext(),
+		groupId,
+	)
+}
+
+// GetGroupMemberCountWithHttpInfoCtx
+// The same as GetGroupMemberCountWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetGroupMemberCountWithHttpInfoCtx(
+	ctx context.Context,
+	groupId string,
+
+) (*http.Response, *GroupMemberCountResponse, error) {
+	path := "/v2/bot/group/{groupId}/members/count"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	path = strings.Replace(path, "{groupId}", groupId, -1)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -1093,7 +1566,7 @@ func (client *MessagingApiAPI) GetGroupMemberCountWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -1131,6 +1604,24 @@ func (client *MessagingApiAPI) GetGroupMemberProfile(
 	return body, error
 }
 
+// GetGroupMemberProfileCtx
+// The same as GetGroupMemberProfile, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetGroupMemberProfileCtx(
+	ctx context.Context,
+	groupId string,
+
+	userId string,
+
+) (*GroupUserProfileResponse, error) {
+	_, body, error := client.GetGroupMemberProfileWithHttpInfoCtx(
+		ctx,
+		groupId,
+
+		userId,
+	)
+	return body, error
+}
+
 // GetGroupMemberProfile
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -1146,6 +1637,23 @@ func (client *MessagingApiAPI) GetGroupMemberProfileWithHttpInfo(
 
 	userId string,
 
+) (*http.Response, *GroupUserProfileResponse, error) {
+	return client.GetGroupMemberProfileWithHttpInfoCtx(
+		client.requestContext(),
+		groupId,
+
+		userId,
+	)
+}
+
+// GetGroupMemberProfileWithHttpInfoCtx
+// The same as GetGroupMemberProfileWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetGroupMemberProfileWithHttpInfoCtx(
+	ctx context.Context,
+	groupId string,
+
+	userId string,
+
 ) (*http.Response, *GroupUserProfileResponse, error) {
 	path := "/v2/bot/group/{groupId}/member/{userId}"
 
@@ -1153,12 +1661,12 @@ func (client *MessagingApiAPI) GetGroupMemberProfileWithHttpInfo(
 
 	path = strings.Replace(path, "{userId}", userId, -1)
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -1171,7 +1679,7 @@ func (client *MessagingApiAPI) GetGroupMemberProfileWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -1209,6 +1717,24 @@ func (client *MessagingApiAPI) GetGroupMembersIds(
 	return body, error
 }
 
+// GetGroupMembersIdsCtx
+// The same as GetGroupMembersIds, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetGroupMembersIdsCtx(
+	ctx context.Context,
+	groupId string,
+
+	start string,
+
+) (*MembersIdsResponse, error) {
+	_, body, error := client.GetGroupMembersIdsWithHttpInfoCtx(
+		ctx,
+		groupId,
+
+		start,
+	)
+	return body, error
+}
+
 // GetGroupMembersIds
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -1224,12 +1750,29 @@ func (client *MessagingApiAPI) GetGroupMembersIdsWithHttpInfo(
 
 	start string,
 
+) (*http.Response, *MembersIdsResponse, error) {
+	return client.GetGroupMembersIdsWithHttpInfoCtx(
+		client.requestContext(),
+		groupId,
+
+		start,
+	)
+}
+
+// GetGroupMembersIdsWithHttpInfoCtx
+// The same as GetGroupMembersIdsWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetGroupMembersIdsWithHttpInfoCtx(
+	ctx context.Context,
+	groupId string,
+
+	start string,
+
 ) (*http.Response, *MembersIdsResponse, error) {
 	path := "/v2/bot/group/{groupId}/members/ids"
 
 	path = strings.Replace(path, "{groupId}", groupId, -1)
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1241,7 +1784,7 @@ func (client *MessagingApiAPI) GetGroupMembersIdsWithHttpInfo(
 
 	req.URL.RawQuery = query.Encode()
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -1254,7 +1797,7 @@ func (client *MessagingApiAPI) GetGroupMembersIdsWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -1287,6 +1830,20 @@ func (client *MessagingApiAPI) GetGroupSummary(
 	return body, error
 }
 
+// GetGroupSummaryCtx
+// The same as GetGroupSummary, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetGroupSummaryCtx(
+	ctx context.Context,
+	groupId string,
+
+) (*GroupSummaryResponse, error) {
+	_, body, error := client.GetGroupSummaryWithHttpInfoCtx(
+		ctx,
+		groupId,
+	)
+	return body, error
+}
+
 // GetGroupSummary
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -1299,17 +1856,30 @@ func (client *MessagingApiAPI) GetGroupSummaryWithHttpInfo(
 
 	groupId string,
 
+) (*http.Response, *GroupSummaryResponse, error) {
+	return client.GetGroupSummaryWithHttpInfoCtx(
+		client.requestContext(),
+		groupId,
+	)
+}
+
+// GetGroupSummaryWithHttpInfoCtx
+// The same as GetGroupSummaryWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetGroupSummaryWithHttpInfoCtx(
+	ctx context.Context,
+	groupId string,
+
 ) (*http.Response, *GroupSummaryResponse, error) {
 	path := "/v2/bot/group/{groupId}/summary"
 
 	path = strings.Replace(path, "{groupId}", groupId, -1)
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -1322,7 +1892,7 @@ func (client *MessagingApiAPI) GetGroupSummaryWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -1365,6 +1935,28 @@ func (client *MessagingApiAPI) GetJoinedMembershipUsers(
 	return body, error
 }
 
+// GetJoinedMembershipUsersCtx
+// The same as GetJoinedMembershipUsers, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetJoinedMembershipUsersCtx(
+	ctx context.Context,
+	membershipId int32,
+
+	start string,
+
+	limit int32,
+
+) (*GetJoinedMembershipUsersResponse, error) {
+	_, body, error := client.GetJoinedMembershipUsersWithHttpInfoCtx(
+		ctx,
+		membershipId,
+
+		start,
+
+		limit,
+	)
+	return body, error
+}
+
 // GetJoinedMembershipUsers
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -1383,12 +1975,33 @@ func (client *MessagingApiAPI) GetJoinedMembershipUsersWithHttpInfo(
 
 	limit int32,
 
+) (*http.Response, *GetJoinedMembershipUsersResponse, error) {
+	return client.GetJoinedMembershipUsersWithHttpInfoCtx(
+		client.requestContext(),
+		membershipId,
+
+		start,
+
+		limit,
+	)
+}
+
+// GetJoinedMembershipUsersWithHttpInfoCtx
+// The same as GetJoinedMembershipUsersWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetJoinedMembershipUsersWithHttpInfoCtx(
+	ctx context.Context,
+	membershipId int32,
+
+	start string,
+
+	limit int32,
+
 ) (*http.Response, *GetJoinedMembershipUsersResponse, error) {
 	path := "/v2/bot/membership/{membershipId}/users/ids"
 
 	path = strings.Replace(path, "{membershipId}", strconv.FormatInt(int64(membershipId), 10), -1)
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1401,7 +2014,7 @@ func (client *MessagingApiAPI) GetJoinedMembershipUsersWithHttpInfo(
 
 	req.URL.RawQuery = query.Encode()
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -1414,7 +2027,7 @@ func (client *MessagingApiAPI) GetJoinedMembershipUsersWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -1439,6 +2052,17 @@ func (client *MessagingApiAPI) GetMembershipList() (*MembershipListResponse, err
 	return body, error
 }
 
+// GetMembershipListCtx
+// The same as GetMembershipList, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetMembershipListCtx(
+	ctx context.Context,
+) (*MembershipListResponse, error) {
+	_, body, error := client.GetMembershipListWithHttpInfoCtx(
+		ctx,
+	)
+	return body, error
+}
+
 // GetMembershipList
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -1447,14 +2071,24 @@ func (client *MessagingApiAPI) GetMembershipList() (*MembershipListResponse, err
 
 // https://developers.line.biz/en/reference/messaging-api/#get-membership-plans
 func (client *MessagingApiAPI) GetMembershipListWithHttpInfo() (*http.Response, *MembershipListResponse, error) {
+	return client.GetMembershipListWithHttpInfoCtx(
+		client.requestContext(),
+	)
+}
+
+// GetMembershipListWithHttpInfoCtx
+// The same as GetMembershipListWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetMembershipListWithHttpInfoCtx(
+	ctx context.Context,
+) (*http.Response, *MembershipListResponse, error) {
 	path := "/v2/bot/membership/list"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -1467,7 +2101,7 @@ func (client *MessagingApiAPI) GetMembershipListWithHttpInfo() (*http.Response,
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -1500,6 +2134,20 @@ func (client *MessagingApiAPI) GetMembershipSubscription(
 	return body, error
 }
 
+// GetMembershipSubscriptionCtx
+// The same as GetMembershipSubscription, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetMembershipSubscriptionCtx(
+	ctx context.Context,
+	userId string,
+
+) (*GetMembershipSubscriptionResponse, error) {
+	_, body, error := client.GetMembershipSubscriptionWithHttpInfoCtx(
+		ctx,
+		userId,
+	)
+	return body, error
+}
+
 // GetMembershipSubscription
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -1512,17 +2160,30 @@ func (client *MessagingApiAPI) GetMembershipSubscriptionWithHttpInfo(
 
 	userId string,
 
+) (*http.Response, *GetMembershipSubscriptionResponse, error) {
+	return client.GetMembershipSubscriptionWithHttpInfoCtx(
+		client.requestContext(),
+		userId,
+	)
+}
+
+// GetMembershipSubscriptionWithHttpInfoCtx
+// The same as GetMembershipSubscriptionWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetMembershipSubscriptionWithHttpInfoCtx(
+	ctx context.Context,
+	userId string,
+
 ) (*http.Response, *GetMembershipSubscriptionResponse, error) {
 	path := "/v2/bot/membership/subscription/{userId}"
 
 	path = strings.Replace(path, "{userId}", userId, -1)
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -1535,7 +2196,7 @@ func (client *MessagingApiAPI) GetMembershipSubscriptionWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -1560,6 +2221,17 @@ func (client *MessagingApiAPI) GetMessageQuota() (*MessageQuotaResponse, error)
 	return body, error
 }
 
+// GetMessageQuotaCtx
+// The same as GetMessageQuota, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetMessageQuotaCtx(
+	ctx context.Context,
+) (*MessageQuotaResponse, error) {
+	_, body, error := client.GetMessageQuotaWithHttpInfoCtx(
+		ctx,
+	)
+	return body, error
+}
+
 // GetMessageQuota
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -1568,14 +2240,24 @@ func (client *MessagingApiAPI) GetMessageQuota() (*MessageQuotaResponse, error)
 
 // https://developers.line.biz/en/reference/messaging-api/#get-quota
 func (client *MessagingApiAPI) GetMessageQuotaWithHttpInfo() (*http.Response, *MessageQuotaResponse, error) {
+	return client.GetMessageQuotaWithHttpInfoCtx(
+		client.requestContext(),
+	)
+}
+
+// GetMessageQuotaWithHttpInfoCtx
+// The same as GetMessageQuotaWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetMessageQuotaWithHttpInfoCtx(
+	ctx context.Context,
+) (*http.Response, *MessageQuotaResponse, error) {
 	path := "/v2/bot/message/quota"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -1588,7 +2270,7 @@ func (client *MessagingApiAPI) GetMessageQuotaWithHttpInfo() (*http.Response, *M
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -1613,6 +2295,17 @@ func (client *MessagingApiAPI) GetMessageQuotaConsumption() (*QuotaConsumptionRe
 	return body, error
 }
 
+// GetMessageQuotaConsumptionCtx
+// The same as GetMessageQuotaConsumption, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetMessageQuotaConsumptionCtx(
+	ctx context.Context,
+) (*QuotaConsumptionResponse, error) {
+	_, body, error := client.GetMessageQuotaConsumptionWithHttpInfoCtx(
+		ctx,
+	)
+	return body, error
+}
+
 // GetMessageQuotaConsumption
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -1621,14 +2314,24 @@ func (client *MessagingApiAPI) GetMessageQuotaConsumption() (*QuotaConsumptionRe
 
 // https://developers.line.biz/en/reference/messaging-api/#get-consumption
 func (client *MessagingApiAPI) GetMessageQuotaConsumptionWithHttpInfo() (*http.Response, *QuotaConsumptionResponse, error) {
+	return client.GetMessageQuotaConsumptionWithHttpInfoCtx(
+		client.requestContext(),
+	)
+}
+
+// GetMessageQuotaConsumptionWithHttpInfoCtx
+// The same as GetMessageQuotaConsumptionWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetMessageQuotaConsumptionWithHttpInfoCtx(
+	ctx context.Context,
+) (*http.Response, *QuotaConsumptionResponse, error) {
 	path := "/v2/bot/message/quota/consumption"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -1641,7 +2344,7 @@ func (client *MessagingApiAPI) GetMessageQuotaConsumptionWithHttpInfo() (*http.R
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -1674,6 +2377,20 @@ func (client *MessagingApiAPI) GetNarrowcastProgress(
 	return body, error
 }
 
+// GetNarrowcastProgressCtx
+// The same as GetNarrowcastProgress, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetNarrowcastProgressCtx(
+	ctx context.Context,
+	requestId string,
+
+) (*NarrowcastProgressResponse, error) {
+	_, body, error := client.GetNarrowcastProgressWithHttpInfoCtx(
+		ctx,
+		requestId,
+	)
+	return body, error
+}
+
 // GetNarrowcastProgress
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -1686,10 +2403,23 @@ func (client *MessagingApiAPI) GetNarrowcastProgressWithHttpInfo(
 
 	requestId string,
 
+) (*http.Response, *NarrowcastProgressResponse, error) {
+	return client.GetNarrowcastProgressWithHttpInfoCtx(
+		client.requestContext(),
+		requestId,
+	)
+}
+
+// GetNarrowcastProgressWithHttpInfoCtx
+// The same as GetNarrowcastProgressWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetNarrowcastProgressWithHttpInfoCtx(
+	ctx context.Context,
+	requestId string,
+
 ) (*http.Response, *NarrowcastProgressResponse, error) {
 	path := "/v2/bot/message/progress/narrowcast"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1699,7 +2429,7 @@ func (client *MessagingApiAPI) GetNarrowcastProgressWithHttpInfo(
 
 	req.URL.RawQuery = query.Encode()
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -1712,7 +2442,7 @@ func (client *MessagingApiAPI) GetNarrowcastProgressWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -1745,6 +2475,20 @@ func (client *MessagingApiAPI) GetNumberOfSentBroadcastMessages(
 	return body, error
 }
 
+// GetNumberOfSentBroadcastMessagesCtx
+// The same as GetNumberOfSentBroadcastMessages, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetNumberOfSentBroadcastMessagesCtx(
+	ctx context.Context,
+	date string,
+
+) (*NumberOfMessagesResponse, error) {
+	_, body, error := client.GetNumberOfSentBroadcastMessagesWithHttpInfoCtx(
+		ctx,
+		date,
+	)
+	return body, error
+}
+
 // GetNumberOfSentBroadcastMessages
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -1757,10 +2501,23 @@ func (client *MessagingApiAPI) GetNumberOfSentBroadcastMessagesWithHttpInfo(
 
 	date string,
 
+) (*http.Response, *NumberOfMessagesResponse, error) {
+	return client.GetNumberOfSentBroadcastMessagesWithHttpInfoCtx(
+		client.requestContext(),
+		date,
+	)
+}
+
+// GetNumberOfSentBroadcastMessagesWithHttpInfoCtx
+// The same as GetNumberOfSentBroadcastMessagesWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetNumberOfSentBroadcastMessagesWithHttpInfoCtx(
+	ctx context.Context,
+	date string,
+
 ) (*http.Response, *NumberOfMessagesResponse, error) {
 	path := "/v2/bot/message/delivery/broadcast"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1770,7 +2527,7 @@ func (client *MessagingApiAPI) GetNumberOfSentBroadcastMessagesWithHttpInfo(
 
 	req.URL.RawQuery = query.Encode()
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -1783,7 +2540,7 @@ func (client *MessagingApiAPI) GetNumberOfSentBroadcastMessagesWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -1816,6 +2573,20 @@ func (client *MessagingApiAPI) GetNumberOfSentMulticastMessages(
 	return body, error
 }
 
+// GetNumberOfSentMulticastMessagesCtx
+// The same as GetNumberOfSentMulticastMessages, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetNumberOfSentMulticastMessagesCtx(
+	ctx context.Context,
+	date string,
+
+) (*NumberOfMessagesResponse, error) {
+	_, body, error := client.GetNumberOfSentMulticastMessagesWithHttpInfoCtx(
+		ctx,
+		date,
+	)
+	return body, error
+}
+
 // GetNumberOfSentMulticastMessages
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -1828,10 +2599,23 @@ func (client *MessagingApiAPI) GetNumberOfSentMulticastMessagesWithHttpInfo(
 
 	date string,
 
+) (*http.Response, *NumberOfMessagesResponse, error) {
+	return client.GetNumberOfSentMulticastMessagesWithHttpInfoCtx(
+		client.requestContext(),
+		date,
+	)
+}
+
+// GetNumberOfSentMulticastMessagesWithHttpInfoCtx
+// The same as GetNumberOfSentMulticastMessagesWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetNumberOfSentMulticastMessagesWithHttpInfoCtx(
+	ctx context.Context,
+	date string,
+
 ) (*http.Response, *NumberOfMessagesResponse, error) {
 	path := "/v2/bot/message/delivery/multicast"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1841,7 +2625,7 @@ func (client *MessagingApiAPI) GetNumberOfSentMulticastMessagesWithHttpInfo(
 
 	req.URL.RawQuery = query.Encode()
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -1854,7 +2638,7 @@ func (client *MessagingApiAPI) GetNumberOfSentMulticastMessagesWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -1887,6 +2671,20 @@ func (client *MessagingApiAPI) GetNumberOfSentPushMessages(
 	return body, error
 }
 
+// GetNumberOfSentPushMessagesCtx
+// The same as GetNumberOfSentPushMessages, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetNumberOfSentPushMessagesCtx(
+	ctx context.Context,
+	date string,
+
+) (*NumberOfMessagesResponse, error) {
+	_, body, error := client.GetNumberOfSentPushMessagesWithHttpInfoCtx(
+		ctx,
+		date,
+	)
+	return body, error
+}
+
 // GetNumberOfSentPushMessages
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -1899,10 +2697,23 @@ func (client *MessagingApiAPI) GetNumberOfSentPushMessagesWithHttpInfo(
 
 	date string,
 
+) (*http.Response, *NumberOfMessagesResponse, error) {
+	return client.GetNumberOfSentPushMessagesWithHttpInfoCtx(
+		client.requestContext(),
+		date,
+	)
+}
+
+// GetNumberOfSentPushMessagesWithHttpInfoCtx
+// The same as GetNumberOfSentPushMessagesWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetNumberOfSentPushMessagesWithHttpInfoCtx(
+	ctx context.Context,
+	date string,
+
 ) (*http.Response, *NumberOfMessagesResponse, error) {
 	path := "/v2/bot/message/delivery/push"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1912,7 +2723,7 @@ func (client *MessagingApiAPI) GetNumberOfSentPushMessagesWithHttpInfo(
 
 	req.URL.RawQuery = query.Encode()
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -1925,7 +2736,7 @@ func (client *MessagingApiAPI) GetNumberOfSentPushMessagesWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -1958,6 +2769,20 @@ func (client *MessagingApiAPI) GetNumberOfSentReplyMessages(
 	return body, error
 }
 
+// GetNumberOfSentReplyMessagesCtx
+// The same as GetNumberOfSentReplyMessages, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetNumberOfSentReplyMessagesCtx(
+	ctx context.Context,
+	date string,
+
+) (*NumberOfMessagesResponse, error) {
+	_, body, error := client.GetNumberOfSentReplyMessagesWithHttpInfoCtx(
+		ctx,
+		date,
+	)
+	return body, error
+}
+
 // GetNumberOfSentReplyMessages
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -1970,10 +2795,23 @@ func (client *MessagingApiAPI) GetNumberOfSentReplyMessagesWithHttpInfo(
 
 	date string,
 
+) (*http.Response, *NumberOfMessagesResponse, error) {
+	return client.GetNumberOfSentReplyMessagesWithHttpInfoCtx(
+		client.requestContext(),
+		date,
+	)
+}
+
+// GetNumberOfSentReplyMessagesWithHttpInfoCtx
+// The same as GetNumberOfSentReplyMessagesWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetNumberOfSentReplyMessagesWithHttpInfoCtx(
+	ctx context.Context,
+	date string,
+
 ) (*http.Response, *NumberOfMessagesResponse, error) {
 	path := "/v2/bot/message/delivery/reply"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1983,7 +2821,7 @@ func (client *MessagingApiAPI) GetNumberOfSentReplyMessagesWithHttpInfo(
 
 	req.URL.RawQuery = query.Encode()
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -1996,7 +2834,7 @@ func (client *MessagingApiAPI) GetNumberOfSentReplyMessagesWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -2029,6 +2867,20 @@ func (client *MessagingApiAPI) GetPNPMessageStatistics(
 	return body, error
 }
 
+// GetPNPMessageStatisticsCtx
+// The same as GetPNPMessageStatistics, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetPNPMessageStatisticsCtx(
+	ctx context.Context,
+	date string,
+
+) (*NumberOfMessagesResponse, error) {
+	_, body, error := client.GetPNPMessageStatisticsWithHttpInfoCtx(
+		ctx,
+		date,
+	)
+	return body, error
+}
+
 // GetPNPMessageStatistics
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -2041,10 +2893,23 @@ func (client *MessagingApiAPI) GetPNPMessageStatisticsWithHttpInfo(
 
 	date string,
 
+) (*http.Response, *NumberOfMessagesResponse, error) {
+	return client.GetPNPMessageStatisticsWithHttpInfoCtx(
+		client.requestContext(),
+		date,
+	)
+}
+
+// GetPNPMessageStatisticsWithHttpInfoCtx
+// The same as GetPNPMessageStatisticsWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetPNPMessageStatisticsWithHttpInfoCtx(
+	ctx context.Context,
+	date string,
+
 ) (*http.Response, *NumberOfMessagesResponse, error) {
 	path := "/v2/bot/message/delivery/pnp"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2054,7 +2919,7 @@ func (client *MessagingApiAPI) GetPNPMessageStatisticsWithHttpInfo(
 
 	req.URL.RawQuery = query.Encode()
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -2067,7 +2932,7 @@ func (client *MessagingApiAPI) GetPNPMessageStatisticsWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -2100,6 +2965,20 @@ func (client *MessagingApiAPI) GetProfile(
 	return body, error
 }
 
+// GetProfileCtx
+// The same as GetProfile, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetProfileCtx(
+	ctx context.Context,
+	userId string,
+
+) (*UserProfileResponse, error) {
+	_, body, error := client.GetProfileWithHttpInfoCtx(
+		ctx,
+		userId,
+	)
+	return body, error
+}
+
 // GetProfile
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -2112,17 +2991,30 @@ func (client *MessagingApiAPI) GetProfileWithHttpInfo(
 
 	userId string,
 
+) (*http.Response, *UserProfileResponse, error) {
+	return client.GetProfileWithHttpInfoCtx(
+		client.requestContext(),
+		userId,
+	)
+}
+
+// GetProfileWithHttpInfoCtx
+// The same as GetProfileWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetProfileWithHttpInfoCtx(
+	ctx context.Context,
+	userId string,
+
 ) (*http.Response, *UserProfileResponse, error) {
 	path := "/v2/bot/profile/{userId}"
 
 	path = strings.Replace(path, "{userId}", userId, -1)
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -2135,7 +3027,7 @@ func (client *MessagingApiAPI) GetProfileWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -2168,6 +3060,20 @@ func (client *MessagingApiAPI) GetRichMenu(
 	return body, error
 }
 
+// GetRichMenuCtx
+// The same as GetRichMenu, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetRichMenuCtx(
+	ctx context.Context,
+	richMenuId string,
+
+) (*RichMenuResponse, error) {
+	_, body, error := client.GetRichMenuWithHttpInfoCtx(
+		ctx,
+		richMenuId,
+	)
+	return body, error
+}
+
 // GetRichMenu
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -2181,16 +3087,29 @@ func (client *MessagingApiAPI) GetRichMenuWithHttpInfo(
 	richMenuId string,
 
 ) (*http.Response, *RichMenuResponse, error) {
-	path := "/v2/bot/richmenu/{richMenuId}"
+	return client.GetRichMenuWithHttpInfoCtx(
+		client.requestContext(),
+		richMenuId,
+	)
+}
 
-	path = strings.Replace(path, "{richMenuId}", richMenuId, -1)
+// GetRichMenuWithHttpInfoCtx
+// The same as GetRichMenuWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetRichMenuWithHttpInfoCtx(
+	ctx context.Context,
+	richMenuId string,
+
+) (*http.Response, *RichMenuResponse, error) {
+	path := "/v2/bot/richmenu/{richMenuId}"
+
+	path = strings.Replace(path, "{richMenuId}", richMenuId, -1)
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -2203,7 +3122,7 @@ func (client *MessagingApiAPI) GetRichMenuWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -2236,6 +3155,20 @@ func (client *MessagingApiAPI) GetRichMenuAlias(
 	return body, error
 }
 
+// GetRichMenuAliasCtx
+// The same as GetRichMenuAlias, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetRichMenuAliasCtx(
+	ctx context.Context,
+	richMenuAliasId string,
+
+) (*RichMenuAliasResponse, error) {
+	_, body, error := client.GetRichMenuAliasWithHttpInfoCtx(
+		ctx,
+		richMenuAliasId,
+	)
+	return body, error
+}
+
 // GetRichMenuAlias
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -2248,17 +3181,30 @@ func (client *MessagingApiAPI) GetRichMenuAliasWithHttpInfo(
 
 	richMenuAliasId string,
 
+) (*http.Response, *RichMenuAliasResponse, error) {
+	return client.GetRichMenuAliasWithHttpInfoCtx(
+		client.requestContext(),
+		richMenuAliasId,
+	)
+}
+
+// GetRichMenuAliasWithHttpInfoCtx
+// The same as GetRichMenuAliasWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetRichMenuAliasWithHttpInfoCtx(
+	ctx context.Context,
+	richMenuAliasId string,
+
 ) (*http.Response, *RichMenuAliasResponse, error) {
 	path := "/v2/bot/richmenu/alias/{richMenuAliasId}"
 
 	path = strings.Replace(path, "{richMenuAliasId}", richMenuAliasId, -1)
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -2271,7 +3217,7 @@ func (client *MessagingApiAPI) GetRichMenuAliasWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -2296,6 +3242,17 @@ func (client *MessagingApiAPI) GetRichMenuAliasList() (*RichMenuAliasListRespons
 	return body, error
 }
 
+// GetRichMenuAliasListCtx
+// The same as GetRichMenuAliasList, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetRichMenuAliasListCtx(
+	ctx context.Context,
+) (*RichMenuAliasListResponse, error) {
+	_, body, error := client.GetRichMenuAliasListWithHttpInfoCtx(
+		ctx,
+	)
+	return body, error
+}
+
 // GetRichMenuAliasList
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -2304,14 +3261,24 @@ func (client *MessagingApiAPI) GetRichMenuAliasList() (*RichMenuAliasListRespons
 
 // https://developers.line.biz/en/reference/messaging-api/#get-rich-menu-alias-list
 func (client *MessagingApiAPI) GetRichMenuAliasListWithHttpInfo() (*http.Response, *RichMenuAliasListResponse, error) {
+	return client.GetRichMenuAliasListWithHttpInfoCtx(
+		client.requestContext(),
+	)
+}
+
+// GetRichMenuAliasListWithHttpInfoCtx
+// The same as GetRichMenuAliasListWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetRichMenuAliasListWithHttpInfoCtx(
+	ctx context.Context,
+) (*http.Response, *RichMenuAliasListResponse, error) {
 	path := "/v2/bot/richmenu/alias/list"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -2324,7 +3291,7 @@ func (client *MessagingApiAPI) GetRichMenuAliasListWithHttpInfo() (*http.Respons
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -2357,6 +3324,20 @@ func (client *MessagingApiAPI) GetRichMenuBatchProgress(
 	return body, error
 }
 
+// GetRichMenuBatchProgressCtx
+// The same as GetRichMenuBatchProgress, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetRichMenuBatchProgressCtx(
+	ctx context.Context,
+	requestId string,
+
+) (*RichMenuBatchProgressResponse, error) {
+	_, body, error := client.GetRichMenuBatchProgressWithHttpInfoCtx(
+		ctx,
+		requestId,
+	)
+	return body, error
+}
+
 // GetRichMenuBatchProgress
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -2369,10 +3350,23 @@ func (client *MessagingApiAPI) GetRichMenuBatchProgressWithHttpInfo(
 
 	requestId string,
 
+) (*http.Response, *RichMenuBatchProgressResponse, error) {
+	return client.GetRichMenuBatchProgressWithHttpInfoCtx(
+		client.requestContext(),
+		requestId,
+	)
+}
+
+// GetRichMenuBatchProgressWithHttpInfoCtx
+// The same as GetRichMenuBatchProgressWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetRichMenuBatchProgressWithHttpInfoCtx(
+	ctx context.Context,
+	requestId string,
+
 ) (*http.Response, *RichMenuBatchProgressResponse, error) {
 	path := "/v2/bot/richmenu/progress/batch"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2382,7 +3376,7 @@ func (client *MessagingApiAPI) GetRichMenuBatchProgressWithHttpInfo(
 
 	req.URL.RawQuery = query.Encode()
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -2395,7 +3389,7 @@ func (client *MessagingApiAPI) GetRichMenuBatchProgressWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -2428,6 +3422,20 @@ func (client *MessagingApiAPI) GetRichMenuIdOfUser(
 	return body, error
 }
 
+// GetRichMenuIdOfUserCtx
+// The same as GetRichMenuIdOfUser, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetRichMenuIdOfUserCtx(
+	ctx context.Context,
+	userId string,
+
+) (*RichMenuIdResponse, error) {
+	_, body, error := client.GetRichMenuIdOfUserWithHttpInfoCtx(
+		ctx,
+		userId,
+	)
+	return body, error
+}
+
 // GetRichMenuIdOfUser
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -2440,17 +3448,30 @@ func (client *MessagingApiAPI) GetRichMenuIdOfUserWithHttpInfo(
 
 	userId string,
 
+) (*http.Response, *RichMenuIdResponse, error) {
+	return client.GetRichMenuIdOfUserWithHttpInfoCtx(
+		client.requestContext(),
+		userId,
+	)
+}
+
+// GetRichMenuIdOfUserWithHttpInfoCtx
+// The same as GetRichMenuIdOfUserWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetRichMenuIdOfUserWithHttpInfoCtx(
+	ctx context.Context,
+	userId string,
+
 ) (*http.Response, *RichMenuIdResponse, error) {
 	path := "/v2/bot/user/{userId}/richmenu"
 
 	path = strings.Replace(path, "{userId}", userId, -1)
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -2463,7 +3484,7 @@ func (client *MessagingApiAPI) GetRichMenuIdOfUserWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -2488,6 +3509,17 @@ func (client *MessagingApiAPI) GetRichMenuList() (*RichMenuListResponse, error)
 	return body, error
 }
 
+// GetRichMenuListCtx
+// The same as GetRichMenuList, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetRichMenuListCtx(
+	ctx context.Context,
+) (*RichMenuListResponse, error) {
+	_, body, error := client.GetRichMenuListWithHttpInfoCtx(
+		ctx,
+	)
+	return body, error
+}
+
 // GetRichMenuList
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -2496,14 +3528,24 @@ func (client *MessagingApiAPI) GetRichMenuList() (*RichMenuListResponse, error)
 
 // https://developers.line.biz/en/reference/messaging-api/#get-rich-menu-list
 func (client *MessagingApiAPI) GetRichMenuListWithHttpInfo() (*http.Response, *RichMenuListResponse, error) {
+	return client.GetRichMenuListWithHttpInfoCtx(
+		client.requestContext(),
+	)
+}
+
+// GetRichMenuListWithHttpInfoCtx
+// The same as GetRichMenuListWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetRichMenuListWithHttpInfoCtx(
+	ctx context.Context,
+) (*http.Response, *RichMenuListResponse, error) {
 	path := "/v2/bot/richmenu/list"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -2516,7 +3558,7 @@ func (client *MessagingApiAPI) GetRichMenuListWithHttpInfo() (*http.Response, *R
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -2549,6 +3591,20 @@ func (client *MessagingApiAPI) GetRoomMemberCount(
 	return body, error
 }
 
+// GetRoomMemberCountCtx
+// The same as GetRoomMemberCount, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetRoomMemberCountCtx(
+	ctx context.Context,
+	roomId string,
+
+) (*RoomMemberCountResponse, error) {
+	_, body, error := client.GetRoomMemberCountWithHttpInfoCtx(
+		ctx,
+		roomId,
+	)
+	return body, error
+}
+
 // GetRoomMemberCount
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -2561,17 +3617,30 @@ func (client *MessagingApiAPI) GetRoomMemberCountWithHttpInfo(
 
 	roomId string,
 
+) (*http.Response, *RoomMemberCountResponse, error) {
+	return client.GetRoomMemberCountWithHttpInfoCtx(
+		client.requestContext(),
+		roomId,
+	)
+}
+
+// GetRoomMemberCountWithHttpInfoCtx
+// The same as GetRoomMemberCountWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetRoomMemberCountWithHttpInfoCtx(
+	ctx context.Context,
+	roomId string,
+
 ) (*http.Response, *RoomMemberCountResponse, error) {
 	path := "/v2/bot/room/{roomId}/members/count"
 
 	path = strings.Replace(path, "{roomId}", roomId, -1)
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -2584,7 +3653,7 @@ func (client *MessagingApiAPI) GetRoomMemberCountWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -2622,6 +3691,24 @@ func (client *MessagingApiAPI) GetRoomMemberProfile(
 	return body, error
 }
 
+// GetRoomMemberProfileCtx
+// The same as GetRoomMemberProfile, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetRoomMemberProfileCtx(
+	ctx context.Context,
+	roomId string,
+
+	userId string,
+
+) (*RoomUserProfileResponse, error) {
+	_, body, error := client.GetRoomMemberProfileWithHttpInfoCtx(
+		ctx,
+		roomId,
+
+		userId,
+	)
+	return body, error
+}
+
 // GetRoomMemberProfile
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -2637,6 +3724,23 @@ func (client *MessagingApiAPI) GetRoomMemberProfileWithHttpInfo(
 
 	userId string,
 
+) (*http.Response, *RoomUserProfileResponse, error) {
+	return client.GetRoomMemberProfileWithHttpInfoCtx(
+		client.requestContext(),
+		roomId,
+
+		userId,
+	)
+}
+
+// GetRoomMemberProfileWithHttpInfoCtx
+// The same as GetRoomMemberProfileWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetRoomMemberProfileWithHttpInfoCtx(
+	ctx context.Context,
+	roomId string,
+
+	userId string,
+
 ) (*http.Response, *RoomUserProfileResponse, error) {
 	path := "/v2/bot/room/{roomId}/member/{userId}"
 
@@ -2644,12 +3748,12 @@ func (client *MessagingApiAPI) GetRoomMemberProfileWithHttpInfo(
 
 	path = strings.Replace(path, "{userId}", userId, -1)
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -2662,7 +3766,7 @@ func (client *MessagingApiAPI) GetRoomMemberProfileWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -2700,6 +3804,24 @@ func (client *MessagingApiAPI) GetRoomMembersIds(
 	return body, error
 }
 
+// GetRoomMembersIdsCtx
+// The same as GetRoomMembersIds, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetRoomMembersIdsCtx(
+	ctx context.Context,
+	roomId string,
+
+	start string,
+
+) (*MembersIdsResponse, error) {
+	_, body, error := client.GetRoomMembersIdsWithHttpInfoCtx(
+		ctx,
+		roomId,
+
+		start,
+	)
+	return body, error
+}
+
 // GetRoomMembersIds
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -2715,12 +3837,29 @@ func (client *MessagingApiAPI) GetRoomMembersIdsWithHttpInfo(
 
 	start string,
 
+) (*http.Response, *MembersIdsResponse, error) {
+	return client.GetRoomMembersIdsWithHttpInfoCtx(
+		client.requestContext(),
+		roomId,
+
+		start,
+	)
+}
+
+// GetRoomMembersIdsWithHttpInfoCtx
+// The same as GetRoomMembersIdsWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetRoomMembersIdsWithHttpInfoCtx(
+	ctx context.Context,
+	roomId string,
+
+	start string,
+
 ) (*http.Response, *MembersIdsResponse, error) {
 	path := "/v2/bot/room/{roomId}/members/ids"
 
 	path = strings.Replace(path, "{roomId}", roomId, -1)
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2732,7 +3871,7 @@ func (client *MessagingApiAPI) GetRoomMembersIdsWithHttpInfo(
 
 	req.URL.RawQuery = query.Encode()
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -2745,7 +3884,7 @@ func (client *MessagingApiAPI) GetRoomMembersIdsWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -2770,6 +3909,17 @@ func (client *MessagingApiAPI) GetWebhookEndpoint() (*GetWebhookEndpointResponse
 	return body, error
 }
 
+// GetWebhookEndpointCtx
+// The same as GetWebhookEndpoint, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetWebhookEndpointCtx(
+	ctx context.Context,
+) (*GetWebhookEndpointResponse, error) {
+	_, body, error := client.GetWebhookEndpointWithHttpInfoCtx(
+		ctx,
+	)
+	return body, error
+}
+
 // GetWebhookEndpoint
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -2778,14 +3928,24 @@ func (client *MessagingApiAPI) GetWebhookEndpoint() (*GetWebhookEndpointResponse
 
 // https://developers.line.biz/en/reference/messaging-api/#get-webhook-endpoint-information
 func (client *MessagingApiAPI) GetWebhookEndpointWithHttpInfo() (*http.Response, *GetWebhookEndpointResponse, error) {
+	return client.GetWebhookEndpointWithHttpInfoCtx(
+		client.requestContext(),
+	)
+}
+
+// GetWebhookEndpointWithHttpInfoCtx
+// The same as GetWebhookEndpointWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) GetWebhookEndpointWithHttpInfoCtx(
+	ctx context.Context,
+) (*http.Response, *GetWebhookEndpointResponse, error) {
 	path := "/v2/bot/channel/webhook/endpoint"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -2798,7 +3958,7 @@ func (client *MessagingApiAPI) GetWebhookEndpointWithHttpInfo() (*http.Response,
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -2831,6 +3991,20 @@ func (client *MessagingApiAPI) IssueLinkToken(
 	return body, error
 }
 
+// IssueLinkTokenCtx
+// The same as IssueLinkToken, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) IssueLinkTokenCtx(
+	ctx context.Context,
+	userId string,
+
+) (*IssueLinkTokenResponse, error) {
+	_, body, error := client.IssueLinkTokenWithHttpInfoCtx(
+		ctx,
+		userId,
+	)
+	return body, error
+}
+
 // IssueLinkToken
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -2843,17 +4017,30 @@ func (client *MessagingApiAPI) IssueLinkTokenWithHttpInfo(
 
 	userId string,
 
+) (*http.Response, *IssueLinkTokenResponse, error) {
+	return client.IssueLinkTokenWithHttpInfoCtx(
+		client.requestContext(),
+		userId,
+	)
+}
+
+// IssueLinkTokenWithHttpInfoCtx
+// The same as IssueLinkTokenWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) IssueLinkTokenWithHttpInfoCtx(
+	ctx context.Context,
+	userId string,
+
 ) (*http.Response, *IssueLinkTokenResponse, error) {
 	path := "/v2/bot/user/{userId}/linkToken"
 
 	path = strings.Replace(path, "{userId}", userId, -1)
 
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -2866,7 +4053,7 @@ func (client *MessagingApiAPI) IssueLinkTokenWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -2899,6 +4086,20 @@ func (client *MessagingApiAPI) LeaveGroup(
 	return body, error
 }
 
+// LeaveGroupCtx
+// The same as LeaveGroup, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) LeaveGroupCtx(
+	ctx context.Context,
+	groupId string,
+
+) (struct{}, error) {
+	_, body, error := client.LeaveGroupWithHttpInfoCtx(
+		ctx,
+		groupId,
+	)
+	return body, error
+}
+
 // LeaveGroup
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -2911,17 +4112,30 @@ func (client *MessagingApiAPI) LeaveGroupWithHttpInfo(
 
 	groupId string,
 
+) (*http.Response, struct{}, error) {
+	return client.LeaveGroupWithHttpInfoCtx(
+		client.requestContext(),
+		groupId,
+	)
+}
+
+// LeaveGroupWithHttpInfoCtx
+// The same as LeaveGroupWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) LeaveGroupWithHttpInfoCtx(
+	ctx context.Context,
+	groupId string,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/group/{groupId}/leave"
 
 	path = strings.Replace(path, "{groupId}", groupId, -1)
 
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), nil)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -2934,7 +4148,7 @@ func (client *MessagingApiAPI) LeaveGroupWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -2962,6 +4176,20 @@ func (client *MessagingApiAPI) LeaveRoom(
 	return body, error
 }
 
+// LeaveRoomCtx
+// The same as LeaveRoom, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) LeaveRoomCtx(
+	ctx context.Context,
+	roomId string,
+
+) (struct{}, error) {
+	_, body, error := client.LeaveRoomWithHttpInfoCtx(
+		ctx,
+		roomId,
+	)
+	return body, error
+}
+
 // LeaveRoom
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -2974,17 +4202,30 @@ func (client *MessagingApiAPI) LeaveRoomWithHttpInfo(
 
 	roomId string,
 
+) (*http.Response, struct{}, error) {
+	return client.LeaveRoomWithHttpInfoCtx(
+		client.requestContext(),
+		roomId,
+	)
+}
+
+// LeaveRoomWithHttpInfoCtx
+// The same as LeaveRoomWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) LeaveRoomWithHttpInfoCtx(
+	ctx context.Context,
+	roomId string,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/room/{roomId}/leave"
 
 	path = strings.Replace(path, "{roomId}", roomId, -1)
 
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), nil)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -2997,7 +4238,7 @@ func (client *MessagingApiAPI) LeaveRoomWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -3030,6 +4271,24 @@ func (client *MessagingApiAPI) LinkRichMenuIdToUser(
 	return body, error
 }
 
+// LinkRichMenuIdToUserCtx
+// The same as LinkRichMenuIdToUser, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) LinkRichMenuIdToUserCtx(
+	ctx context.Context,
+	userId string,
+
+	richMenuId string,
+
+) (struct{}, error) {
+	_, body, error := client.LinkRichMenuIdToUserWithHttpInfoCtx(
+		ctx,
+		userId,
+
+		richMenuId,
+	)
+	return body, error
+}
+
 // LinkRichMenuIdToUser
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -3045,6 +4304,23 @@ func (client *MessagingApiAPI) LinkRichMenuIdToUserWithHttpInfo(
 
 	richMenuId string,
 
+) (*http.Response, struct{}, error) {
+	return client.LinkRichMenuIdToUserWithHttpInfoCtx(
+		client.requestContext(),
+		userId,
+
+		richMenuId,
+	)
+}
+
+// LinkRichMenuIdToUserWithHttpInfoCtx
+// The same as LinkRichMenuIdToUserWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) LinkRichMenuIdToUserWithHttpInfoCtx(
+	ctx context.Context,
+	userId string,
+
+	richMenuId string,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/user/{userId}/richmenu/{richMenuId}"
 
@@ -3052,12 +4328,12 @@ func (client *MessagingApiAPI) LinkRichMenuIdToUserWithHttpInfo(
 
 	path = strings.Replace(path, "{richMenuId}", richMenuId, -1)
 
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), nil)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -3070,7 +4346,7 @@ func (client *MessagingApiAPI) LinkRichMenuIdToUserWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -3098,6 +4374,20 @@ func (client *MessagingApiAPI) LinkRichMenuIdToUsers(
 	return body, error
 }
 
+// LinkRichMenuIdToUsersCtx
+// The same as LinkRichMenuIdToUsers, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) LinkRichMenuIdToUsersCtx(
+	ctx context.Context,
+	richMenuBulkLinkRequest *RichMenuBulkLinkRequest,
+
+) (struct{}, error) {
+	_, body, error := client.LinkRichMenuIdToUsersWithHttpInfoCtx(
+		ctx,
+		richMenuBulkLinkRequest,
+	)
+	return body, error
+}
+
 // LinkRichMenuIdToUsers
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -3110,6 +4400,19 @@ func (client *MessagingApiAPI) LinkRichMenuIdToUsersWithHttpInfo(
 
 	richMenuBulkLinkRequest *RichMenuBulkLinkRequest,
 
+) (*http.Response, struct{}, error) {
+	return client.LinkRichMenuIdToUsersWithHttpInfoCtx(
+		client.requestContext(),
+		richMenuBulkLinkRequest,
+	)
+}
+
+// LinkRichMenuIdToUsersWithHttpInfoCtx
+// The same as LinkRichMenuIdToUsersWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) LinkRichMenuIdToUsersWithHttpInfoCtx(
+	ctx context.Context,
+	richMenuBulkLinkRequest *RichMenuBulkLinkRequest,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/richmenu/bulk/link"
 
@@ -3118,13 +4421,13 @@ func (client *MessagingApiAPI) LinkRichMenuIdToUsersWithHttpInfo(
 	if err := enc.Encode(richMenuBulkLinkRequest); err != nil {
 		return nil, struct{}{}, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -3137,7 +4440,7 @@ func (client *MessagingApiAPI) LinkRichMenuIdToUsersWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -3175,6 +4478,28 @@ func (client *MessagingApiAPI) ListCoupon(
 	return body, error
 }
 
+// ListCouponCtx
+// The same as ListCoupon, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) ListCouponCtx(
+	ctx context.Context,
+	status *[]string,
+
+	start string,
+
+	limit int32,
+
+) (*MessagingApiPagerCouponListResponse, error) {
+	_, body, error := client.ListCouponWithHttpInfoCtx(
+		ctx,
+		status,
+
+		start,
+
+		limit,
+	)
+	return body, error
+}
+
 // ListCoupon
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -3193,10 +4518,31 @@ func (client *MessagingApiAPI) ListCouponWithHttpInfo(
 
 	limit int32,
 
+) (*http.Response, *MessagingApiPagerCouponListResponse, error) {
+	return client.ListCouponWithHttpInfoCtx(
+		client.requestContext(),
+		status,
+
+		start,
+
+		limit,
+	)
+}
+
+// ListCouponWithHttpInfoCtx
+// The same as ListCouponWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) ListCouponWithHttpInfoCtx(
+	ctx context.Context,
+	status *[]string,
+
+	start string,
+
+	limit int32,
+
 ) (*http.Response, *MessagingApiPagerCouponListResponse, error) {
 	path := "/v2/bot/coupon"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -3212,7 +4558,7 @@ func (client *MessagingApiAPI) ListCouponWithHttpInfo(
 
 	req.URL.RawQuery = query.Encode()
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -3225,7 +4571,7 @@ func (client *MessagingApiAPI) ListCouponWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -3258,6 +4604,20 @@ func (client *MessagingApiAPI) MarkMessagesAsRead(
 	return body, error
 }
 
+// MarkMessagesAsReadCtx
+// The same as MarkMessagesAsRead, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) MarkMessagesAsReadCtx(
+	ctx context.Context,
+	markMessagesAsReadRequest *MarkMessagesAsReadRequest,
+
+) (struct{}, error) {
+	_, body, error := client.MarkMessagesAsReadWithHttpInfoCtx(
+		ctx,
+		markMessagesAsReadRequest,
+	)
+	return body, error
+}
+
 // MarkMessagesAsRead
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -3270,6 +4630,19 @@ func (client *MessagingApiAPI) MarkMessagesAsReadWithHttpInfo(
 
 	markMessagesAsReadRequest *MarkMessagesAsReadRequest,
 
+) (*http.Response, struct{}, error) {
+	return client.MarkMessagesAsReadWithHttpInfoCtx(
+		client.requestContext(),
+		markMessagesAsReadRequest,
+	)
+}
+
+// MarkMessagesAsReadWithHttpInfoCtx
+// The same as MarkMessagesAsReadWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) MarkMessagesAsReadWithHttpInfoCtx(
+	ctx context.Context,
+	markMessagesAsReadRequest *MarkMessagesAsReadRequest,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/message/markAsRead"
 
@@ -3278,13 +4651,13 @@ func (client *MessagingApiAPI) MarkMessagesAsReadWithHttpInfo(
 	if err := enc.Encode(markMessagesAsReadRequest); err != nil {
 		return nil, struct{}{}, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -3297,7 +4670,7 @@ func (client *MessagingApiAPI) MarkMessagesAsReadWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -3325,6 +4698,20 @@ func (client *MessagingApiAPI) MarkMessagesAsReadByToken(
 	return body, error
 }
 
+// MarkMessagesAsReadByTokenCtx
+// The same as MarkMessagesAsReadByToken, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) MarkMessagesAsReadByTokenCtx(
+	ctx context.Context,
+	markMessagesAsReadByTokenRequest *MarkMessagesAsReadByTokenRequest,
+
+) (struct{}, error) {
+	_, body, error := client.MarkMessagesAsReadByTokenWithHttpInfoCtx(
+		ctx,
+		markMessagesAsReadByTokenRequest,
+	)
+	return body, error
+}
+
 // MarkMessagesAsReadByToken
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -3337,6 +4724,19 @@ func (client *MessagingApiAPI) MarkMessagesAsReadByTokenWithHttpInfo(
 
 	markMessagesAsReadByTokenRequest *MarkMessagesAsReadByTokenRequest,
 
+) (*http.Response, struct{}, error) {
+	return client.MarkMessagesAsReadByTokenWithHttpInfoCtx(
+		client.requestContext(),
+		markMessagesAsReadByTokenRequest,
+	)
+}
+
+// MarkMessagesAsReadByTokenWithHttpInfoCtx
+// The same as MarkMessagesAsReadByTokenWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) MarkMessagesAsReadByTokenWithHttpInfoCtx(
+	ctx context.Context,
+	markMessagesAsReadByTokenRequest *MarkMessagesAsReadByTokenRequest,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/chat/markAsRead"
 
@@ -3345,13 +4745,13 @@ func (client *MessagingApiAPI) MarkMessagesAsReadByTokenWithHttpInfo(
 	if err := enc.Encode(markMessagesAsReadByTokenRequest); err != nil {
 		return nil, struct{}{}, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -3364,7 +4764,7 @@ func (client *MessagingApiAPI) MarkMessagesAsReadByTokenWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -3397,6 +4797,24 @@ func (client *MessagingApiAPI) Multicast(
 	return body, error
 }
 
+// MulticastCtx
+// The same as Multicast, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) MulticastCtx(
+	ctx context.Context,
+	multicastRequest *MulticastRequest,
+
+	xLineRetryKey string,
+
+) (*map[string]interface{}, error) {
+	_, body, error := client.MulticastWithHttpInfoCtx(
+		ctx,
+		multicastRequest,
+
+		xLineRetryKey,
+	)
+	return body, error
+}
+
 // Multicast
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -3412,6 +4830,23 @@ func (client *MessagingApiAPI) MulticastWithHttpInfo(
 
 	xLineRetryKey string,
 
+) (*http.Response, *map[string]interface{}, error) {
+	return client.MulticastWithHttpInfoCtx(
+		client.requestContext(),
+		multicastRequest,
+
+		xLineRetryKey,
+	)
+}
+
+// MulticastWithHttpInfoCtx
+// The same as MulticastWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) MulticastWithHttpInfoCtx(
+	ctx context.Context,
+	multicastRequest *MulticastRequest,
+
+	xLineRetryKey string,
+
 ) (*http.Response, *map[string]interface{}, error) {
 	path := "/v2/bot/message/multicast"
 
@@ -3420,7 +4855,7 @@ func (client *MessagingApiAPI) MulticastWithHttpInfo(
 	if err := enc.Encode(multicastRequest); err != nil {
 		return nil, nil, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -3428,7 +4863,7 @@ func (client *MessagingApiAPI) MulticastWithHttpInfo(
 
 	req.Header.Set("X-Line-Retry-Key", xLineRetryKey)
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -3441,7 +4876,7 @@ func (client *MessagingApiAPI) MulticastWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -3479,6 +4914,24 @@ func (client *MessagingApiAPI) Narrowcast(
 	return body, error
 }
 
+// NarrowcastCtx
+// The same as Narrowcast, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) NarrowcastCtx(
+	ctx context.Context,
+	narrowcastRequest *NarrowcastRequest,
+
+	xLineRetryKey string,
+
+) (*map[string]interface{}, error) {
+	_, body, error := client.NarrowcastWithHttpInfoCtx(
+		ctx,
+		narrowcastRequest,
+
+		xLineRetryKey,
+	)
+	return body, error
+}
+
 // Narrowcast
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -3494,6 +4947,23 @@ func (client *MessagingApiAPI) NarrowcastWithHttpInfo(
 
 	xLineRetryKey string,
 
+) (*http.Response, *map[string]interface{}, error) {
+	return client.NarrowcastWithHttpInfoCtx(
+		client.requestContext(),
+		narrowcastRequest,
+
+		xLineRetryKey,
+	)
+}
+
+// NarrowcastWithHttpInfoCtx
+// The same as NarrowcastWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) NarrowcastWithHttpInfoCtx(
+	ctx context.Context,
+	narrowcastRequest *NarrowcastRequest,
+
+	xLineRetryKey string,
+
 ) (*http.Response, *map[string]interface{}, error) {
 	path := "/v2/bot/message/narrowcast"
 
@@ -3502,7 +4972,7 @@ func (client *MessagingApiAPI) NarrowcastWithHttpInfo(
 	if err := enc.Encode(narrowcastRequest); err != nil {
 		return nil, nil, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -3510,7 +4980,7 @@ func (client *MessagingApiAPI) NarrowcastWithHttpInfo(
 
 	req.Header.Set("X-Line-Retry-Key", xLineRetryKey)
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -3523,7 +4993,7 @@ func (client *MessagingApiAPI) NarrowcastWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -3561,6 +5031,24 @@ func (client *MessagingApiAPI) PushMessage(
 	return body, error
 }
 
+// PushMessageCtx
+// The same as PushMessage, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) PushMessageCtx(
+	ctx context.Context,
+	pushMessageRequest *PushMessageRequest,
+
+	xLineRetryKey string,
+
+) (*PushMessageResponse, error) {
+	_, body, error := client.PushMessageWithHttpInfoCtx(
+		ctx,
+		pushMessageRequest,
+
+		xLineRetryKey,
+	)
+	return body, error
+}
+
 // PushMessage
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -3576,6 +5064,23 @@ func (client *MessagingApiAPI) PushMessageWithHttpInfo(
 
 	xLineRetryKey string,
 
+) (*http.Response, *PushMessageResponse, error) {
+	return client.PushMessageWithHttpInfoCtx(
+		client.requestContext(),
+		pushMessageRequest,
+
+		xLineRetryKey,
+	)
+}
+
+// PushMessageWithHttpInfoCtx
+// The same as PushMessageWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) PushMessageWithHttpInfoCtx(
+	ctx context.Context,
+	pushMessageRequest *PushMessageRequest,
+
+	xLineRetryKey string,
+
 ) (*http.Response, *PushMessageResponse, error) {
 	path := "/v2/bot/message/push"
 
@@ -3584,7 +5089,7 @@ func (client *MessagingApiAPI) PushMessageWithHttpInfo(
 	if err := enc.Encode(pushMessageRequest); err != nil {
 		return nil, nil, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -3592,7 +5097,7 @@ func (client *MessagingApiAPI) PushMessageWithHttpInfo(
 
 	req.Header.Set("X-Line-Retry-Key", xLineRetryKey)
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -3605,7 +5110,7 @@ func (client *MessagingApiAPI) PushMessageWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -3643,6 +5148,24 @@ func (client *MessagingApiAPI) PushMessagesByPhone(
 	return body, error
 }
 
+// PushMessagesByPhoneCtx
+// The same as PushMessagesByPhone, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) PushMessagesByPhoneCtx(
+	ctx context.Context,
+	pnpMessagesRequest *PnpMessagesRequest,
+
+	xLineDeliveryTag string,
+
+) (struct{}, error) {
+	_, body, error := client.PushMessagesByPhoneWithHttpInfoCtx(
+		ctx,
+		pnpMessagesRequest,
+
+		xLineDeliveryTag,
+	)
+	return body, error
+}
+
 // PushMessagesByPhone
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -3651,9 +5174,26 @@ func (client *MessagingApiAPI) PushMessagesByPhone(
 //        pnpMessagesRequest
 //        xLineDeliveryTag             String returned in the delivery.data property of the delivery completion event via Webhook.
 
-// https://developers.line.biz/en/reference/partner-docs/#send-line-notification-message
-func (client *MessagingApiAPI) PushMessagesByPhoneWithHttpInfo(
+// https://developers.line.biz/en/reference/partner-docs/#send-line-notification-message
+func (client *MessagingApiAPI) PushMessagesByPhoneWithHttpInfo(
+
+	pnpMessagesRequest *PnpMessagesRequest,
+
+	xLineDeliveryTag string,
+
+) (*http.Response, struct{}, error) {
+	return client.PushMessagesByPhoneWithHttpInfoCtx(
+		client.requestContext(),
+		pnpMessagesRequest,
+
+		xLineDeliveryTag,
+	)
+}
 
+// PushMessagesByPhoneWithHttpInfoCtx
+// The same as PushMessagesByPhoneWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) PushMessagesByPhoneWithHttpInfoCtx(
+	ctx context.Context,
 	pnpMessagesRequest *PnpMessagesRequest,
 
 	xLineDeliveryTag string,
@@ -3666,7 +5206,7 @@ func (client *MessagingApiAPI) PushMessagesByPhoneWithHttpInfo(
 	if err := enc.Encode(pnpMessagesRequest); err != nil {
 		return nil, struct{}{}, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
@@ -3674,7 +5214,7 @@ func (client *MessagingApiAPI) PushMessagesByPhoneWithHttpInfo(
 
 	req.Header.Set("X-Line-Delivery-Tag", xLineDeliveryTag)
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -3687,7 +5227,7 @@ func (client *MessagingApiAPI) PushMessagesByPhoneWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -3715,6 +5255,20 @@ func (client *MessagingApiAPI) ReplyMessage(
 	return body, error
 }
 
+// ReplyMessageCtx
+// The same as ReplyMessage, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) ReplyMessageCtx(
+	ctx context.Context,
+	replyMessageRequest *ReplyMessageRequest,
+
+) (*ReplyMessageResponse, error) {
+	_, body, error := client.ReplyMessageWithHttpInfoCtx(
+		ctx,
+		replyMessageRequest,
+	)
+	return body, error
+}
+
 // ReplyMessage
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -3727,6 +5281,19 @@ func (client *MessagingApiAPI) ReplyMessageWithHttpInfo(
 
 	replyMessageRequest *ReplyMessageRequest,
 
+) (*http.Response, *ReplyMessageResponse, error) {
+	return client.ReplyMessageWithHttpInfoCtx(
+		client.requestContext(),
+		replyMessageRequest,
+	)
+}
+
+// ReplyMessageWithHttpInfoCtx
+// The same as ReplyMessageWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) ReplyMessageWithHttpInfoCtx(
+	ctx context.Context,
+	replyMessageRequest *ReplyMessageRequest,
+
 ) (*http.Response, *ReplyMessageResponse, error) {
 	path := "/v2/bot/message/reply"
 
@@ -3735,13 +5302,13 @@ func (client *MessagingApiAPI) ReplyMessageWithHttpInfo(
 	if err := enc.Encode(replyMessageRequest); err != nil {
 		return nil, nil, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, nil, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -3754,7 +5321,7 @@ func (client *MessagingApiAPI) ReplyMessageWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -3787,6 +5354,20 @@ func (client *MessagingApiAPI) RichMenuBatch(
 	return body, error
 }
 
+// RichMenuBatchCtx
+// The same as RichMenuBatch, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) RichMenuBatchCtx(
+	ctx context.Context,
+	richMenuBatchRequest *RichMenuBatchRequest,
+
+) (struct{}, error) {
+	_, body, error := client.RichMenuBatchWithHttpInfoCtx(
+		ctx,
+		richMenuBatchRequest,
+	)
+	return body, error
+}
+
 // RichMenuBatch
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -3799,6 +5380,19 @@ func (client *MessagingApiAPI) RichMenuBatchWithHttpInfo(
 
 	richMenuBatchRequest *RichMenuBatchRequest,
 
+) (*http.Response, struct{}, error) {
+	return client.RichMenuBatchWithHttpInfoCtx(
+		client.requestContext(),
+		richMenuBatchRequest,
+	)
+}
+
+// RichMenuBatchWithHttpInfoCtx
+// The same as RichMenuBatchWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) RichMenuBatchWithHttpInfoCtx(
+	ctx context.Context,
+	richMenuBatchRequest *RichMenuBatchRequest,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/richmenu/batch"
 
@@ -3807,13 +5401,13 @@ func (client *MessagingApiAPI) RichMenuBatchWithHttpInfo(
 	if err := enc.Encode(richMenuBatchRequest); err != nil {
 		return nil, struct{}{}, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -3826,7 +5420,7 @@ func (client *MessagingApiAPI) RichMenuBatchWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -3854,6 +5448,20 @@ func (client *MessagingApiAPI) SetDefaultRichMenu(
 	return body, error
 }
 
+// SetDefaultRichMenuCtx
+// The same as SetDefaultRichMenu, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) SetDefaultRichMenuCtx(
+	ctx context.Context,
+	richMenuId string,
+
+) (struct{}, error) {
+	_, body, error := client.SetDefaultRichMenuWithHttpInfoCtx(
+		ctx,
+		richMenuId,
+	)
+	return body, error
+}
+
 // SetDefaultRichMenu
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -3866,17 +5474,30 @@ func (client *MessagingApiAPI) SetDefaultRichMenuWithHttpInfo(
 
 	richMenuId string,
 
+) (*http.Response, struct{}, error) {
+	return client.SetDefaultRichMenuWithHttpInfoCtx(
+		client.requestContext(),
+		richMenuId,
+	)
+}
+
+// SetDefaultRichMenuWithHttpInfoCtx
+// The same as SetDefaultRichMenuWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) SetDefaultRichMenuWithHttpInfoCtx(
+	ctx context.Context,
+	richMenuId string,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/user/all/richmenu/{richMenuId}"
 
 	path = strings.Replace(path, "{richMenuId}", richMenuId, -1)
 
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), nil)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -3889,7 +5510,7 @@ func (client *MessagingApiAPI) SetDefaultRichMenuWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -3917,6 +5538,20 @@ func (client *MessagingApiAPI) SetWebhookEndpoint(
 	return body, error
 }
 
+// SetWebhookEndpointCtx
+// The same as SetWebhookEndpoint, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) SetWebhookEndpointCtx(
+	ctx context.Context,
+	setWebhookEndpointRequest *SetWebhookEndpointRequest,
+
+) (struct{}, error) {
+	_, body, error := client.SetWebhookEndpointWithHttpInfoCtx(
+		ctx,
+		setWebhookEndpointRequest,
+	)
+	return body, error
+}
+
 // SetWebhookEndpoint
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -3929,6 +5564,19 @@ func (client *MessagingApiAPI) SetWebhookEndpointWithHttpInfo(
 
 	setWebhookEndpointRequest *SetWebhookEndpointRequest,
 
+) (*http.Response, struct{}, error) {
+	return client.SetWebhookEndpointWithHttpInfoCtx(
+		client.requestContext(),
+		setWebhookEndpointRequest,
+	)
+}
+
+// SetWebhookEndpointWithHttpInfoCtx
+// The same as SetWebhookEndpointWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) SetWebhookEndpointWithHttpInfoCtx(
+	ctx context.Context,
+	setWebhookEndpointRequest *SetWebhookEndpointRequest,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/channel/webhook/endpoint"
 
@@ -3937,13 +5585,13 @@ func (client *MessagingApiAPI) SetWebhookEndpointWithHttpInfo(
 	if err := enc.Encode(setWebhookEndpointRequest); err != nil {
 		return nil, struct{}{}, err
 	}
-	req, err := http.NewRequest(http.MethodPut, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, client.Url(path), &buf)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -3956,7 +5604,7 @@ func (client *MessagingApiAPI) SetWebhookEndpointWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -3984,6 +5632,20 @@ func (client *MessagingApiAPI) ShowLoadingAnimation(
 	return body, error
 }
 
+// ShowLoadingAnimationCtx
+// The same as ShowLoadingAnimation, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) ShowLoadingAnimationCtx(
+	ctx context.Context,
+	showLoadingAnimationRequest *ShowLoadingAnimationRequest,
+
+) (*map[string]interface{}, error) {
+	_, body, error := client.ShowLoadingAnimationWithHttpInfoCtx(
+		ctx,
+		showLoadingAnimationRequest,
+	)
+	return body, error
+}
+
 // ShowLoadingAnimation
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -3996,6 +5658,19 @@ func (client *MessagingApiAPI) ShowLoadingAnimationWithHttpInfo(
 
 	showLoadingAnimationRequest *ShowLoadingAnimationRequest,
 
+) (*http.Response, *map[string]interface{}, error) {
+	return client.ShowLoadingAnimationWithHttpInfoCtx(
+		client.requestContext(),
+		showLoadingAnimationRequest,
+	)
+}
+
+// ShowLoadingAnimationWithHttpInfoCtx
+// The same as ShowLoadingAnimationWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) ShowLoadingAnimationWithHttpInfoCtx(
+	ctx context.Context,
+	showLoadingAnimationRequest *ShowLoadingAnimationRequest,
+
 ) (*http.Response, *map[string]interface{}, error) {
 	path := "/v2/bot/chat/loading/start"
 
@@ -4004,13 +5679,13 @@ func (client *MessagingApiAPI) ShowLoadingAnimationWithHttpInfo(
 	if err := enc.Encode(showLoadingAnimationRequest); err != nil {
 		return nil, nil, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, nil, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -4023,7 +5698,7 @@ func (client *MessagingApiAPI) ShowLoadingAnimationWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -4056,6 +5731,20 @@ func (client *MessagingApiAPI) TestWebhookEndpoint(
 	return body, error
 }
 
+// TestWebhookEndpointCtx
+// The same as TestWebhookEndpoint, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) TestWebhookEndpointCtx(
+	ctx context.Context,
+	testWebhookEndpointRequest *TestWebhookEndpointRequest,
+
+) (*TestWebhookEndpointResponse, error) {
+	_, body, error := client.TestWebhookEndpointWithHttpInfoCtx(
+		ctx,
+		testWebhookEndpointRequest,
+	)
+	return body, error
+}
+
 // TestWebhookEndpoint
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -4068,6 +5757,19 @@ func (client *MessagingApiAPI) TestWebhookEndpointWithHttpInfo(
 
 	testWebhookEndpointRequest *TestWebhookEndpointRequest,
 
+) (*http.Response, *TestWebhookEndpointResponse, error) {
+	return client.TestWebhookEndpointWithHttpInfoCtx(
+		client.requestContext(),
+		testWebhookEndpointRequest,
+	)
+}
+
+// TestWebhookEndpointWithHttpInfoCtx
+// The same as TestWebhookEndpointWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) TestWebhookEndpointWithHttpInfoCtx(
+	ctx context.Context,
+	testWebhookEndpointRequest *TestWebhookEndpointRequest,
+
 ) (*http.Response, *TestWebhookEndpointResponse, error) {
 	path := "/v2/bot/channel/webhook/test"
 
@@ -4076,13 +5778,13 @@ func (client *MessagingApiAPI) TestWebhookEndpointWithHttpInfo(
 	if err := enc.Encode(testWebhookEndpointRequest); err != nil {
 		return nil, nil, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, nil, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -4095,7 +5797,7 @@ func (client *MessagingApiAPI) TestWebhookEndpointWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -4128,6 +5830,20 @@ func (client *MessagingApiAPI) UnlinkRichMenuIdFromUser(
 	return body, error
 }
 
+// UnlinkRichMenuIdFromUserCtx
+// The same as UnlinkRichMenuIdFromUser, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) UnlinkRichMenuIdFromUserCtx(
+	ctx context.Context,
+	userId string,
+
+) (struct{}, error) {
+	_, body, error := client.UnlinkRichMenuIdFromUserWithHttpInfoCtx(
+		ctx,
+		userId,
+	)
+	return body, error
+}
+
 // UnlinkRichMenuIdFromUser
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -4140,17 +5856,30 @@ func (client *MessagingApiAPI) UnlinkRichMenuIdFromUserWithHttpInfo(
 
 	userId string,
 
+) (*http.Response, struct{}, error) {
+	return client.UnlinkRichMenuIdFromUserWithHttpInfoCtx(
+		client.requestContext(),
+		userId,
+	)
+}
+
+// UnlinkRichMenuIdFromUserWithHttpInfoCtx
+// The same as UnlinkRichMenuIdFromUserWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) UnlinkRichMenuIdFromUserWithHttpInfoCtx(
+	ctx context.Context,
+	userId string,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/user/{userId}/richmenu"
 
 	path = strings.Replace(path, "{userId}", userId, -1)
 
-	req, err := http.NewRequest(http.MethodDelete, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, client.Url(path), nil)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -4163,7 +5892,7 @@ func (client *MessagingApiAPI) UnlinkRichMenuIdFromUserWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -4191,6 +5920,20 @@ func (client *MessagingApiAPI) UnlinkRichMenuIdFromUsers(
 	return body, error
 }
 
+// UnlinkRichMenuIdFromUsersCtx
+// The same as UnlinkRichMenuIdFromUsers, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) UnlinkRichMenuIdFromUsersCtx(
+	ctx context.Context,
+	richMenuBulkUnlinkRequest *RichMenuBulkUnlinkRequest,
+
+) (struct{}, error) {
+	_, body, error := client.UnlinkRichMenuIdFromUsersWithHttpInfoCtx(
+		ctx,
+		richMenuBulkUnlinkRequest,
+	)
+	return body, error
+}
+
 // UnlinkRichMenuIdFromUsers
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -4203,6 +5946,19 @@ func (client *MessagingApiAPI) UnlinkRichMenuIdFromUsersWithHttpInfo(
 
 	richMenuBulkUnlinkRequest *RichMenuBulkUnlinkRequest,
 
+) (*http.Response, struct{}, error) {
+	return client.UnlinkRichMenuIdFromUsersWithHttpInfoCtx(
+		client.requestContext(),
+		richMenuBulkUnlinkRequest,
+	)
+}
+
+// UnlinkRichMenuIdFromUsersWithHttpInfoCtx
+// The same as UnlinkRichMenuIdFromUsersWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) UnlinkRichMenuIdFromUsersWithHttpInfoCtx(
+	ctx context.Context,
+	richMenuBulkUnlinkRequest *RichMenuBulkUnlinkRequest,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/richmenu/bulk/unlink"
 
@@ -4211,13 +5967,13 @@ func (client *MessagingApiAPI) UnlinkRichMenuIdFromUsersWithHttpInfo(
 	if err := enc.Encode(richMenuBulkUnlinkRequest); err != nil {
 		return nil, struct{}{}, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -4230,7 +5986,7 @@ func (client *MessagingApiAPI) UnlinkRichMenuIdFromUsersWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -4263,6 +6019,24 @@ func (client *MessagingApiAPI) UpdateRichMenuAlias(
 	return body, error
 }
 
+// UpdateRichMenuAliasCtx
+// The same as UpdateRichMenuAlias, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) UpdateRichMenuAliasCtx(
+	ctx context.Context,
+	richMenuAliasId string,
+
+	updateRichMenuAliasRequest *UpdateRichMenuAliasRequest,
+
+) (struct{}, error) {
+	_, body, error := client.UpdateRichMenuAliasWithHttpInfoCtx(
+		ctx,
+		richMenuAliasId,
+
+		updateRichMenuAliasRequest,
+	)
+	return body, error
+}
+
 // UpdateRichMenuAlias
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -4278,6 +6052,23 @@ func (client *MessagingApiAPI) UpdateRichMenuAliasWithHttpInfo(
 
 	updateRichMenuAliasRequest *UpdateRichMenuAliasRequest,
 
+) (*http.Response, struct{}, error) {
+	return client.UpdateRichMenuAliasWithHttpInfoCtx(
+		client.requestContext(),
+		richMenuAliasId,
+
+		updateRichMenuAliasRequest,
+	)
+}
+
+// UpdateRichMenuAliasWithHttpInfoCtx
+// The same as UpdateRichMenuAliasWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) UpdateRichMenuAliasWithHttpInfoCtx(
+	ctx context.Context,
+	richMenuAliasId string,
+
+	updateRichMenuAliasRequest *UpdateRichMenuAliasRequest,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/richmenu/alias/{richMenuAliasId}"
 
@@ -4288,13 +6079,13 @@ func (client *MessagingApiAPI) UpdateRichMenuAliasWithHttpInfo(
 	if err := enc.Encode(updateRichMenuAliasRequest); err != nil {
 		return nil, struct{}{}, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -4307,7 +6098,7 @@ func (client *MessagingApiAPI) UpdateRichMenuAliasWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -4335,6 +6126,20 @@ func (client *MessagingApiAPI) ValidateBroadcast(
 	return body, error
 }
 
+// ValidateBroadcastCtx
+// The same as ValidateBroadcast, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) ValidateBroadcastCtx(
+	ctx context.Context,
+	validateMessageRequest *ValidateMessageRequest,
+
+) (struct{}, error) {
+	_, body, error := client.ValidateBroadcastWithHttpInfoCtx(
+		ctx,
+		validateMessageRequest,
+	)
+	return body, error
+}
+
 // ValidateBroadcast
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -4347,6 +6152,19 @@ func (client *MessagingApiAPI) ValidateBroadcastWithHttpInfo(
 
 	validateMessageRequest *ValidateMessageRequest,
 
+) (*http.Response, struct{}, error) {
+	return client.ValidateBroadcastWithHttpInfoCtx(
+		client.requestContext(),
+		validateMessageRequest,
+	)
+}
+
+// ValidateBroadcastWithHttpInfoCtx
+// The same as ValidateBroadcastWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) ValidateBroadcastWithHttpInfoCtx(
+	ctx context.Context,
+	validateMessageRequest *ValidateMessageRequest,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/message/validate/broadcast"
 
@@ -4355,13 +6173,13 @@ func (client *MessagingApiAPI) ValidateBroadcastWithHttpInfo(
 	if err := enc.Encode(validateMessageRequest); err != nil {
 		return nil, struct{}{}, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -4374,7 +6192,7 @@ func (client *MessagingApiAPI) ValidateBroadcastWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -4402,6 +6220,20 @@ func (client *MessagingApiAPI) ValidateMulticast(
 	return body, error
 }
 
+// ValidateMulticastCtx
+// The same as ValidateMulticast, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) ValidateMulticastCtx(
+	ctx context.Context,
+	validateMessageRequest *ValidateMessageRequest,
+
+) (struct{}, error) {
+	_, body, error := client.ValidateMulticastWithHttpInfoCtx(
+		ctx,
+		validateMessageRequest,
+	)
+	return body, error
+}
+
 // ValidateMulticast
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -4414,6 +6246,19 @@ func (client *MessagingApiAPI) ValidateMulticastWithHttpInfo(
 
 	validateMessageRequest *ValidateMessageRequest,
 
+) (*http.Response, struct{}, error) {
+	return client.ValidateMulticastWithHttpInfoCtx(
+		client.requestContext(),
+		validateMessageRequest,
+	)
+}
+
+// ValidateMulticastWithHttpInfoCtx
+// The same as ValidateMulticastWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) ValidateMulticastWithHttpInfoCtx(
+	ctx context.Context,
+	validateMessageRequest *ValidateMessageRequest,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/message/validate/multicast"
 
@@ -4422,13 +6267,13 @@ func (client *MessagingApiAPI) ValidateMulticastWithHttpInfo(
 	if err := enc.Encode(validateMessageRequest); err != nil {
 		return nil, struct{}{}, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -4441,7 +6286,7 @@ func (client *MessagingApiAPI) ValidateMulticastWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -4469,6 +6314,20 @@ func (client *MessagingApiAPI) ValidateNarrowcast(
 	return body, error
 }
 
+// ValidateNarrowcastCtx
+// The same as ValidateNarrowcast, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) ValidateNarrowcastCtx(
+	ctx context.Context,
+	validateMessageRequest *ValidateMessageRequest,
+
+) (struct{}, error) {
+	_, body, error := client.ValidateNarrowcastWithHttpInfoCtx(
+		ctx,
+		validateMessageRequest,
+	)
+	return body, error
+}
+
 // ValidateNarrowcast
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -4481,6 +6340,19 @@ func (client *MessagingApiAPI) ValidateNarrowcastWithHttpInfo(
 
 	validateMessageRequest *ValidateMessageRequest,
 
+) (*http.Response, struct{}, error) {
+	return client.ValidateNarrowcastWithHttpInfoCtx(
+		client.requestContext(),
+		validateMessageRequest,
+	)
+}
+
+// ValidateNarrowcastWithHttpInfoCtx
+// The same as ValidateNarrowcastWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) ValidateNarrowcastWithHttpInfoCtx(
+	ctx context.Context,
+	validateMessageRequest *ValidateMessageRequest,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/message/validate/narrowcast"
 
@@ -4489,13 +6361,13 @@ func (client *MessagingApiAPI) ValidateNarrowcastWithHttpInfo(
 	if err := enc.Encode(validateMessageRequest); err != nil {
 		return nil, struct{}{}, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -4508,7 +6380,7 @@ func (client *MessagingApiAPI) ValidateNarrowcastWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -4536,6 +6408,20 @@ func (client *MessagingApiAPI) ValidatePush(
 	return body, error
 }
 
+// ValidatePushCtx
+// The same as ValidatePush, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) ValidatePushCtx(
+	ctx context.Context,
+	validateMessageRequest *ValidateMessageRequest,
+
+) (struct{}, error) {
+	_, body, error := client.ValidatePushWithHttpInfoCtx(
+		ctx,
+		validateMessageRequest,
+	)
+	return body, error
+}
+
 // ValidatePush
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -4548,6 +6434,19 @@ func (client *MessagingApiAPI) ValidatePushWithHttpInfo(
 
 	validateMessageRequest *ValidateMessageRequest,
 
+) (*http.Response, struct{}, error) {
+	return client.ValidatePushWithHttpInfoCtx(
+		client.requestContext(),
+		validateMessageRequest,
+	)
+}
+
+// ValidatePushWithHttpInfoCtx
+// The same as ValidatePushWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) ValidatePushWithHttpInfoCtx(
+	ctx context.Context,
+	validateMessageRequest *ValidateMessageRequest,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/message/validate/push"
 
@@ -4556,13 +6455,13 @@ func (client *MessagingApiAPI) ValidatePushWithHttpInfo(
 	if err := enc.Encode(validateMessageRequest); err != nil {
 		return nil, struct{}{}, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -4575,7 +6474,7 @@ func (client *MessagingApiAPI) ValidatePushWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -4603,6 +6502,20 @@ func (client *MessagingApiAPI) ValidateReply(
 	return body, error
 }
 
+// ValidateReplyCtx
+// The same as ValidateReply, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) ValidateReplyCtx(
+	ctx context.Context,
+	validateMessageRequest *ValidateMessageRequest,
+
+) (struct{}, error) {
+	_, body, error := client.ValidateReplyWithHttpInfoCtx(
+		ctx,
+		validateMessageRequest,
+	)
+	return body, error
+}
+
 // ValidateReply
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -4615,6 +6528,19 @@ func (client *MessagingApiAPI) ValidateReplyWithHttpInfo(
 
 	validateMessageRequest *ValidateMessageRequest,
 
+) (*http.Response, struct{}, error) {
+	return client.ValidateReplyWithHttpInfoCtx(
+		client.requestContext(),
+		validateMessageRequest,
+	)
+}
+
+// ValidateReplyWithHttpInfoCtx
+// The same as ValidateReplyWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) ValidateReplyWithHttpInfoCtx(
+	ctx context.Context,
+	validateMessageRequest *ValidateMessageRequest,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/message/validate/reply"
 
@@ -4623,13 +6549,13 @@ func (client *MessagingApiAPI) ValidateReplyWithHttpInfo(
 	if err := enc.Encode(validateMessageRequest); err != nil {
 		return nil, struct{}{}, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -4642,7 +6568,7 @@ func (client *MessagingApiAPI) ValidateReplyWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -4670,6 +6596,20 @@ func (client *MessagingApiAPI) ValidateRichMenuBatchRequest(
 	return body, error
 }
 
+// ValidateRichMenuBatchRequestCtx
+// The same as ValidateRichMenuBatchRequest, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) ValidateRichMenuBatchRequestCtx(
+	ctx context.Context,
+	richMenuBatchRequest *RichMenuBatchRequest,
+
+) (struct{}, error) {
+	_, body, error := client.ValidateRichMenuBatchRequestWithHttpInfoCtx(
+		ctx,
+		richMenuBatchRequest,
+	)
+	return body, error
+}
+
 // ValidateRichMenuBatchRequest
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -4682,6 +6622,19 @@ func (client *MessagingApiAPI) ValidateRichMenuBatchRequestWithHttpInfo(
 
 	richMenuBatchRequest *RichMenuBatchRequest,
 
+) (*http.Response, struct{}, error) {
+	return client.ValidateRichMenuBatchRequestWithHttpInfoCtx(
+		client.requestContext(),
+		richMenuBatchRequest,
+	)
+}
+
+// ValidateRichMenuBatchRequestWithHttpInfoCtx
+// The same as ValidateRichMenuBatchRequestWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) ValidateRichMenuBatchRequestWithHttpInfoCtx(
+	ctx context.Context,
+	richMenuBatchRequest *RichMenuBatchRequest,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/richmenu/validate/batch"
 
@@ -4690,13 +6643,13 @@ func (client *MessagingApiAPI) ValidateRichMenuBatchRequestWithHttpInfo(
 	if err := enc.Encode(richMenuBatchRequest); err != nil {
 		return nil, struct{}{}, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -4709,7 +6662,7 @@ func (client *MessagingApiAPI) ValidateRichMenuBatchRequestWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -4737,6 +6690,20 @@ func (client *MessagingApiAPI) ValidateRichMenuObject(
 	return body, error
 }
 
+// ValidateRichMenuObjectCtx
+// The same as ValidateRichMenuObject, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) ValidateRichMenuObjectCtx(
+	ctx context.Context,
+	richMenuRequest *RichMenuRequest,
+
+) (struct{}, error) {
+	_, body, error := client.ValidateRichMenuObjectWithHttpInfoCtx(
+		ctx,
+		richMenuRequest,
+	)
+	return body, error
+}
+
 // ValidateRichMenuObject
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -4749,6 +6716,19 @@ func (client *MessagingApiAPI) ValidateRichMenuObjectWithHttpInfo(
 
 	richMenuRequest *RichMenuRequest,
 
+) (*http.Response, struct{}, error) {
+	return client.ValidateRichMenuObjectWithHttpInfoCtx(
+		client.requestContext(),
+		richMenuRequest,
+	)
+}
+
+// ValidateRichMenuObjectWithHttpInfoCtx
+// The same as ValidateRichMenuObjectWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiAPI) ValidateRichMenuObjectWithHttpInfoCtx(
+	ctx context.Context,
+	richMenuRequest *RichMenuRequest,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/richmenu/validate"
 
@@ -4757,13 +6737,13 @@ func (client *MessagingApiAPI) ValidateRichMenuObjectWithHttpInfo(
 	if err := enc.Encode(richMenuRequest); err != nil {
 		return nil, struct{}{}, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -4776,7 +6756,7 @@ func (client *MessagingApiAPI) ValidateRichMenuObjectWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
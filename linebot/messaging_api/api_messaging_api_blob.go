@@ -33,24 +33,27 @@ import (
 	"strings"
 
 	"github.com/line/line-bot-sdk-go/v8/linebot"
+	"github.com/line/line-bot-sdk-go/v8/linebot/channel_access_token"
+	"github.com/line/line-bot-sdk-go/v8/linebot/ratelimit"
+	"github.com/line/line-bot-sdk-go/v8/linebot/retry"
 )
 
 type MessagingApiBlobAPI struct {
 	httpClient   *http.Client
 	endpoint     *url.URL
 	channelToken string
+	tokenSource  channel_access_token.TokenSource
 	ctx          context.Context
+	retryPolicy  *retry.Policy
+	rateLimiter  *ratelimit.Limiter
 }
 
 // MessagingApiBlobAPIOption type
 type MessagingApiBlobAPIOption func(*MessagingApiBlobAPI) error
 
 // New returns a new bot client instance.
+// channelToken may be empty if a TokenSource is given with WithBlobTokenSource.
 func NewMessagingApiBlobAPI(channelToken string, options ...MessagingApiBlobAPIOption) (*MessagingApiBlobAPI, error) {
-	if channelToken == "" {
-		return nil, errors.New("missing channel access token")
-	}
-
 	c := &MessagingApiBlobAPI{
 		channelToken: channelToken,
 		httpClient:   http.DefaultClient,
@@ -68,24 +71,61 @@ func NewMessagingApiBlobAPI(channelToken string, options ...MessagingApiBlobAPIO
 			return nil, err
 		}
 	}
+	if c.channelToken == "" && c.tokenSource == nil {
+		return nil, errors.New("missing channel access token")
+	}
 	return c, nil
 }
 
 // WithContext method
+// The context is stored on the client and shared by every call made through it.
+// To use a different context for each call, use the methods with the Ctx suffix instead.
 func (call *MessagingApiBlobAPI) WithContext(ctx context.Context) *MessagingApiBlobAPI {
 	call.ctx = ctx
 	return call
 }
 
 func (client *MessagingApiBlobAPI) Do(req *http.Request) (*http.Response, error) {
-	if client.channelToken != "" {
+	if client.ctx != nil {
+		req = req.WithContext(client.ctx)
+	}
+	return client.do(req)
+}
+
+// do sends req with the context it already carries.
+func (client *MessagingApiBlobAPI) do(req *http.Request) (*http.Response, error) {
+	if client.retryPolicy != nil {
+		return client.retryPolicy.Do(req, client.send)
+	}
+	return client.send(req)
+}
+
+// send sends a single attempt of req.
+func (client *MessagingApiBlobAPI) send(req *http.Request) (*http.Response, error) {
+	if client.rateLimiter != nil {
+		if err := client.rateLimiter.Wait(req); err != nil {
+			return nil, err
+		}
+	}
+	if client.tokenSource != nil {
+		token, err := client.tokenSource.Token(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else if client.channelToken != "" {
 		req.Header.Set("Authorization", "Bearer "+client.channelToken)
 	}
 	req.Header.Set("User-Agent", "LINE-BotSDK-Go/"+linebot.GetVersion())
+	return client.httpClient.Do(req)
+}
+
+// requestContext returns the context set by WithContext, or context.Background if there is none.
+func (client *MessagingApiBlobAPI) requestContext() context.Context {
 	if client.ctx != nil {
-		req = req.WithContext(client.ctx)
+		return client.ctx
 	}
-	return client.httpClient.Do(req)
+	return context.Background()
 }
 
 func (client *MessagingApiBlobAPI) Url(endpointPath string) string {
@@ -103,6 +143,34 @@ func WithBlobHTTPClient(c *http.Client) MessagingApiBlobAPIOption {
 	}
 }
 
+// WithBlobTokenSource function
+// Every request uses a token obtained from ts instead of the channel access token given to NewMessagingApiBlobAPI.
+func WithBlobTokenSource(ts channel_access_token.TokenSource) MessagingApiBlobAPIOption {
+	return func(client *MessagingApiBlobAPI) error {
+		client.tokenSource = ts
+		return nil
+	}
+}
+
+// WithBlobRetryPolicy function
+// Failed requests are retried according to policy. See the retry package for which requests are retried.
+func WithBlobRetryPolicy(policy *retry.Policy) MessagingApiBlobAPIOption {
+	return func(client *MessagingApiBlobAPI) error {
+		client.retryPolicy = policy
+		return nil
+	}
+}
+
+// WithBlobRateLimiter function
+// Every request, including retries, waits for limiter before it is sent.
+// Share one limiter between the clients of the same channel.
+func WithBlobRateLimiter(limiter *ratelimit.Limiter) MessagingApiBlobAPIOption {
+	return func(client *MessagingApiBlobAPI) error {
+		client.rateLimiter = limiter
+		return nil
+	}
+}
+
 // WithBlobEndpoint function
 func WithBlobEndpoint(endpoint string) MessagingApiBlobAPIOption {
 	return func(client *MessagingApiBlobAPI) error {
@@ -135,6 +203,20 @@ func (client *MessagingApiBlobAPI) GetMessageContent(
 	return body, error
 }
 
+// GetMessageContentCtx
+// The same as GetMessageContent, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiBlobAPI) GetMessageContentCtx(
+	ctx context.Context,
+	messageId string,
+
+) (*http.Response, error) {
+	_, body, error := client.GetMessageContentWithHttpInfoCtx(
+		ctx,
+		messageId,
+	)
+	return body, error
+}
+
 // GetMessageContent
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -148,17 +230,30 @@ func (client *MessagingApiBlobAPI) GetMessageContentWithHttpInfo(
 
 	messageId string,
 
+) (*http.Response, *http.Response, error) {
+	return client.GetMessageContentWithHttpInfoCtx(
+		client.requestContext(),
+		messageId,
+	)
+}
+
+// GetMessageContentWithHttpInfoCtx
+// The same as GetMessageContentWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiBlobAPI) GetMessageContentWithHttpInfoCtx(
+	ctx context.Context,
+	messageId string,
+
 ) (*http.Response, *http.Response, error) {
 	path := "/v2/bot/message/{messageId}/content"
 
 	path = strings.Replace(path, "{messageId}", messageId, -1)
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -171,7 +266,7 @@ func (client *MessagingApiBlobAPI) GetMessageContentWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	return res, res, nil
@@ -198,6 +293,20 @@ func (client *MessagingApiBlobAPI) GetMessageContentPreview(
 	return body, error
 }
 
+// GetMessageContentPreviewCtx
+// The same as GetMessageContentPreview, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiBlobAPI) GetMessageContentPreviewCtx(
+	ctx context.Context,
+	messageId string,
+
+) (*http.Response, error) {
+	_, body, error := client.GetMessageContentPreviewWithHttpInfoCtx(
+		ctx,
+		messageId,
+	)
+	return body, error
+}
+
 // GetMessageContentPreview
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -211,17 +320,30 @@ func (client *MessagingApiBlobAPI) GetMessageContentPreviewWithHttpInfo(
 
 	messageId string,
 
+) (*http.Response, *http.Response, error) {
+	return client.GetMessageContentPreviewWithHttpInfoCtx(
+		client.requestContext(),
+		messageId,
+	)
+}
+
+// GetMessageContentPreviewWithHttpInfoCtx
+// The same as GetMessageContentPreviewWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiBlobAPI) GetMessageContentPreviewWithHttpInfoCtx(
+	ctx context.Context,
+	messageId string,
+
 ) (*http.Response, *http.Response, error) {
 	path := "/v2/bot/message/{messageId}/content/preview"
 
 	path = strings.Replace(path, "{messageId}", messageId, -1)
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -234,7 +356,7 @@ func (client *MessagingApiBlobAPI) GetMessageContentPreviewWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	return res, res, nil
@@ -260,6 +382,20 @@ func (client *MessagingApiBlobAPI) GetMessageContentTranscodingByMessageId(
 	return body, error
 }
 
+// GetMessageContentTranscodingByMessageIdCtx
+// The same as GetMessageContentTranscodingByMessageId, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiBlobAPI) GetMessageContentTranscodingByMessageIdCtx(
+	ctx context.Context,
+	messageId string,
+
+) (*GetMessageContentTranscodingResponse, error) {
+	_, body, error := client.GetMessageContentTranscodingByMessageIdWithHttpInfoCtx(
+		ctx,
+		messageId,
+	)
+	return body, error
+}
+
 // GetMessageContentTranscodingByMessageId
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -272,17 +408,30 @@ func (client *MessagingApiBlobAPI) GetMessageContentTranscodingByMessageIdWithHt
 
 	messageId string,
 
+) (*http.Response, *GetMessageContentTranscodingResponse, error) {
+	return client.GetMessageContentTranscodingByMessageIdWithHttpInfoCtx(
+		client.requestContext(),
+		messageId,
+	)
+}
+
+// GetMessageContentTranscodingByMessageIdWithHttpInfoCtx
+// The same as GetMessageContentTranscodingByMessageIdWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiBlobAPI) GetMessageContentTranscodingByMessageIdWithHttpInfoCtx(
+	ctx context.Context,
+	messageId string,
+
 ) (*http.Response, *GetMessageContentTranscodingResponse, error) {
 	path := "/v2/bot/message/{messageId}/content/transcoding"
 
 	path = strings.Replace(path, "{messageId}", messageId, -1)
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -295,7 +444,7 @@ func (client *MessagingApiBlobAPI) GetMessageContentTranscodingByMessageIdWithHt
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -329,6 +478,20 @@ func (client *MessagingApiBlobAPI) GetRichMenuImage(
 	return body, error
 }
 
+// GetRichMenuImageCtx
+// The same as GetRichMenuImage, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiBlobAPI) GetRichMenuImageCtx(
+	ctx context.Context,
+	richMenuId string,
+
+) (*http.Response, error) {
+	_, body, error := client.GetRichMenuImageWithHttpInfoCtx(
+		ctx,
+		richMenuId,
+	)
+	return body, error
+}
+
 // GetRichMenuImage
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -342,17 +505,30 @@ func (client *MessagingApiBlobAPI) GetRichMenuImageWithHttpInfo(
 
 	richMenuId string,
 
+) (*http.Response, *http.Response, error) {
+	return client.GetRichMenuImageWithHttpInfoCtx(
+		client.requestContext(),
+		richMenuId,
+	)
+}
+
+// GetRichMenuImageWithHttpInfoCtx
+// The same as GetRichMenuImageWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiBlobAPI) GetRichMenuImageWithHttpInfoCtx(
+	ctx context.Context,
+	richMenuId string,
+
 ) (*http.Response, *http.Response, error) {
 	path := "/v2/bot/richmenu/{richMenuId}/content"
 
 	path = strings.Replace(path, "{richMenuId}", richMenuId, -1)
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -365,7 +541,7 @@ func (client *MessagingApiBlobAPI) GetRichMenuImageWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	return res, res, nil
@@ -399,6 +575,26 @@ func (client *MessagingApiBlobAPI) SetRichMenuImage(
 	return body, error
 }
 
+// SetRichMenuImageCtx
+// The same as SetRichMenuImage, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiBlobAPI) SetRichMenuImageCtx(
+	ctx context.Context,
+	richMenuId string,
+
+	bodyContentType string,
+	bodyReader io.Reader,
+
+) (struct{}, error) {
+	_, body, error := client.SetRichMenuImageWithHttpInfoCtx(
+		ctx,
+		richMenuId,
+
+		bodyContentType,
+		bodyReader,
+	)
+	return body, error
+}
+
 // SetRichMenuImage
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -416,18 +612,37 @@ func (client *MessagingApiBlobAPI) SetRichMenuImageWithHttpInfo(
 	bodyContentType string,
 	bodyReader io.Reader,
 
+) (*http.Response, struct{}, error) {
+	return client.SetRichMenuImageWithHttpInfoCtx(
+		client.requestContext(),
+		richMenuId,
+
+		bodyContentType,
+		bodyReader,
+	)
+}
+
+// SetRichMenuImageWithHttpInfoCtx
+// The same as SetRichMenuImageWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *MessagingApiBlobAPI) SetRichMenuImageWithHttpInfoCtx(
+	ctx context.Context,
+	richMenuId string,
+
+	bodyContentType string,
+	bodyReader io.Reader,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/richmenu/{richMenuId}/content"
 
 	path = strings.Replace(path, "{richMenuId}", richMenuId, -1)
 
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), bodyReader)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), bodyReader)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", bodyContentType)
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -440,7 +655,7 @@ func (client *MessagingApiBlobAPI) SetRichMenuImageWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
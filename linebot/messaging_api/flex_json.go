@@ -0,0 +1,319 @@
+package messaging_api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// FlexJSONError is a problem found at a property of Flex Message JSON.
+type FlexJSONError struct {
+	// Path is the JSON path of the property, relative to the container, such as "body.contents[2].action".
+	Path    string
+	Message string
+}
+
+func (e *FlexJSONError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// flexJSONTypes maps the interfaces of the Flex Message types to their implementations, by type property.
+var flexJSONTypes = map[reflect.Type]map[string]reflect.Type{
+	reflect.TypeOf((*FlexContainerInterface)(nil)).Elem(): {
+		"bubble":   reflect.TypeOf(FlexBubble{}),
+		"carousel": reflect.TypeOf(FlexCarousel{}),
+	},
+	reflect.TypeOf((*FlexComponentInterface)(nil)).Elem(): {
+		"box":       reflect.TypeOf(FlexBox{}),
+		"button":    reflect.TypeOf(FlexButton{}),
+		"filler":    reflect.TypeOf(FlexFiller{}),
+		"icon":      reflect.TypeOf(FlexIcon{}),
+		"image":     reflect.TypeOf(FlexImage{}),
+		"separator": reflect.TypeOf(FlexSeparator{}),
+		"span":      reflect.TypeOf(FlexSpan{}),
+		"text":      reflect.TypeOf(FlexText{}),
+		"video":     reflect.TypeOf(FlexVideo{}),
+	},
+	reflect.TypeOf((*FlexBoxBackgroundInterface)(nil)).Elem(): {
+		"linearGradient": reflect.TypeOf(FlexBoxLinearGradient{}),
+	},
+	reflect.TypeOf((*ActionInterface)(nil)).Elem(): {
+		"camera":         reflect.TypeOf(CameraAction{}),
+		"cameraRoll":     reflect.TypeOf(CameraRollAction{}),
+		"clipboard":      reflect.TypeOf(ClipboardAction{}),
+		"datetimepicker": reflect.TypeOf(DatetimePickerAction{}),
+		"location":       reflect.TypeOf(LocationAction{}),
+		"message":        reflect.TypeOf(MessageAction{}),
+		"postback":       reflect.TypeOf(PostbackAction{}),
+		"richmenuswitch": reflect.TypeOf(RichMenuSwitchAction{}),
+		"uri":            reflect.TypeOf(UriAction{}),
+	},
+}
+
+// flexTypes lists the components that have a flex property.
+var flexTypes = []string{"box", "button", "filler", "image", "text"}
+
+// UnmarshalFlexContainerJSON parses the JSON of a bubble or a carousel, such
+// as the output of the Flex Message Simulator.
+//
+// Unlike UnmarshalFlexContainer, unknown types and properties and values of
+// the wrong type are errors, reported as FlexJSONErrors joined with
+// errors.Join. Because the flex property of components is always sent, it
+// is set to 1, the default of the LINE Platform, for the components of
+// horizontal and baseline boxes that do not have one.
+func UnmarshalFlexContainerJSON(data []byte) (FlexContainerInterface, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var tree any
+	if err := dec.Decode(&tree); err != nil {
+		return nil, &FlexJSONError{Message: fmt.Sprintf("invalid JSON: %v", err)}
+	}
+
+	w := &flexJSONWalker{}
+	w.value("", tree, reflect.TypeOf((*FlexContainerInterface)(nil)).Elem())
+	if len(w.errs) > 0 {
+		return nil, errors.Join(w.errs...)
+	}
+
+	normalized, err := json.Marshal(tree)
+	if err != nil {
+		return nil, err
+	}
+	return UnmarshalFlexContainer(normalized)
+}
+
+type flexJSONWalker struct {
+	errs []error
+}
+
+func (w *flexJSONWalker) fail(path, format string, args ...any) {
+	w.errs = append(w.errs, &FlexJSONError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func joinFlexJSONPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// value checks that v, decoded from JSON, can be unmarshaled into t.
+func (w *flexJSONWalker) value(path string, v any, t reflect.Type) {
+	if v == nil {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Interface:
+		implementations, ok := flexJSONTypes[t]
+		if !ok {
+			return
+		}
+		m, ok := v.(map[string]any)
+		if !ok {
+			w.fail(path, "must be an object")
+			return
+		}
+		typ, _ := m["type"].(string)
+		if typ == "" {
+			w.fail(path, "missing type")
+			return
+		}
+		impl, ok := implementations[typ]
+		if !ok {
+			w.fail(path, "unknown type %q", typ)
+			return
+		}
+		w.object(path, m, impl)
+	case reflect.Pointer:
+		w.value(path, v, t.Elem())
+	case reflect.Struct:
+		m, ok := v.(map[string]any)
+		if !ok {
+			w.fail(path, "must be an object")
+			return
+		}
+		if name := flexJSONTypeName(t); name != "" && m["type"] != nil && m["type"] != name {
+			w.fail(path, "must be %s, got %v", name, m["type"])
+			return
+		}
+		w.object(path, m, t)
+	case reflect.Slice:
+		a, ok := v.([]any)
+		if !ok {
+			w.fail(path, "must be an array")
+			return
+		}
+		for i, e := range a {
+			w.value(fmt.Sprintf("%s[%d]", path, i), e, t.Elem())
+		}
+	case reflect.String:
+		if _, ok := v.(string); !ok {
+			w.fail(path, "must be a string")
+		}
+	case reflect.Bool:
+		if _, ok := v.(bool); !ok {
+			w.fail(path, "must be a boolean")
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := v.(json.Number)
+		if !ok {
+			w.fail(path, "must be a number")
+		} else if _, err := n.Int64(); err != nil {
+			w.fail(path, "must be an integer, got %s", n)
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := v.(json.Number); !ok {
+			w.fail(path, "must be a number")
+		}
+	}
+}
+
+func (w *flexJSONWalker) object(path string, m map[string]any, t reflect.Type) {
+	fields := flexJSONFields(t)
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		field, ok := fields[key]
+		if !ok {
+			w.fail(joinFlexJSONPath(path, key), "unknown property for %s", m["type"])
+			continue
+		}
+		w.value(joinFlexJSONPath(path, key), m[key], field)
+	}
+
+	if t == reflect.TypeOf(FlexBox{}) {
+		layout, _ := m["layout"].(string)
+		contents, _ := m["contents"].([]any)
+		if layout != string(FlexBoxLAYOUT_HORIZONTAL) && layout != string(FlexBoxLAYOUT_BASELINE) {
+			return
+		}
+		for _, child := range contents {
+			c, ok := child.(map[string]any)
+			if !ok {
+				continue
+			}
+			if _, ok := c["flex"]; !ok && slices.Contains(flexTypes, fmt.Sprint(c["type"])) {
+				c["flex"] = json.Number("1")
+			}
+		}
+	}
+}
+
+// flexJSONTypeName returns the type property of t, or "" if t has none.
+func flexJSONTypeName(t reflect.Type) string {
+	for _, implementations := range flexJSONTypes {
+		for name, impl := range implementations {
+			if impl == t {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// flexJSONFields returns the types of the JSON properties of the struct t.
+func flexJSONFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			for k, v := range flexJSONFields(f.Type) {
+				fields[k] = v
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// MarshalFlexContainerJSON returns the indented JSON of a bubble or a carousel,
+// without the properties that have their default values, with the keys of
+// objects sorted. Marshaling the result of UnmarshalFlexContainerJSON gives
+// the same JSON every time, so containers can be kept in files and diffed.
+//
+// The flex property is kept when it differs from the default of the parent
+// box layout: 1 in horizontal and baseline boxes, and 0 elsewhere.
+func MarshalFlexContainerJSON(container FlexContainerInterface) ([]byte, error) {
+	container = setDiscriminatorPropertyFlexContainer(container)
+	b, err := json.Marshal(container)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var tree any
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+	tree, _ = omitFlexDefaults(tree, "")
+	return json.MarshalIndent(tree, "", "  ")
+}
+
+// omitFlexDefaults removes the properties with default values from v, the
+// JSON of an element of a box of parentLayout. It reports whether v itself
+// is a default value.
+func omitFlexDefaults(v any, parentLayout string) (any, bool) {
+	switch t := v.(type) {
+	case nil:
+		return nil, true
+	case bool:
+		return t, !t
+	case string:
+		return t, t == ""
+	case json.Number:
+		f, err := t.Float64()
+		return t, err == nil && f == 0
+	case []any:
+		for i, e := range t {
+			t[i], _ = omitFlexDefaults(e, parentLayout)
+		}
+		return t, len(t) == 0
+	case map[string]any:
+		layout, _ := t["layout"].(string)
+		for key, value := range t {
+			childLayout := ""
+			if key == "contents" {
+				childLayout = layout
+			}
+			value, isDefault := omitFlexDefaults(value, childLayout)
+			t[key] = value
+			switch {
+			case key == "flex":
+				defaultFlex := "0"
+				if parentLayout == string(FlexBoxLAYOUT_HORIZONTAL) || parentLayout == string(FlexBoxLAYOUT_BASELINE) {
+					defaultFlex = "1"
+				}
+				if value.(json.Number).String() == defaultFlex {
+					delete(t, key)
+				}
+			case key == "contents":
+				// Boxes and carousels require contents, even if empty.
+			case isDefault:
+				delete(t, key)
+			}
+		}
+		return t, len(t) == 0
+	}
+	return v, false
+}
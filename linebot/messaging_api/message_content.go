@@ -0,0 +1,51 @@
+package messaging_api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// GetMessageContentRangeCtx
+// The same as GetMessageContentCtx, but requests the content from the byte
+// offset with a Range header, to resume a download that was cut off. The
+// response is 206 if the server honored the range, or 200 with the whole
+// content otherwise. While a video or an audio is being prepared, the
+// response is 202 with no content.
+//
+// You must close the response body when finished with it.
+func (client *MessagingApiBlobAPI) GetMessageContentRangeCtx(
+	ctx context.Context,
+	messageId string,
+	offset int64,
+) (*http.Response, error) {
+	path := "/v2/bot/message/" + url.PathEscape(messageId) + "/content"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
+	if err != nil {
+		return nil, err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	}
+
+	res, err := client.do(req)
+	if err != nil {
+		return res, err
+	}
+
+	if res.StatusCode/100 != 2 {
+		bodyBytes, err := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+		if err != nil {
+			return res, fmt.Errorf("failed to read response body: %w", err)
+		}
+		res.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+		return res, newAPIError(res, bodyBytes)
+	}
+	return res, nil
+}
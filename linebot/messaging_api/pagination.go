@@ -0,0 +1,74 @@
+package messaging_api
+
+import (
+	"context"
+	"iter"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/pagination"
+)
+
+// FollowerIDs returns an iterator over the user IDs of the friends of the LINE Official Account.
+// See GetFollowers. The default page size is 300 and the maximum is 1000.
+func (client *MessagingApiAPI) FollowerIDs(ctx context.Context, options ...pagination.Option) iter.Seq2[string, error] {
+	config := pagination.NewConfig(options...)
+	limit := int32(config.PageSizeOr(300))
+	return pagination.Seq(ctx, config, "", func(ctx context.Context, start string) (pagination.Page[string, string], error) {
+		resp, err := client.GetFollowersCtx(ctx, start, limit)
+		if err != nil {
+			return pagination.Page[string, string]{}, err
+		}
+		return pagination.Page[string, string]{Items: resp.UserIds, Next: resp.Next, HasNext: resp.Next != ""}, nil
+	})
+}
+
+// GroupMemberIDs returns an iterator over the user IDs of the members of a group chat.
+// See GetGroupMembersIds. The page size cannot be set.
+func (client *MessagingApiAPI) GroupMemberIDs(ctx context.Context, groupId string, options ...pagination.Option) iter.Seq2[string, error] {
+	return pagination.Seq(ctx, pagination.NewConfig(options...), "", func(ctx context.Context, start string) (pagination.Page[string, string], error) {
+		resp, err := client.GetGroupMembersIdsCtx(ctx, groupId, start)
+		if err != nil {
+			return pagination.Page[string, string]{}, err
+		}
+		return pagination.Page[string, string]{Items: resp.MemberIds, Next: resp.Next, HasNext: resp.Next != ""}, nil
+	})
+}
+
+// RoomMemberIDs returns an iterator over the user IDs of the members of a multi-person chat.
+// See GetRoomMembersIds. The page size cannot be set.
+func (client *MessagingApiAPI) RoomMemberIDs(ctx context.Context, roomId string, options ...pagination.Option) iter.Seq2[string, error] {
+	return pagination.Seq(ctx, pagination.NewConfig(options...), "", func(ctx context.Context, start string) (pagination.Page[string, string], error) {
+		resp, err := client.GetRoomMembersIdsCtx(ctx, roomId, start)
+		if err != nil {
+			return pagination.Page[string, string]{}, err
+		}
+		return pagination.Page[string, string]{Items: resp.MemberIds, Next: resp.Next, HasNext: resp.Next != ""}, nil
+	})
+}
+
+// JoinedMembershipUserIDs returns an iterator over the user IDs of the users who joined a membership plan.
+// See GetJoinedMembershipUsers. The default page size is 300 and the maximum is 1000.
+func (client *MessagingApiAPI) JoinedMembershipUserIDs(ctx context.Context, membershipId int32, options ...pagination.Option) iter.Seq2[string, error] {
+	config := pagination.NewConfig(options...)
+	limit := int32(config.PageSizeOr(300))
+	return pagination.Seq(ctx, config, "", func(ctx context.Context, start string) (pagination.Page[string, string], error) {
+		resp, err := client.GetJoinedMembershipUsersCtx(ctx, membershipId, start, limit)
+		if err != nil {
+			return pagination.Page[string, string]{}, err
+		}
+		return pagination.Page[string, string]{Items: resp.UserIds, Next: resp.Next, HasNext: resp.Next != ""}, nil
+	})
+}
+
+// Coupons returns an iterator over the coupons with one of the statuses, or all of them if status is empty.
+// See ListCoupon. The default page size is 20 and the maximum is 100.
+func (client *MessagingApiAPI) Coupons(ctx context.Context, status []string, options ...pagination.Option) iter.Seq2[CouponListResponse, error] {
+	config := pagination.NewConfig(options...)
+	limit := int32(config.PageSizeOr(20))
+	return pagination.Seq(ctx, config, "", func(ctx context.Context, start string) (pagination.Page[CouponListResponse, string], error) {
+		resp, err := client.ListCouponCtx(ctx, &status, start, limit)
+		if err != nil {
+			return pagination.Page[CouponListResponse, string]{}, err
+		}
+		return pagination.Page[CouponListResponse, string]{Items: resp.Items, Next: resp.Next, HasNext: resp.Next != ""}, nil
+	})
+}
@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+func TestCtxMethodsAreIndependent(t *testing.T) {
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("start") == "slow" {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(5 * time.Second):
+				}
+				return
+			}
+			w.Write([]byte(`{"userIds":["U1"]}`))
+		}),
+	)
+	defer server.Close()
+	client, err := messaging_api.NewMessagingApiAPI(
+		"channelToken",
+		messaging_api.WithEndpoint(server.URL),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := client.GetFollowersCtx(ctx, "slow", 0)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected context.DeadlineExceeded, but got %v", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		resp, err := client.GetFollowersCtx(context.Background(), "", 0)
+		if err != nil {
+			t.Errorf("Failed to get followers: %v", err)
+			return
+		}
+		if len(resp.UserIds) != 1 || resp.UserIds[0] != "U1" {
+			t.Errorf("UserIds: %v", resp.UserIds)
+		}
+	}()
+	wg.Wait()
+}
+
+func TestCtxMethodOverridesWithContext(t *testing.T) {
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{}`))
+		}),
+	)
+	defer server.Close()
+	client, err := messaging_api.NewMessagingApiAPI(
+		"channelToken",
+		messaging_api.WithEndpoint(server.URL),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	client.WithContext(canceled)
+
+	if _, err := client.GetBotInfo(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, but got %v", err)
+	}
+	if _, err := client.GetBotInfoCtx(context.Background()); err != nil {
+		t.Errorf("GetBotInfoCtx should not use the client context: %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+	"github.com/line/line-bot-sdk-go/v8/linebot/retry"
+)
+
+func TestPushMessageWithRetryPolicy(t *testing.T) {
+	var retryKeys []string
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			retryKeys = append(retryKeys, r.Header.Get("X-Line-Retry-Key"))
+			if r.Header.Get("Authorization") != "Bearer channelToken" {
+				t.Errorf("Authorization: %s", r.Header.Get("Authorization"))
+			}
+			if len(retryKeys) == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"message":"An error occurred in the server."}`))
+				return
+			}
+			w.Write([]byte(`{"sentMessages":[{"id":"461230966842064897","quoteToken":"IStG5h1Tz7b..."}]}`))
+		}),
+	)
+	defer server.Close()
+	client, err := messaging_api.NewMessagingApiAPI(
+		"channelToken",
+		messaging_api.WithEndpoint(server.URL),
+		messaging_api.WithRetryPolicy(&retry.Policy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			Multiplier:     2,
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	resp, err := client.PushMessage(&messaging_api.PushMessageRequest{
+		To: "U1234567890",
+		Messages: []messaging_api.MessageInterface{
+			&messaging_api.TextMessage{
+				Text: "Hello, world",
+			},
+		},
+	}, "")
+	if err != nil {
+		t.Fatalf("Failed to push message: %v", err)
+	}
+	if len(resp.SentMessages) != 1 {
+		t.Errorf("SentMessages: %v", resp.SentMessages)
+	}
+	if len(retryKeys) != 2 || retryKeys[0] == "" || retryKeys[0] != retryKeys[1] {
+		t.Errorf("Retry keys: %v", retryKeys)
+	}
+}
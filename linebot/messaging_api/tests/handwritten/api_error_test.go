@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+func TestAPIError(t *testing.T) {
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Line-Request-Id", "1234567890")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"message":"You have reached your monthly limit.","details":[{"message":"must be specified","property":"to"}]}`))
+		}),
+	)
+	defer server.Close()
+	client, err := messaging_api.NewMessagingApiAPI(
+		"channelToken",
+		messaging_api.WithEndpoint(server.URL),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	resp, _, err := client.PushMessageWithHttpInfo(&messaging_api.PushMessageRequest{
+		To: "U1234567890",
+		Messages: []messaging_api.MessageInterface{
+			&messaging_api.TextMessage{
+				Text: "Hello, world",
+			},
+		},
+	}, "")
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("status: %d", resp.StatusCode)
+	}
+
+	var apiError *messaging_api.APIError
+	if !errors.As(err, &apiError) {
+		t.Fatalf("Expected *APIError, but got %T", err)
+	}
+	if apiError.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("StatusCode: %d", apiError.StatusCode)
+	}
+	if apiError.RequestID != "1234567890" {
+		t.Errorf("RequestID: %s", apiError.RequestID)
+	}
+	if apiError.Response == nil || apiError.Response.Message != "You have reached your monthly limit." {
+		t.Fatalf("Response: %v", apiError.Response)
+	}
+	if len(apiError.Response.Details) != 1 || apiError.Response.Details[0].Property != "to" {
+		t.Errorf("Details: %v", apiError.Response.Details)
+	}
+	if !messaging_api.IsRateLimited(err) {
+		t.Errorf("IsRateLimited should be true")
+	}
+	if messaging_api.IsNotFound(err) || messaging_api.IsConflict(err) {
+		t.Errorf("IsNotFound and IsConflict should be false")
+	}
+	if messaging_api.IsRateLimited(errors.New("unexpected status code: 429")) {
+		t.Errorf("IsRateLimited should be false for a non-APIError")
+	}
+}
+
+func TestAPIErrorWithInvalidBody(t *testing.T) {
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`not found`))
+		}),
+	)
+	defer server.Close()
+	client, err := messaging_api.NewMessagingApiAPI(
+		"channelToken",
+		messaging_api.WithEndpoint(server.URL),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	_, err = client.GetProfile("U1234567890")
+
+	var apiError *messaging_api.APIError
+	if !errors.As(err, &apiError) {
+		t.Fatalf("Expected *APIError, but got %T", err)
+	}
+	if apiError.Response != nil {
+		t.Errorf("Response should be nil: %v", apiError.Response)
+	}
+	if string(apiError.Body) != "not found" {
+		t.Errorf("Body: %s", apiError.Body)
+	}
+	if err.Error() != "unexpected status code: 404, not found" {
+		t.Errorf("Error: %s", err.Error())
+	}
+	if !messaging_api.IsNotFound(err) {
+		t.Errorf("IsNotFound should be true")
+	}
+}
@@ -0,0 +1,208 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+const simulatorBubble = `{
+  "type": "bubble",
+  "hero": {
+    "type": "image",
+    "url": "https://example.com/hero.png",
+    "size": "full",
+    "aspectRatio": "20:13",
+    "aspectMode": "cover",
+    "action": {"type": "uri", "uri": "https://example.com"}
+  },
+  "body": {
+    "type": "box",
+    "layout": "vertical",
+    "contents": [
+      {"type": "text", "text": "Brown Cafe", "weight": "bold", "size": "xl"},
+      {
+        "type": "box",
+        "layout": "baseline",
+        "margin": "md",
+        "contents": [
+          {"type": "icon", "size": "sm", "url": "https://example.com/star.png"},
+          {"type": "text", "text": "4.0", "size": "sm", "color": "#999999", "margin": "md", "flex": 0}
+        ]
+      },
+      {
+        "type": "box",
+        "layout": "horizontal",
+        "contents": [
+          {"type": "text", "text": "Place"},
+          {"type": "text", "text": "Shinjuku", "wrap": true, "flex": 5}
+        ]
+      }
+    ]
+  },
+  "footer": {
+    "type": "box",
+    "layout": "vertical",
+    "spacing": "sm",
+    "contents": [
+      {"type": "button", "style": "link", "height": "sm", "action": {"type": "uri", "label": "CALL", "uri": "https://example.com"}},
+      {"type": "spacer", "size": "sm"}
+    ],
+    "flex": 0
+  }
+}`
+
+func TestUnmarshalFlexContainerJSON(t *testing.T) {
+	// Spacers are deprecated and not part of the SDK.
+	data := strings.Replace(simulatorBubble, `,
+      {"type": "spacer", "size": "sm"}`, "", 1)
+	container, err := messaging_api.UnmarshalFlexContainerJSON([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bubble, ok := container.(messaging_api.FlexBubble)
+	if !ok {
+		t.Fatalf("got %T, want FlexBubble", container)
+	}
+	if hero, ok := bubble.Hero.(messaging_api.FlexImage); !ok || hero.AspectMode != messaging_api.FlexImageASPECT_MODE_COVER {
+		t.Errorf("unexpected hero: %+v", bubble.Hero)
+	}
+	baseline := bubble.Body.Contents[1].(messaging_api.FlexBox)
+	if rating := baseline.Contents[1].(messaging_api.FlexText); rating.Flex != 0 {
+		t.Errorf("explicit flex 0 should be kept, got %d", rating.Flex)
+	}
+	row := bubble.Body.Contents[2].(messaging_api.FlexBox)
+	if label := row.Contents[0].(messaging_api.FlexText); label.Flex != 1 {
+		t.Errorf("texts in horizontal boxes should default to flex 1, got %d", label.Flex)
+	}
+	if title := bubble.Body.Contents[0].(messaging_api.FlexText); title.Flex != 0 {
+		t.Errorf("texts in vertical boxes should default to flex 0, got %d", title.Flex)
+	}
+}
+
+func TestUnmarshalFlexContainerJSONErrors(t *testing.T) {
+	_, err := messaging_api.UnmarshalFlexContainerJSON([]byte(simulatorBubble))
+	if err == nil || err.Error() != `footer.contents[1]: unknown type "spacer"` {
+		t.Errorf("got %v", err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		data string
+		want []string
+	}{
+		{
+			name: "unknown action",
+			data: `{"type":"bubble","body":{"type":"box","layout":"vertical","contents":[
+				{"type":"text","text":"a"},{"type":"text","text":"b"},
+				{"type":"button","action":{"type":"foo"}}]}}`,
+			want: []string{`body.contents[2].action: unknown type "foo"`},
+		},
+		{
+			name: "wrong types",
+			data: `{"type":"bubble","body":{"type":"box","layout":"vertical","contents":[
+				{"type":"text","text":1,"wrap":"yes","flex":1.5}]}}`,
+			want: []string{
+				"body.contents[0].flex: must be an integer, got 1.5",
+				"body.contents[0].text: must be a string",
+				"body.contents[0].wrap: must be a boolean",
+			},
+		},
+		{
+			name: "unknown property",
+			data: `{"type":"carousel","contents":[{"type":"bubble","hedaer":{}}]}`,
+			want: []string{"contents[0].hedaer: unknown property for bubble"},
+		},
+		{
+			name: "missing type",
+			data: `{"type":"bubble","body":{"type":"box","layout":"vertical","contents":[{"text":"a"}]}}`,
+			want: []string{"body.contents[0]: missing type"},
+		},
+		{
+			name: "text as body",
+			data: `{"type":"bubble","body":{"type":"text","text":"a"}}`,
+			want: []string{"body: must be box, got text"},
+		},
+		{
+			name: "unknown container",
+			data: `{"type":"bubbles"}`,
+			want: []string{`unknown type "bubbles"`},
+		},
+		{
+			name: "invalid JSON",
+			data: `{"type":`,
+			want: []string{"invalid JSON: unexpected EOF"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := messaging_api.UnmarshalFlexContainerJSON([]byte(tc.data))
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if got := strings.Split(err.Error(), "\n"); strings.Join(got, "|") != strings.Join(tc.want, "|") {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+			var jsonErr *messaging_api.FlexJSONError
+			if !errors.As(err, &jsonErr) {
+				t.Errorf("got %T, want FlexJSONError", err)
+			}
+		})
+	}
+}
+
+func TestMarshalFlexContainerJSON(t *testing.T) {
+	data := strings.Replace(simulatorBubble, `,
+      {"type": "spacer", "size": "sm"}`, "", 1)
+	container, err := messaging_api.UnmarshalFlexContainerJSON([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := messaging_api.MarshalFlexContainerJSON(container)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, unwanted := range []string{`"wrap": false`, `"scaling"`, `"flex": 1`, `: ""`} {
+		if strings.Contains(string(first), unwanted) {
+			t.Errorf("%s should not contain %s", first, unwanted)
+		}
+	}
+	for _, wanted := range []string{`"flex": 0`, `"flex": 5`, `"wrap": true`, `"contents": [`} {
+		if !strings.Contains(string(first), wanted) {
+			t.Errorf("%s should contain %s", first, wanted)
+		}
+	}
+
+	container, err = messaging_api.UnmarshalFlexContainerJSON(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := messaging_api.MarshalFlexContainerJSON(container)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(first) != string(second) {
+		t.Errorf("marshaling is not stable:\n%s\n%s", first, second)
+	}
+}
+
+func TestMarshalFlexContainerJSONEmptyBox(t *testing.T) {
+	b, err := messaging_api.MarshalFlexContainerJSON(&messaging_api.FlexBubble{
+		Body: &messaging_api.FlexBox{Layout: messaging_api.FlexBoxLAYOUT_VERTICAL},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "body": {
+    "contents": [],
+    "layout": "vertical",
+    "type": "box"
+  },
+  "type": "bubble"
+}`
+	if string(b) != want {
+		t.Errorf("got %s, want %s", b, want)
+	}
+}
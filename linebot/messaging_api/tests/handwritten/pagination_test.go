@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+	"github.com/line/line-bot-sdk-go/v8/linebot/pagination"
+)
+
+func TestFollowerIDs(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			if r.URL.Query().Get("limit") != "2" {
+				t.Errorf("limit = %q; want 2", r.URL.Query().Get("limit"))
+			}
+			switch r.URL.Query().Get("start") {
+			case "":
+				w.Write([]byte(`{"userIds":["U1","U2"],"next":"p2"}`))
+			case "p2":
+				w.Write([]byte(`{"userIds":["U3","U4"],"next":"p3"}`))
+			case "p3":
+				w.Write([]byte(`{"userIds":["U5"]}`))
+			default:
+				t.Errorf("unexpected start: %q", r.URL.Query().Get("start"))
+			}
+		}),
+	)
+	defer server.Close()
+	client, err := messaging_api.NewMessagingApiAPI(
+		"channelToken",
+		messaging_api.WithEndpoint(server.URL),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	var ids []string
+	for id, err := range client.FollowerIDs(context.Background(), pagination.WithPageSize(2)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	if strings.Join(ids, ",") != "U1,U2,U3,U4,U5" {
+		t.Errorf("got %v", ids)
+	}
+	if requests.Load() != 3 {
+		t.Errorf("got %d requests, want 3", requests.Load())
+	}
+
+	// Stopping on the first page fetches nothing more.
+	requests.Store(0)
+	for id, err := range client.FollowerIDs(context.Background(), pagination.WithPageSize(2)) {
+		if err != nil || id != "U1" {
+			t.Fatalf("got %q, %v", id, err)
+		}
+		break
+	}
+	if requests.Load() != 1 {
+		t.Errorf("got %d requests, want 1", requests.Load())
+	}
+}
+
+func TestCouponsError(t *testing.T) {
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"message":"error"}`))
+		}),
+	)
+	defer server.Close()
+	client, err := messaging_api.NewMessagingApiAPI(
+		"channelToken",
+		messaging_api.WithEndpoint(server.URL),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	n := 0
+	for _, err := range client.Coupons(context.Background(), nil) {
+		n++
+		var apiErr *messaging_api.APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+			t.Errorf("got %v, want an APIError with status 500", err)
+		}
+	}
+	if n != 1 {
+		t.Errorf("got %d items, want 1 error", n)
+	}
+}
@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+	"github.com/line/line-bot-sdk-go/v8/linebot/validation"
+)
+
+func TestTextV2Builder(t *testing.T) {
+	msg, err := messaging_api.NewTextV2().
+		Text("Hi ").Mention("U1").
+		Text(" and ").Mention("U2").
+		Text(", {not a placeholder} ").Emoji("5ac1bfd5040ab15980c9b435", "002").
+		Text(" ").MentionAll().
+		Text(" ").Mention("U1").Emoji("5ac1bfd5040ab15980c9b435", "002").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Hi {user1} and {user2}, {{not a placeholder}} {emoji1} {everyone} {user1}{emoji1}"
+	if msg.Text != want {
+		t.Errorf("got %q, want %q", msg.Text, want)
+	}
+	if len(msg.Substitution) != 4 {
+		t.Fatalf("got %d substitutions, want 4", len(msg.Substitution))
+	}
+	if m := msg.Substitution["user2"].(*messaging_api.MentionSubstitutionObject); m.Mentionee.(*messaging_api.UserMentionTarget).UserId != "U2" {
+		t.Errorf("unexpected mention: %+v", m.Mentionee)
+	}
+	if _, ok := msg.Substitution["everyone"].(*messaging_api.MentionSubstitutionObject).Mentionee.(*messaging_api.AllMentionTarget); !ok {
+		t.Errorf("unexpected mention: %+v", msg.Substitution["everyone"])
+	}
+
+	b, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"type":"textV2"`, `"emoji1":{"productId":"5ac1bfd5040ab15980c9b435","emojiId":"002","type":"emoji"}`, `"mentionee":{"type":"all"}`} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("%s does not contain %s", b, want)
+		}
+	}
+}
+
+func TestTextV2BuilderErrors(t *testing.T) {
+	if _, err := messaging_api.NewTextV2().Text("Hi ").Mention("").Build(); err == nil || !strings.Contains(err.Error(), "user ID") {
+		t.Errorf("got %v", err)
+	}
+	if _, err := messaging_api.NewTextV2().Emoji("p", "").Build(); err == nil || !strings.Contains(err.Error(), "emoji ID") {
+		t.Errorf("got %v", err)
+	}
+
+	// The limits are checked by the validation package, on the escaped text.
+	msg, err := messaging_api.NewTextV2().Text(strings.Repeat("{", 2501)).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := validation.Message(msg); err == nil || !strings.Contains(err.Error(), "got 5002") {
+		t.Errorf("escaped braces should count towards the limit, got %v", err)
+	}
+}
+
+func TestTextV2Placeholders(t *testing.T) {
+	keys, err := messaging_api.TextV2Placeholders("{a}{{b}} {c}}}")
+	if err != nil || strings.Join(keys, ",") != "a,c" {
+		t.Errorf("got %v, %v", keys, err)
+	}
+	for _, text := range []string{"{a", "a}", "{a{b}}"} {
+		if _, err := messaging_api.TextV2Placeholders(text); err == nil {
+			t.Errorf("%q should be invalid", text)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package messaging_api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// TextV2Builder builds a TextMessageV2 from runs of text, mentions and emojis,
+// keeping the {key} placeholders of the text and the substitutions in sync.
+//
+//	msg, err := messaging_api.NewTextV2().
+//		Text("Hi ").Mention(userId).Text("! ").Emoji("5ac1bfd5040ab15980c9b435", "002").
+//		Build()
+type TextV2Builder struct {
+	text         strings.Builder
+	substitution map[string]SubstitutionObjectInterface
+	// keys holds the keys of the mentions and emojis already added, so that they are reused.
+	keys     map[string]string
+	mentions int
+	emojis   int
+	err      error
+}
+
+// NewTextV2 function
+func NewTextV2() *TextV2Builder {
+	return &TextV2Builder{
+		substitution: map[string]SubstitutionObjectInterface{},
+		keys:         map[string]string{},
+	}
+}
+
+// Text method
+// Appends plain text. Braces are escaped, so they are displayed as is.
+func (b *TextV2Builder) Text(text string) *TextV2Builder {
+	b.text.WriteString(strings.NewReplacer("{", "{{", "}", "}}").Replace(text))
+	return b
+}
+
+// Mention method
+// Appends a mention of the user.
+func (b *TextV2Builder) Mention(userId string) *TextV2Builder {
+	if userId == "" {
+		b.fail(errors.New("the user ID of a mention must not be empty"))
+		return b
+	}
+	return b.placeholder("user:"+userId, func() string {
+		b.mentions++
+		return fmt.Sprintf("user%d", b.mentions)
+	}, &MentionSubstitutionObject{Mentionee: &UserMentionTarget{UserId: userId}})
+}
+
+// MentionAll method
+// Appends a mention of all the members of the group chat or multi-person chat.
+func (b *TextV2Builder) MentionAll() *TextV2Builder {
+	return b.placeholder("all", func() string {
+		return "everyone"
+	}, &MentionSubstitutionObject{Mentionee: &AllMentionTarget{}})
+}
+
+// Emoji method
+// Appends a LINE emoji.
+func (b *TextV2Builder) Emoji(productId, emojiId string) *TextV2Builder {
+	if productId == "" || emojiId == "" {
+		b.fail(errors.New("the product ID and the emoji ID of an emoji must not be empty"))
+		return b
+	}
+	return b.placeholder("emoji:"+productId+"/"+emojiId, func() string {
+		b.emojis++
+		return fmt.Sprintf("emoji%d", b.emojis)
+	}, &EmojiSubstitutionObject{ProductId: productId, EmojiId: emojiId})
+}
+
+// placeholder appends the placeholder of the substitution identified by id,
+// which is added with a new key the first time.
+func (b *TextV2Builder) placeholder(id string, newKey func() string, s SubstitutionObjectInterface) *TextV2Builder {
+	key, ok := b.keys[id]
+	if !ok {
+		key = newKey()
+		b.keys[id] = key
+		b.substitution[key] = s
+	}
+	b.text.WriteString("{" + key + "}")
+	return b
+}
+
+func (b *TextV2Builder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// Build method
+// Returns the message, or an error if an argument was invalid. The limits of
+// the LINE Platform are not checked: use the validation package for them.
+func (b *TextV2Builder) Build() (*TextMessageV2, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	msg := &TextMessageV2{Text: b.text.String()}
+	if len(b.substitution) > 0 {
+		msg.Substitution = make(map[string]SubstitutionObjectInterface, len(b.substitution))
+		for key, s := range b.substitution {
+			msg.Substitution[key] = s
+		}
+	}
+	return msg, nil
+}
+
+// TextV2Placeholders returns the keys of the {key} placeholders of the text of
+// a TextMessageV2, in order. {{ and }} are literal braces.
+func TextV2Placeholders(text string) ([]string, error) {
+	var keys []string
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '{':
+			if i+1 < len(text) && text[i+1] == '{' {
+				i++
+				continue
+			}
+			end := i + 1
+			for end < len(text) && text[end] != '}' && text[end] != '{' {
+				end++
+			}
+			if end == len(text) || text[end] != '}' {
+				return keys, errors.New("unterminated {, use {{ for a literal brace")
+			}
+			keys = append(keys, text[i+1:end])
+			i = end
+		case '}':
+			if i+1 < len(text) && text[i+1] == '}' {
+				i++
+				continue
+			}
+			return keys, errors.New("unmatched }, use }} for a literal brace")
+		}
+	}
+	return keys, nil
+}
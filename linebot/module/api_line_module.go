@@ -34,24 +34,27 @@ import (
 	"strings"
 
 	"github.com/line/line-bot-sdk-go/v8/linebot"
+	"github.com/line/line-bot-sdk-go/v8/linebot/channel_access_token"
+	"github.com/line/line-bot-sdk-go/v8/linebot/ratelimit"
+	"github.com/line/line-bot-sdk-go/v8/linebot/retry"
 )
 
 type LineModuleAPI struct {
 	httpClient   *http.Client
 	endpoint     *url.URL
 	channelToken string
+	tokenSource  channel_access_token.TokenSource
 	ctx          context.Context
+	retryPolicy  *retry.Policy
+	rateLimiter  *ratelimit.Limiter
 }
 
 // LineModuleAPIOption type
 type LineModuleAPIOption func(*LineModuleAPI) error
 
 // New returns a new bot client instance.
+// channelToken may be empty if a TokenSource is given with WithTokenSource.
 func NewLineModuleAPI(channelToken string, options ...LineModuleAPIOption) (*LineModuleAPI, error) {
-	if channelToken == "" {
-		return nil, errors.New("missing channel access token")
-	}
-
 	c := &LineModuleAPI{
 		channelToken: channelToken,
 		httpClient:   http.DefaultClient,
@@ -69,24 +72,61 @@ func NewLineModuleAPI(channelToken string, options ...LineModuleAPIOption) (*Lin
 			return nil, err
 		}
 	}
+	if c.channelToken == "" && c.tokenSource == nil {
+		return nil, errors.New("missing channel access token")
+	}
 	return c, nil
 }
 
 // WithContext method
+// The context is stored on the client and shared by every call made through it.
+// To use a different context for each call, use the methods with the Ctx suffix instead.
 func (call *LineModuleAPI) WithContext(ctx context.Context) *LineModuleAPI {
 	call.ctx = ctx
 	return call
 }
 
 func (client *LineModuleAPI) Do(req *http.Request) (*http.Response, error) {
-	if client.channelToken != "" {
+	if client.ctx != nil {
+		req = req.WithContext(client.ctx)
+	}
+	return client.do(req)
+}
+
+// do sends req with the context it already carries.
+func (client *LineModuleAPI) do(req *http.Request) (*http.Response, error) {
+	if client.retryPolicy != nil {
+		return client.retryPolicy.Do(req, client.send)
+	}
+	return client.send(req)
+}
+
+// send sends a single attempt of req.
+func (client *LineModuleAPI) send(req *http.Request) (*http.Response, error) {
+	if client.rateLimiter != nil {
+		if err := client.rateLimiter.Wait(req); err != nil {
+			return nil, err
+		}
+	}
+	if client.tokenSource != nil {
+		token, err := client.tokenSource.Token(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else if client.channelToken != "" {
 		req.Header.Set("Authorization", "Bearer "+client.channelToken)
 	}
 	req.Header.Set("User-Agent", "LINE-BotSDK-Go/"+linebot.GetVersion())
+	return client.httpClient.Do(req)
+}
+
+// requestContext returns the context set by WithContext, or context.Background if there is none.
+func (client *LineModuleAPI) requestContext() context.Context {
 	if client.ctx != nil {
-		req = req.WithContext(client.ctx)
+		return client.ctx
 	}
-	return client.httpClient.Do(req)
+	return context.Background()
 }
 
 func (client *LineModuleAPI) Url(endpointPath string) string {
@@ -104,6 +144,34 @@ func WithHTTPClient(c *http.Client) LineModuleAPIOption {
 	}
 }
 
+// WithTokenSource function
+// Every request uses a token obtained from ts instead of the channel access token given to NewLineModuleAPI.
+func WithTokenSource(ts channel_access_token.TokenSource) LineModuleAPIOption {
+	return func(client *LineModuleAPI) error {
+		client.tokenSource = ts
+		return nil
+	}
+}
+
+// WithRetryPolicy function
+// Failed requests are retried according to policy. See the retry package for which requests are retried.
+func WithRetryPolicy(policy *retry.Policy) LineModuleAPIOption {
+	return func(client *LineModuleAPI) error {
+		client.retryPolicy = policy
+		return nil
+	}
+}
+
+// WithRateLimiter function
+// Every request, including retries, waits for limiter before it is sent.
+// Share one limiter between the clients of the same channel.
+func WithRateLimiter(limiter *ratelimit.Limiter) LineModuleAPIOption {
+	return func(client *LineModuleAPI) error {
+		client.rateLimiter = limiter
+		return nil
+	}
+}
+
 // WithEndpoint function
 func WithEndpoint(endpoint string) LineModuleAPIOption {
 	return func(client *LineModuleAPI) error {
@@ -140,6 +208,24 @@ func (client *LineModuleAPI) AcquireChatControl(
 	return body, error
 }
 
+// AcquireChatControlCtx
+// The same as AcquireChatControl, but the request uses ctx instead of the context set by WithContext.
+func (client *LineModuleAPI) AcquireChatControlCtx(
+	ctx context.Context,
+	chatId string,
+
+	acquireChatControlRequest *AcquireChatControlRequest,
+
+) (struct{}, error) {
+	_, body, error := client.AcquireChatControlWithHttpInfoCtx(
+		ctx,
+		chatId,
+
+		acquireChatControlRequest,
+	)
+	return body, error
+}
+
 // AcquireChatControl
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -155,6 +241,23 @@ func (client *LineModuleAPI) AcquireChatControlWithHttpInfo(
 
 	acquireChatControlRequest *AcquireChatControlRequest,
 
+) (*http.Response, struct{}, error) {
+	return client.AcquireChatControlWithHttpInfoCtx(
+		client.requestContext(),
+		chatId,
+
+		acquireChatControlRequest,
+	)
+}
+
+// AcquireChatControlWithHttpInfoCtx
+// The same as AcquireChatControlWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *LineModuleAPI) AcquireChatControlWithHttpInfoCtx(
+	ctx context.Context,
+	chatId string,
+
+	acquireChatControlRequest *AcquireChatControlRequest,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/chat/{chatId}/control/acquire"
 
@@ -165,13 +268,13 @@ func (client *LineModuleAPI) AcquireChatControlWithHttpInfo(
 	if err := enc.Encode(acquireChatControlRequest); err != nil {
 		return nil, struct{}{}, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -184,7 +287,7 @@ func (client *LineModuleAPI) AcquireChatControlWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -212,6 +315,20 @@ func (client *LineModuleAPI) DetachModule(
 	return body, error
 }
 
+// DetachModuleCtx
+// The same as DetachModule, but the request uses ctx instead of the context set by WithContext.
+func (client *LineModuleAPI) DetachModuleCtx(
+	ctx context.Context,
+	detachModuleRequest *DetachModuleRequest,
+
+) (struct{}, error) {
+	_, body, error := client.DetachModuleWithHttpInfoCtx(
+		ctx,
+		detachModuleRequest,
+	)
+	return body, error
+}
+
 // DetachModule
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -224,6 +341,19 @@ func (client *LineModuleAPI) DetachModuleWithHttpInfo(
 
 	detachModuleRequest *DetachModuleRequest,
 
+) (*http.Response, struct{}, error) {
+	return client.DetachModuleWithHttpInfoCtx(
+		client.requestContext(),
+		detachModuleRequest,
+	)
+}
+
+// DetachModuleWithHttpInfoCtx
+// The same as DetachModuleWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *LineModuleAPI) DetachModuleWithHttpInfoCtx(
+	ctx context.Context,
+	detachModuleRequest *DetachModuleRequest,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/channel/detach"
 
@@ -232,13 +362,13 @@ func (client *LineModuleAPI) DetachModuleWithHttpInfo(
 	if err := enc.Encode(detachModuleRequest); err != nil {
 		return nil, struct{}{}, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -251,7 +381,7 @@ func (client *LineModuleAPI) DetachModuleWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -284,6 +414,24 @@ func (client *LineModuleAPI) GetModules(
 	return body, error
 }
 
+// GetModulesCtx
+// The same as GetModules, but the request uses ctx instead of the context set by WithContext.
+func (client *LineModuleAPI) GetModulesCtx(
+	ctx context.Context,
+	start string,
+
+	limit int32,
+
+) (*GetModulesResponse, error) {
+	_, body, error := client.GetModulesWithHttpInfoCtx(
+		ctx,
+		start,
+
+		limit,
+	)
+	return body, error
+}
+
 // GetModules
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -299,10 +447,27 @@ func (client *LineModuleAPI) GetModulesWithHttpInfo(
 
 	limit int32,
 
+) (*http.Response, *GetModulesResponse, error) {
+	return client.GetModulesWithHttpInfoCtx(
+		client.requestContext(),
+		start,
+
+		limit,
+	)
+}
+
+// GetModulesWithHttpInfoCtx
+// The same as GetModulesWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *LineModuleAPI) GetModulesWithHttpInfoCtx(
+	ctx context.Context,
+	start string,
+
+	limit int32,
+
 ) (*http.Response, *GetModulesResponse, error) {
 	path := "/v2/bot/list"
 
-	req, err := http.NewRequest(http.MethodGet, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.Url(path), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -315,7 +480,7 @@ func (client *LineModuleAPI) GetModulesWithHttpInfo(
 
 	req.URL.RawQuery = query.Encode()
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -328,7 +493,7 @@ func (client *LineModuleAPI) GetModulesWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -361,6 +526,20 @@ func (client *LineModuleAPI) ReleaseChatControl(
 	return body, error
 }
 
+// ReleaseChatControlCtx
+// The same as ReleaseChatControl, but the request uses ctx instead of the context set by WithContext.
+func (client *LineModuleAPI) ReleaseChatControlCtx(
+	ctx context.Context,
+	chatId string,
+
+) (struct{}, error) {
+	_, body, error := client.ReleaseChatControlWithHttpInfoCtx(
+		ctx,
+		chatId,
+	)
+	return body, error
+}
+
 // ReleaseChatControl
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -373,17 +552,30 @@ func (client *LineModuleAPI) ReleaseChatControlWithHttpInfo(
 
 	chatId string,
 
+) (*http.Response, struct{}, error) {
+	return client.ReleaseChatControlWithHttpInfoCtx(
+		client.requestContext(),
+		chatId,
+	)
+}
+
+// ReleaseChatControlWithHttpInfoCtx
+// The same as ReleaseChatControlWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *LineModuleAPI) ReleaseChatControlWithHttpInfoCtx(
+	ctx context.Context,
+	chatId string,
+
 ) (*http.Response, struct{}, error) {
 	path := "/v2/bot/chat/{chatId}/control/release"
 
 	path = strings.Replace(path, "{chatId}", chatId, -1)
 
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), nil)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -396,7 +588,7 @@ func (client *LineModuleAPI) ReleaseChatControlWithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -0,0 +1,22 @@
+package module
+
+import (
+	"context"
+	"iter"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/pagination"
+)
+
+// Modules returns an iterator over the bots to which the module is attached.
+// See GetModules. The default page size is 100 and the maximum is 100.
+func (client *LineModuleAPI) Modules(ctx context.Context, options ...pagination.Option) iter.Seq2[ModuleBot, error] {
+	config := pagination.NewConfig(options...)
+	limit := int32(config.PageSizeOr(100))
+	return pagination.Seq(ctx, config, "", func(ctx context.Context, start string) (pagination.Page[ModuleBot, string], error) {
+		resp, err := client.GetModulesCtx(ctx, start, limit)
+		if err != nil {
+			return pagination.Page[ModuleBot, string]{}, err
+		}
+		return pagination.Page[ModuleBot, string]{Items: resp.Bots, Next: resp.Next, HasNext: resp.Next != ""}, nil
+	})
+}
@@ -32,24 +32,27 @@ import (
 	"path"
 
 	"github.com/line/line-bot-sdk-go/v8/linebot"
+	"github.com/line/line-bot-sdk-go/v8/linebot/channel_access_token"
+	"github.com/line/line-bot-sdk-go/v8/linebot/ratelimit"
+	"github.com/line/line-bot-sdk-go/v8/linebot/retry"
 )
 
 type LineModuleAttachAPI struct {
 	httpClient   *http.Client
 	endpoint     *url.URL
 	channelToken string
+	tokenSource  channel_access_token.TokenSource
 	ctx          context.Context
+	retryPolicy  *retry.Policy
+	rateLimiter  *ratelimit.Limiter
 }
 
 // LineModuleAttachAPIOption type
 type LineModuleAttachAPIOption func(*LineModuleAttachAPI) error
 
 // New returns a new bot client instance.
+// channelToken may be empty if a TokenSource is given with WithTokenSource.
 func NewLineModuleAttachAPI(channelToken string, options ...LineModuleAttachAPIOption) (*LineModuleAttachAPI, error) {
-	if channelToken == "" {
-		return nil, errors.New("missing channel access token")
-	}
-
 	c := &LineModuleAttachAPI{
 		channelToken: channelToken,
 		httpClient:   http.DefaultClient,
@@ -67,24 +70,61 @@ func NewLineModuleAttachAPI(channelToken string, options ...LineModuleAttachAPIO
 			return nil, err
 		}
 	}
+	if c.channelToken == "" && c.tokenSource == nil {
+		return nil, errors.New("missing channel access token")
+	}
 	return c, nil
 }
 
 // WithContext method
+// The context is stored on the client and shared by every call made through it.
+// To use a different context for each call, use the methods with the Ctx suffix instead.
 func (call *LineModuleAttachAPI) WithContext(ctx context.Context) *LineModuleAttachAPI {
 	call.ctx = ctx
 	return call
 }
 
 func (client *LineModuleAttachAPI) Do(req *http.Request) (*http.Response, error) {
-	if client.channelToken != "" {
+	if client.ctx != nil {
+		req = req.WithContext(client.ctx)
+	}
+	return client.do(req)
+}
+
+// do sends req with the context it already carries.
+func (client *LineModuleAttachAPI) do(req *http.Request) (*http.Response, error) {
+	if client.retryPolicy != nil {
+		return client.retryPolicy.Do(req, client.send)
+	}
+	return client.send(req)
+}
+
+// send sends a single attempt of req.
+func (client *LineModuleAttachAPI) send(req *http.Request) (*http.Response, error) {
+	if client.rateLimiter != nil {
+		if err := client.rateLimiter.Wait(req); err != nil {
+			return nil, err
+		}
+	}
+	if client.tokenSource != nil {
+		token, err := client.tokenSource.Token(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else if client.channelToken != "" {
 		req.Header.Set("Authorization", "Bearer "+client.channelToken)
 	}
 	req.Header.Set("User-Agent", "LINE-BotSDK-Go/"+linebot.GetVersion())
+	return client.httpClient.Do(req)
+}
+
+// requestContext returns the context set by WithContext, or context.Background if there is none.
+func (client *LineModuleAttachAPI) requestContext() context.Context {
 	if client.ctx != nil {
-		req = req.WithContext(client.ctx)
+		return client.ctx
 	}
-	return client.httpClient.Do(req)
+	return context.Background()
 }
 
 func (client *LineModuleAttachAPI) Url(endpointPath string) string {
@@ -102,6 +142,34 @@ func WithHTTPClient(c *http.Client) LineModuleAttachAPIOption {
 	}
 }
 
+// WithTokenSource function
+// Every request uses a token obtained from ts instead of the channel access token given to NewLineModuleAttachAPI.
+func WithTokenSource(ts channel_access_token.TokenSource) LineModuleAttachAPIOption {
+	return func(client *LineModuleAttachAPI) error {
+		client.tokenSource = ts
+		return nil
+	}
+}
+
+// WithRetryPolicy function
+// Failed requests are retried according to policy. See the retry package for which requests are retried.
+func WithRetryPolicy(policy *retry.Policy) LineModuleAttachAPIOption {
+	return func(client *LineModuleAttachAPI) error {
+		client.retryPolicy = policy
+		return nil
+	}
+}
+
+// WithRateLimiter function
+// Every request, including retries, waits for limiter before it is sent.
+// Share one limiter between the clients of the same channel.
+func WithRateLimiter(limiter *ratelimit.Limiter) LineModuleAttachAPIOption {
+	return func(client *LineModuleAttachAPI) error {
+		client.rateLimiter = limiter
+		return nil
+	}
+}
+
 // WithEndpoint function
 func WithEndpoint(endpoint string) LineModuleAttachAPIOption {
 	return func(client *LineModuleAttachAPI) error {
@@ -178,6 +246,56 @@ func (client *LineModuleAttachAPI) AttachModule(
 	return body, error
 }
 
+// AttachModuleCtx
+// The same as AttachModule, but the request uses ctx instead of the context set by WithContext.
+func (client *LineModuleAttachAPI) AttachModuleCtx(
+	ctx context.Context,
+	grantType string,
+
+	code string,
+
+	redirectUri string,
+
+	codeVerifier string,
+
+	clientId string,
+
+	clientSecret string,
+
+	region string,
+
+	basicSearchId string,
+
+	scope string,
+
+	brandType string,
+
+) (*AttachModuleResponse, error) {
+	_, body, error := client.AttachModuleWithHttpInfoCtx(
+		ctx,
+		grantType,
+
+		code,
+
+		redirectUri,
+
+		codeVerifier,
+
+		clientId,
+
+		clientSecret,
+
+		region,
+
+		basicSearchId,
+
+		scope,
+
+		brandType,
+	)
+	return body, error
+}
+
 // AttachModule
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -217,6 +335,55 @@ func (client *LineModuleAttachAPI) AttachModuleWithHttpInfo(
 
 	brandType string,
 
+) (*http.Response, *AttachModuleResponse, error) {
+	return client.AttachModuleWithHttpInfoCtx(
+		client.requestContext(),
+		grantType,
+
+		code,
+
+		redirectUri,
+
+		codeVerifier,
+
+		clientId,
+
+		clientSecret,
+
+		region,
+
+		basicSearchId,
+
+		scope,
+
+		brandType,
+	)
+}
+
+// AttachModuleWithHttpInfoCtx
+// The same as AttachModuleWithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *LineModuleAttachAPI) AttachModuleWithHttpInfoCtx(
+	ctx context.Context,
+	grantType string,
+
+	code string,
+
+	redirectUri string,
+
+	codeVerifier string,
+
+	clientId string,
+
+	clientSecret string,
+
+	region string,
+
+	basicSearchId string,
+
+	scope string,
+
+	brandType string,
+
 ) (*http.Response, *AttachModuleResponse, error) {
 	path := "/module/auth/v1/token"
 
@@ -249,13 +416,13 @@ func (client *LineModuleAttachAPI) AttachModuleWithHttpInfo(
 	buf := vs.Encode()
 	body := bytes.NewBufferString(buf)
 
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), body)
 	if err != nil {
 		return nil, nil, err
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, nil, err
@@ -268,7 +435,7 @@ func (client *LineModuleAttachAPI) AttachModuleWithHttpInfo(
 			return res, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, nil, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, nil, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
@@ -0,0 +1,132 @@
+// Package pagination turns the paginated listings of the LINE Platform into iterators.
+//
+// The generated clients return one page per call, along with a continuation
+// token or a page number. Seq fetches the pages lazily as the iterator is
+// consumed, and optionally fetches the next page while the items of the
+// current one are being consumed. The API clients expose iterators built on
+// it, such as MessagingApiAPI.FollowerIDs.
+package pagination
+
+import (
+	"context"
+	"iter"
+)
+
+// Config holds the options of an iterator.
+type Config struct {
+	// PageSize is the number of items to request per page. 0 means the default of the endpoint.
+	PageSize int
+	// Prefetch makes the iterator fetch the next page while the current one is consumed.
+	Prefetch bool
+}
+
+// Option type
+type Option func(*Config)
+
+// NewConfig function
+func NewConfig(options ...Option) Config {
+	var c Config
+	for _, option := range options {
+		option(&c)
+	}
+	return c
+}
+
+// PageSizeOr returns PageSize, or def if PageSize is not set.
+func (c Config) PageSizeOr(def int) int {
+	if c.PageSize > 0 {
+		return c.PageSize
+	}
+	return def
+}
+
+// WithPageSize function
+// Sets the number of items to request per page, up to the maximum of the endpoint.
+func WithPageSize(n int) Option {
+	return func(c *Config) {
+		c.PageSize = n
+	}
+}
+
+// WithPrefetch function
+// Fetches the next page in the background while the items of the current page are consumed.
+func WithPrefetch() Option {
+	return func(c *Config) {
+		c.Prefetch = true
+	}
+}
+
+// Page is one page of a listing.
+type Page[T, C any] struct {
+	Items []T
+	// Next is the cursor of the next page: a continuation token or a page number.
+	Next C
+	// HasNext is false on the last page.
+	HasNext bool
+}
+
+// FetchFunc fetches the page at cursor.
+type FetchFunc[T, C any] func(ctx context.Context, cursor C) (Page[T, C], error)
+
+// Seq returns an iterator over the items of all the pages, starting at the page at first.
+//
+// Pages are fetched as the iterator is consumed. When fetching a page fails,
+// the error is yielded with the zero value of T and the iteration ends.
+// Breaking out of the loop stops the iteration, and cancels the prefetch of
+// the next page if one is in flight.
+func Seq[T, C any](ctx context.Context, config Config, first C, fetch FetchFunc[T, C]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		type result struct {
+			page Page[T, C]
+			err  error
+		}
+		var pending chan result
+		prefetch := func(cursor C) {
+			pending = make(chan result, 1)
+			go func(ch chan<- result) {
+				page, err := fetch(ctx, cursor)
+				ch <- result{page, err}
+			}(pending)
+		}
+		defer func() {
+			if pending != nil {
+				cancel()
+				<-pending
+			}
+		}()
+
+		cursor := first
+		for {
+			var page Page[T, C]
+			var err error
+			if pending != nil {
+				r := <-pending
+				pending = nil
+				page, err = r.page, r.err
+			} else {
+				page, err = fetch(ctx, cursor)
+			}
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			if page.HasNext && config.Prefetch {
+				prefetch(page.Next)
+			}
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if !page.HasNext {
+				return
+			}
+			cursor = page.Next
+		}
+	}
+}
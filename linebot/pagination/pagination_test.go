@@ -0,0 +1,96 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+// pages is a listing of three pages with page numbers as cursors.
+var pages = [][]int{{1, 2}, {3, 4}, {5}}
+
+func fetchPages(fetched *atomic.Int32) FetchFunc[int, int] {
+	return func(ctx context.Context, page int) (Page[int, int], error) {
+		fetched.Add(1)
+		if err := ctx.Err(); err != nil {
+			return Page[int, int]{}, err
+		}
+		return Page[int, int]{Items: pages[page], Next: page + 1, HasNext: page+1 < len(pages)}, nil
+	}
+}
+
+func TestSeq(t *testing.T) {
+	for _, prefetch := range []bool{false, true} {
+		var fetched atomic.Int32
+		var got []int
+		for v, err := range Seq(context.Background(), Config{Prefetch: prefetch}, 0, fetchPages(&fetched)) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, v)
+		}
+		if len(got) != 5 || got[0] != 1 || got[4] != 5 {
+			t.Errorf("prefetch=%v: got %v", prefetch, got)
+		}
+		if fetched.Load() != 3 {
+			t.Errorf("prefetch=%v: fetched %d pages, want 3", prefetch, fetched.Load())
+		}
+	}
+}
+
+func TestSeqStopsEarly(t *testing.T) {
+	var fetched atomic.Int32
+	for v := range Seq(context.Background(), Config{}, 0, fetchPages(&fetched)) {
+		if v == 2 {
+			break
+		}
+	}
+	if fetched.Load() != 1 {
+		t.Errorf("fetched %d pages, want 1", fetched.Load())
+	}
+
+	// With prefetch, the second page is fetched while the first is consumed,
+	// and the iterator waits for it before returning.
+	fetched.Store(0)
+	for v := range Seq(context.Background(), NewConfig(WithPrefetch()), 0, fetchPages(&fetched)) {
+		if v == 1 {
+			break
+		}
+	}
+	if fetched.Load() != 2 {
+		t.Errorf("fetched %d pages, want 2", fetched.Load())
+	}
+}
+
+func TestSeqError(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	n := 0
+	seq := Seq(context.Background(), Config{}, 0, func(ctx context.Context, page int) (Page[int, int], error) {
+		if page == 1 {
+			return Page[int, int]{}, errFetch
+		}
+		return Page[int, int]{Items: []int{1}, Next: 1, HasNext: true}, nil
+	})
+	for v, err := range seq {
+		n++
+		if n == 1 && (v != 1 || err != nil) {
+			t.Errorf("got %d, %v", v, err)
+		}
+		if n == 2 && !errors.Is(err, errFetch) {
+			t.Errorf("got %v, want %v", err, errFetch)
+		}
+	}
+	if n != 2 {
+		t.Errorf("got %d values, want 2", n)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	if NewConfig().PageSizeOr(300) != 300 {
+		t.Errorf("the default page size should be used")
+	}
+	if NewConfig(WithPageSize(10)).PageSizeOr(300) != 10 {
+		t.Errorf("the page size should be used")
+	}
+}
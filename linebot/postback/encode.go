@@ -0,0 +1,127 @@
+package postback
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+// Encode encodes action and the fields of the struct v as a query string,
+// such as "action=buy&item=42".
+//
+// action may be a pattern such as "items/{id}/buy", in which case the
+// placeholders are replaced with the values of the fields of the same name,
+// and those fields are not repeated in the query string. v may be nil.
+func Encode(action string, v any) (string, error) {
+	action, values, err := encodeFields(action, v)
+	if err != nil {
+		return "", err
+	}
+	query := url.Values{}
+	for _, kv := range values {
+		if len(kv.values) > 0 {
+			query[kv.name] = kv.values
+		}
+	}
+	data := ActionKey + "=" + url.QueryEscape(action)
+	if len(query) > 0 {
+		data += "&" + query.Encode()
+	}
+	if err := ValidateData(data); err != nil {
+		return "", err
+	}
+	return data, nil
+}
+
+// EncodeJSON encodes action and the fields of the struct v as a JSON object,
+// such as {"action":"buy","item":42}. See Encode for action patterns.
+func EncodeJSON(action string, v any) (string, error) {
+	action, values, err := encodeFields(action, v)
+	if err != nil {
+		return "", err
+	}
+	object := map[string]any{ActionKey: action}
+	for _, kv := range values {
+		object[kv.name] = kv.value.Interface()
+	}
+	b, err := json.Marshal(object)
+	if err != nil {
+		return "", err
+	}
+	data := string(b)
+	if err := ValidateData(data); err != nil {
+		return "", err
+	}
+	return data, nil
+}
+
+// NewAction returns a postback action labeled label, whose data is Encode(action, v).
+func NewAction(label, action string, v any) (*messaging_api.PostbackAction, error) {
+	data, err := Encode(action, v)
+	if err != nil {
+		return nil, err
+	}
+	return &messaging_api.PostbackAction{
+		Label: label,
+		Data:  data,
+	}, nil
+}
+
+type encodedField struct {
+	name   string
+	value  reflect.Value
+	values []string
+}
+
+// encodeFields expands the placeholders of action and returns the remaining fields of v.
+func encodeFields(action string, v any) (string, []encodedField, error) {
+	rv, err := structValue(v)
+	if err != nil {
+		return "", nil, err
+	}
+	var fields []encodedField
+	if rv.IsValid() {
+		for _, f := range structFields(rv.Type()) {
+			fv := rv.FieldByIndex(f.index)
+			if f.omitEmpty && fv.IsZero() {
+				continue
+			}
+			if f.name == ActionKey {
+				return "", nil, fmt.Errorf("postback: field name %q is reserved", ActionKey)
+			}
+			values, err := formatValue(fv)
+			if err != nil {
+				return "", nil, fmt.Errorf("postback: field %s: %w", f.name, err)
+			}
+			fields = append(fields, encodedField{name: f.name, value: fv, values: values})
+		}
+	}
+
+	segments := strings.Split(action, "/")
+	for i, segment := range segments {
+		name, ok := placeholder(segment)
+		if !ok {
+			continue
+		}
+		j := slices.IndexFunc(fields, func(f encodedField) bool { return f.name == name })
+		if j < 0 || len(fields[j].values) != 1 {
+			return "", nil, fmt.Errorf("postback: no single value for {%s}", name)
+		}
+		segments[i] = url.PathEscape(fields[j].values[0])
+		fields = slices.Delete(fields, j, j+1)
+	}
+	return strings.Join(segments, "/"), fields, nil
+}
+
+// placeholder returns the name of a "{name}" pattern segment.
+func placeholder(segment string) (string, bool) {
+	if len(segment) > 2 && segment[0] == '{' && segment[len(segment)-1] == '}' {
+		return segment[1 : len(segment)-1], true
+	}
+	return "", false
+}
@@ -0,0 +1,164 @@
+package postback
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// field is a struct field that is encoded into postback data.
+type field struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+// structFields returns the fields of t, named by their `postback` tags.
+// Untagged exported fields use the field name, and fields tagged "-" are skipped.
+func structFields(t reflect.Type) []field {
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag := f.Tag.Get("postback")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, field{
+			name:      name,
+			index:     f.Index,
+			omitEmpty: opts == "omitempty",
+		})
+	}
+	return fields
+}
+
+// structValue returns the struct v points to or holds.
+// A nil v is allowed and has no fields.
+func structValue(v any) (reflect.Value, error) {
+	if v == nil {
+		return reflect.Value{}, nil
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return reflect.Value{}, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("postback: %T is not a struct", v)
+	}
+	return rv, nil
+}
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// formatValue formats v as query-string values.
+func formatValue(v reflect.Value) ([]string, error) {
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		return nil, nil
+	}
+	if v.Type().Implements(textMarshalerType) {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return []string{string(text)}, nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return []string{v.String()}, nil
+	case reflect.Bool:
+		return []string{strconv.FormatBool(v.Bool())}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return []string{strconv.FormatInt(v.Int(), 10)}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return []string{strconv.FormatUint(v.Uint(), 10)}, nil
+	case reflect.Float32, reflect.Float64:
+		return []string{strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())}, nil
+	case reflect.Pointer:
+		return formatValue(v.Elem())
+	case reflect.Slice:
+		var values []string
+		for i := 0; i < v.Len(); i++ {
+			s, err := formatValue(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			if len(s) != 1 {
+				return nil, fmt.Errorf("postback: unsupported type %s", v.Type())
+			}
+			values = append(values, s[0])
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("postback: unsupported type %s", v.Type())
+}
+
+// parseValue sets v from query-string values.
+func parseValue(v reflect.Value, values []string) error {
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(values[0]))
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return parseValue(v.Elem(), values)
+	case reflect.Slice:
+		s := reflect.MakeSlice(v.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := parseValue(s.Index(i), []string{value}); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+	}
+
+	s := values[0]
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("postback: unsupported type %s", v.Type())
+	}
+	return nil
+}
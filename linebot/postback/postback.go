@@ -0,0 +1,85 @@
+// Package postback encodes and routes the data of postback actions.
+//
+// Postback data is an opaque string of up to 300 characters. This package
+// gives it a structure: an action, which names the route, and fields, which
+// are taken from a struct by their `postback` tags. The data is encoded either
+// as a query string such as "action=buy&item=42" or as a JSON object such as
+// {"action":"buy","item":42}. The Router decodes both, as well as path-like
+// data such as "items/42/buy?color=red".
+package postback
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+// ActionKey is the key that holds the action in query-string and JSON data.
+const ActionKey = "action"
+
+// MaxDataLength is the maximum number of characters of the data of a postback action.
+const MaxDataLength = 300
+
+// ErrDataTooLong is returned when encoded data is longer than MaxDataLength characters.
+var ErrDataTooLong = errors.New("postback: data too long")
+
+// Rich menu switch action results, found in Params.Status.
+const (
+	StatusSuccess                 = "SUCCESS"
+	StatusRichMenuAliasIdNotFound = "RICHMENU_ALIAS_ID_NOTFOUND"
+	StatusRichMenuNotFound        = "RICHMENU_NOTFOUND"
+	StatusFailed                  = "FAILED"
+)
+
+// Params holds the values that the LINE Platform adds to the postback of a
+// datetime picker action or a rich menu switch action.
+// Fields the postback does not carry are left zero.
+type Params struct {
+	// Date is the date selected with mode "date", at midnight.
+	Date time.Time
+	// Time is the time selected with mode "time", on January 1 of year 0.
+	Time time.Time
+	// Datetime is the date and time selected with mode "datetime".
+	Datetime time.Time
+	// NewRichMenuAliasId is the alias of the rich menu switched to.
+	NewRichMenuAliasId string
+	// Status is the result of the rich menu switch, one of the Status constants.
+	Status string
+}
+
+// ParseParams parses PostbackContent.Params.
+// The LINE Platform sends dates and times without a time zone; they are read in loc.
+func ParseParams(params map[string]string, loc *time.Location) (Params, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	var p Params
+	var err error
+	if s, ok := params["date"]; ok {
+		if p.Date, err = time.ParseInLocation("2006-01-02", s, loc); err != nil {
+			return Params{}, fmt.Errorf("postback: invalid date %q: %w", s, err)
+		}
+	}
+	if s, ok := params["time"]; ok {
+		if p.Time, err = time.ParseInLocation("15:04", s, loc); err != nil {
+			return Params{}, fmt.Errorf("postback: invalid time %q: %w", s, err)
+		}
+	}
+	if s, ok := params["datetime"]; ok {
+		if p.Datetime, err = time.ParseInLocation("2006-01-02T15:04", s, loc); err != nil {
+			return Params{}, fmt.Errorf("postback: invalid datetime %q: %w", s, err)
+		}
+	}
+	p.NewRichMenuAliasId = params["newRichMenuAliasId"]
+	p.Status = params["status"]
+	return p, nil
+}
+
+// ValidateData returns ErrDataTooLong if data is longer than MaxDataLength characters.
+func ValidateData(data string) error {
+	if n := utf8.RuneCountInString(data); n > MaxDataLength {
+		return fmt.Errorf("%w: %d characters, the maximum is %d", ErrDataTooLong, n, MaxDataLength)
+	}
+	return nil
+}
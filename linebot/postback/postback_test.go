@@ -0,0 +1,249 @@
+package postback
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/webhook"
+)
+
+type buyData struct {
+	Item     int      `postback:"item"`
+	Color    string   `postback:"color,omitempty"`
+	Gift     bool     `postback:"gift"`
+	Tags     []string `postback:"tag,omitempty"`
+	Internal string   `postback:"-"`
+}
+
+func TestEncode(t *testing.T) {
+	data, err := Encode("buy", buyData{Item: 42, Gift: true, Tags: []string{"a", "b"}, Internal: "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "action=buy&gift=true&item=42&tag=a&tag=b"; data != want {
+		t.Errorf("got %q, want %q", data, want)
+	}
+
+	data, err = Encode("items/{item}/buy", &buyData{Item: 42, Color: "dark red"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "action=items%2F42%2Fbuy&color=dark+red&gift=false"; data != want {
+		t.Errorf("got %q, want %q", data, want)
+	}
+
+	data, err = EncodeJSON("buy", buyData{Item: 42, Color: "red"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"action":"buy","color":"red","gift":false,"item":42}`; data != want {
+		t.Errorf("got %q, want %q", data, want)
+	}
+
+	if _, err := Encode("items/{id}", buyData{}); err == nil {
+		t.Errorf("expected an error for a missing placeholder field")
+	}
+	if _, err := Encode("buy", buyData{Color: strings.Repeat("あ", 300)}); !errors.Is(err, ErrDataTooLong) {
+		t.Errorf("got %v, want ErrDataTooLong", err)
+	}
+}
+
+func TestNewAction(t *testing.T) {
+	action, err := NewAction("Buy", "buy", buyData{Item: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if action.Label != "Buy" || action.Data != "action=buy&gift=false&item=1" {
+		t.Errorf("unexpected action: %+v", action)
+	}
+}
+
+func TestDecodeRoundTrip(t *testing.T) {
+	in := buyData{Item: 42, Color: "dark red", Gift: true, Tags: []string{"a", "b"}}
+	for _, encode := range []func(string, any) (string, error){Encode, EncodeJSON} {
+		data, err := encode("buy", in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out buyData
+		action, err := Decode(data, &out)
+		if err != nil {
+			t.Fatalf("Decode(%q): %v", data, err)
+		}
+		if action != "buy" {
+			t.Errorf("got action %q", action)
+		}
+		if out.Item != in.Item || out.Color != in.Color || out.Gift != in.Gift || strings.Join(out.Tags, ",") != "a,b" {
+			t.Errorf("Decode(%q) = %+v", data, out)
+		}
+	}
+}
+
+func TestParseParams(t *testing.T) {
+	loc := time.FixedZone("JST", 9*60*60)
+	p, err := ParseParams(map[string]string{
+		"date":     "2017-12-25",
+		"time":     "13:30",
+		"datetime": "2017-12-25T01:00",
+	}, loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.Date.Equal(time.Date(2017, 12, 25, 0, 0, 0, 0, loc)) {
+		t.Errorf("unexpected date: %v", p.Date)
+	}
+	if p.Time.Hour() != 13 || p.Time.Minute() != 30 {
+		t.Errorf("unexpected time: %v", p.Time)
+	}
+	if !p.Datetime.Equal(time.Date(2017, 12, 25, 1, 0, 0, 0, loc)) {
+		t.Errorf("unexpected datetime: %v", p.Datetime)
+	}
+
+	p, err = ParseParams(map[string]string{"newRichMenuAliasId": "richmenu-alias-b", "status": "SUCCESS"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.NewRichMenuAliasId != "richmenu-alias-b" || p.Status != StatusSuccess || !p.Date.IsZero() {
+		t.Errorf("unexpected params: %+v", p)
+	}
+
+	if _, err := ParseParams(map[string]string{"date": "12/25"}, nil); err == nil {
+		t.Errorf("expected an error for an invalid date")
+	}
+}
+
+func postbackEvent(data string, params map[string]string) *webhook.PostbackEvent {
+	return &webhook.PostbackEvent{
+		Postback: &webhook.PostbackContent{Data: data, Params: params},
+	}
+}
+
+func TestRouter(t *testing.T) {
+	var got []string
+	r := NewRouter()
+	r.Handle("buy", Bind(func(ctx context.Context, e *webhook.PostbackEvent, v *buyData, p Params) error {
+		got = append(got, "buy:"+v.Color)
+		return nil
+	}))
+	r.Handle("items/{item}/buy", Bind(func(ctx context.Context, e *webhook.PostbackEvent, v *buyData, p Params) error {
+		got = append(got, "item:"+v.Color+":"+string(rune('0'+v.Item)))
+		return nil
+	}))
+	r.Handle("reserve", func(ctx context.Context, e *webhook.PostbackEvent, req *Request) error {
+		got = append(got, "reserve:"+req.Params.Date.Format("01/02"))
+		return nil
+	})
+	r.Fallback(func(ctx context.Context, e *webhook.PostbackEvent, req *Request) error {
+		got = append(got, "fallback:"+req.Action)
+		return nil
+	})
+
+	data, err := Encode("items/{item}/buy", buyData{Item: 7, Color: "red"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range []*webhook.PostbackEvent{
+		postbackEvent("action=buy&color=blue", nil),
+		postbackEvent(`{"action":"buy","color":"green"}`, nil),
+		postbackEvent(data, nil),
+		postbackEvent("items/8/buy?color=white", nil),
+		postbackEvent("action=reserve", map[string]string{"date": "2024-03-01"}),
+		postbackEvent("action=sell", nil),
+	} {
+		if err := r.HandlePostback(context.Background(), e); err != nil {
+			t.Fatalf("HandlePostback(%q): %v", e.Postback.Data, err)
+		}
+	}
+	want := []string{
+		"buy:blue",
+		"buy:green",
+		"item:red:7",
+		"item:white:8",
+		"reserve:03/01",
+		"fallback:sell",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestRouterWithDispatcher(t *testing.T) {
+	called := false
+	r := NewRouter()
+	r.Handle("ping", func(ctx context.Context, e *webhook.PostbackEvent, req *Request) error {
+		called = true
+		return nil
+	})
+	d := webhook.NewDispatcher()
+	d.OnPostback(r.HandlePostback)
+	if err := d.Dispatch(context.Background(), webhook.PostbackEvent{
+		Postback: &webhook.PostbackContent{Data: "action=ping"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !called {
+		t.Errorf("handler was not called")
+	}
+}
+
+func TestEncodeNilTextMarshaler(t *testing.T) {
+	type reminder struct {
+		At *time.Time `postback:"at"`
+	}
+	data, err := Encode("remind", reminder{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data != "action=remind" {
+		t.Errorf("got %q, want the nil field omitted", data)
+	}
+
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	data, err = Encode("remind", reminder{At: &at})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out reminder
+	if _, err := Decode(data, &out); err != nil || out.At == nil || !out.At.Equal(at) {
+		t.Errorf("Decode(%q) = %+v, %v", data, out, err)
+	}
+}
+
+func TestDecodePattern(t *testing.T) {
+	type itemData struct {
+		Id    string `postback:"id"`
+		Color string `postback:"color"`
+	}
+	for _, encode := range []func(string, any) (string, error){Encode, EncodeJSON} {
+		data, err := encode("items/{id}/buy", itemData{Id: "a b&c", Color: "red"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out itemData
+		if err := DecodePattern("items/{id}/buy", data, &out); err != nil {
+			t.Fatalf("DecodePattern(%q): %v", data, err)
+		}
+		if out.Id != "a b&c" || out.Color != "red" {
+			t.Errorf("DecodePattern(%q) = %+v", data, out)
+		}
+		if err := DecodePattern("items/{id}/sell", data, &out); err == nil {
+			t.Errorf("expected an error for an action that does not match")
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	var v buyData
+	if _, err := Decode("action=buy&item=abc", &v); err == nil {
+		t.Errorf("expected an error for an invalid integer")
+	}
+	if _, err := Decode("action=buy", v); err == nil {
+		t.Errorf("expected an error for a non-pointer")
+	}
+	if _, err := Decode(`{"action":`, &v); err == nil {
+		t.Errorf("expected an error for invalid JSON")
+	}
+}
@@ -0,0 +1,250 @@
+package postback
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/webhook"
+)
+
+// Request is the postback data of an event, parsed by the Router.
+type Request struct {
+	// Data is the raw postback data.
+	Data string
+	// Action is the action of the data, such as "buy" or "items/42/buy".
+	Action string
+	// Params holds the values added by datetime picker and rich menu switch actions.
+	Params Params
+
+	values url.Values
+	object map[string]json.RawMessage
+}
+
+// Value returns the first value of the field name, or "" if there is none.
+// For JSON data, only string values are returned.
+func (r *Request) Value(name string) string {
+	if raw, ok := r.object[name]; ok {
+		var s string
+		if json.Unmarshal(raw, &s) == nil {
+			return s
+		}
+		return ""
+	}
+	return r.values.Get(name)
+}
+
+// Decode stores the fields of the data, and the values captured by the route
+// pattern, in the struct v points to.
+func (r *Request) Decode(v any) error {
+	rv, err := structValue(v)
+	if err != nil {
+		return err
+	}
+	if !rv.IsValid() || !rv.CanSet() {
+		return fmt.Errorf("postback: Decode needs a non-nil pointer to a struct, got %T", v)
+	}
+	for _, f := range structFields(rv.Type()) {
+		fv := rv.FieldByIndex(f.index)
+		if raw, ok := r.object[f.name]; ok {
+			if err := json.Unmarshal(raw, fv.Addr().Interface()); err != nil {
+				return fmt.Errorf("postback: field %s: %w", f.name, err)
+			}
+			continue
+		}
+		if values := r.values[f.name]; len(values) > 0 {
+			if err := parseValue(fv, values); err != nil {
+				return fmt.Errorf("postback: field %s: %w", f.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Parse parses postback data in any of the forms the Router accepts.
+func Parse(data string) (*Request, error) {
+	r := &Request{Data: data, values: url.Values{}}
+	if strings.HasPrefix(strings.TrimSpace(data), "{") {
+		if err := json.Unmarshal([]byte(data), &r.object); err != nil {
+			return nil, fmt.Errorf("postback: invalid JSON data: %w", err)
+		}
+		if raw, ok := r.object[ActionKey]; ok {
+			if err := json.Unmarshal(raw, &r.Action); err != nil {
+				return nil, fmt.Errorf("postback: invalid action: %w", err)
+			}
+			delete(r.object, ActionKey)
+		}
+	} else if path, query, _ := strings.Cut(data, "?"); !strings.Contains(path, "=") {
+		values, err := url.ParseQuery(query)
+		if err != nil {
+			return nil, fmt.Errorf("postback: invalid query string: %w", err)
+		}
+		r.Action = path
+		r.values = values
+	} else {
+		values, err := url.ParseQuery(data)
+		if err != nil {
+			return nil, fmt.Errorf("postback: invalid query string: %w", err)
+		}
+		r.Action = values.Get(ActionKey)
+		values.Del(ActionKey)
+		r.values = values
+	}
+	return r, nil
+}
+
+// Decode parses postback data, stores its fields in the struct v points to,
+// and returns its action.
+//
+// The action is returned as it is in the data. For data encoded with a
+// pattern such as "items/{id}/buy", use DecodePattern or the Router, which
+// also decode the values of the placeholders.
+func Decode(data string, v any) (string, error) {
+	r, err := Parse(data)
+	if err != nil {
+		return "", err
+	}
+	if err := r.Decode(v); err != nil {
+		return "", err
+	}
+	return r.Action, nil
+}
+
+// DecodePattern parses postback data whose action matches pattern, such as
+// "items/{id}/buy", and stores its fields, including the values of the
+// placeholders, in the struct v points to. It is the reverse of Encode with
+// the same pattern.
+func DecodePattern(pattern, data string, v any) error {
+	r, err := Parse(data)
+	if err != nil {
+		return err
+	}
+	captured, ok := route{segments: strings.Split(pattern, "/")}.match(strings.Split(r.Action, "/"))
+	if !ok {
+		return fmt.Errorf("postback: action %q does not match %q", r.Action, pattern)
+	}
+	r.capture(captured)
+	return r.Decode(v)
+}
+
+// capture adds the values captured by a route pattern to the fields of r.
+func (r *Request) capture(captured map[string]string) {
+	for name, value := range captured {
+		r.values.Set(name, value)
+		delete(r.object, name)
+	}
+}
+
+// Handler handles a postback event routed by the Router.
+type Handler func(ctx context.Context, event *webhook.PostbackEvent, req *Request) error
+
+// Bind returns a Handler that decodes the postback data into a new T before calling f.
+func Bind[T any](f func(ctx context.Context, event *webhook.PostbackEvent, v *T, params Params) error) Handler {
+	return func(ctx context.Context, event *webhook.PostbackEvent, req *Request) error {
+		v := new(T)
+		if err := req.Decode(v); err != nil {
+			return err
+		}
+		return f(ctx, event, v, req.Params)
+	}
+}
+
+type route struct {
+	segments []string
+	handler  Handler
+}
+
+// Router routes postback events to handlers by the action of their data.
+//
+// A pattern either matches the action exactly, or has placeholders such as
+// "items/{id}/buy" that each match one path segment of the action. The
+// matched values are decoded like the other fields of the data. Routes are
+// tried in the order they were added.
+type Router struct {
+	routes   []route
+	fallback Handler
+	location *time.Location
+}
+
+// RouterOption type
+type RouterOption func(*Router)
+
+// NewRouter function
+func NewRouter(options ...RouterOption) *Router {
+	r := &Router{location: time.UTC}
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
+
+// WithLocation function
+// Sets the location in which the dates and times of Params are read. The default is UTC.
+func WithLocation(loc *time.Location) RouterOption {
+	return func(r *Router) {
+		r.location = loc
+	}
+}
+
+// Handle adds a route.
+func (r *Router) Handle(pattern string, handler Handler) {
+	r.routes = append(r.routes, route{
+		segments: strings.Split(pattern, "/"),
+		handler:  handler,
+	})
+}
+
+// Fallback sets the handler called for postbacks that no route matches.
+func (r *Router) Fallback(handler Handler) {
+	r.fallback = handler
+}
+
+// HandlePostback routes event. It can be passed to webhook.Dispatcher.OnPostback.
+func (r *Router) HandlePostback(ctx context.Context, event *webhook.PostbackEvent) error {
+	if event.Postback == nil {
+		return nil
+	}
+	req, err := Parse(event.Postback.Data)
+	if err != nil {
+		return err
+	}
+	if req.Params, err = ParseParams(event.Postback.Params, r.location); err != nil {
+		return err
+	}
+
+	segments := strings.Split(req.Action, "/")
+	for _, route := range r.routes {
+		captured, ok := route.match(segments)
+		if !ok {
+			continue
+		}
+		req.capture(captured)
+		return route.handler(ctx, event, req)
+	}
+	if r.fallback != nil {
+		return r.fallback(ctx, event, req)
+	}
+	return nil
+}
+
+func (rt route) match(segments []string) (map[string]string, bool) {
+	if len(segments) != len(rt.segments) {
+		return nil, false
+	}
+	captured := map[string]string{}
+	for i, segment := range segments {
+		segment, err := url.PathUnescape(segment)
+		if err != nil {
+			return nil, false
+		}
+		if name, ok := placeholder(rt.segments[i]); ok {
+			captured[name] = segment
+		} else if segment != rt.segments[i] {
+			return nil, false
+		}
+	}
+	return captured, true
+}
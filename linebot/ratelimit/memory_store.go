@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore is a Store that keeps the token buckets in memory.
+// It only limits requests sent from the current process.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+type bucket struct {
+	// next is the time at which the next token becomes available when the bucket is empty.
+	next time.Time
+}
+
+// NewMemoryStore function
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: map[string]*bucket{},
+		now:     time.Now,
+	}
+}
+
+// Reserve method
+func (s *MemoryStore) Reserve(ctx context.Context, key string, rate Rate, maxWait time.Duration) (time.Duration, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{}
+		s.buckets[key] = b
+	}
+
+	// The bucket is full, holding burst tokens, once next is burst-1 intervals in the past.
+	interval := rate.interval()
+	if earliest := now.Add(-interval * time.Duration(rate.burst()-1)); b.next.Before(earliest) {
+		b.next = earliest
+	}
+	wait := b.next.Sub(now)
+	if wait < 0 {
+		wait = 0
+	}
+	if wait > maxWait {
+		return 0, false, nil
+	}
+	b.next = b.next.Add(interval)
+	return wait, true, nil
+}
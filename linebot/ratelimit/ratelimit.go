@@ -0,0 +1,196 @@
+// Package ratelimit provides a client-side rate limiter for the generated API clients.
+//
+// Requests are classified into endpoint groups that share a quota on the LINE
+// Platform, and every group has its own token bucket. The buckets live in a
+// Store, so several processes sending on behalf of the same channel can share
+// them by plugging in a Store backed by a shared database.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrLimited is returned by Wait when a request would have to wait longer than allowed.
+var ErrLimited = errors.New("ratelimit: rate limit exceeded")
+
+// Group identifies a set of endpoints that share a quota.
+type Group string
+
+const (
+	GroupPush          Group = "push"
+	GroupMulticast     Group = "multicast"
+	GroupNarrowcast    Group = "narrowcast"
+	GroupBroadcast     Group = "broadcast"
+	GroupRichMenuBulk  Group = "richmenu_bulk"
+	GroupRichMenuBatch Group = "richmenu_batch"
+	GroupAudience      Group = "audience"
+	GroupOther         Group = "other"
+)
+
+// Rate is the number of requests allowed per period.
+type Rate struct {
+	Limit int
+	Per   time.Duration
+
+	// Burst is the number of requests that may be sent at once.
+	// If it is 0, requests are spaced evenly over the period.
+	Burst int
+}
+
+func (r Rate) interval() time.Duration {
+	if r.Limit <= 0 {
+		return 0
+	}
+	return r.Per / time.Duration(r.Limit)
+}
+
+func (r Rate) burst() int {
+	if r.Burst <= 0 {
+		return 1
+	}
+	return r.Burst
+}
+
+// DefaultRates returns the rate limits documented for each group.
+// The groups limited per minute or per hour allow their whole quota at once,
+// since spacing the requests evenly would delay the second one by minutes.
+// See https://developers.line.biz/en/reference/messaging-api/#rate-limits
+func DefaultRates() map[Group]Rate {
+	return map[Group]Rate{
+		GroupPush:          {Limit: 2000, Per: time.Second},
+		GroupMulticast:     {Limit: 200, Per: time.Second},
+		GroupNarrowcast:    {Limit: 60, Per: time.Hour, Burst: 60},
+		GroupBroadcast:     {Limit: 60, Per: time.Hour, Burst: 60},
+		GroupRichMenuBulk:  {Limit: 3, Per: time.Second},
+		GroupRichMenuBatch: {Limit: 3, Per: time.Hour, Burst: 3},
+		GroupAudience:      {Limit: 60, Per: time.Minute, Burst: 60},
+		GroupOther:         {Limit: 2000, Per: time.Second},
+	}
+}
+
+// GroupOf returns the group that req belongs to.
+func GroupOf(req *http.Request) Group {
+	p := req.URL.Path
+	switch {
+	case strings.HasSuffix(p, "/v2/bot/message/push"):
+		return GroupPush
+	case strings.HasSuffix(p, "/v2/bot/message/multicast"):
+		return GroupMulticast
+	case strings.HasSuffix(p, "/v2/bot/message/narrowcast"):
+		return GroupNarrowcast
+	case strings.HasSuffix(p, "/v2/bot/message/broadcast"):
+		return GroupBroadcast
+	case strings.HasSuffix(p, "/v2/bot/richmenu/bulk/link"), strings.HasSuffix(p, "/v2/bot/richmenu/bulk/unlink"):
+		return GroupRichMenuBulk
+	case strings.HasSuffix(p, "/v2/bot/richmenu/batch"):
+		return GroupRichMenuBatch
+	case strings.Contains(p, "/v2/bot/audienceGroup/") && req.Method != http.MethodGet:
+		return GroupAudience
+	}
+	return GroupOther
+}
+
+// Store holds the token buckets.
+type Store interface {
+	// Reserve takes a token from the bucket identified by key and returns how long
+	// the caller must wait before using it. If the wait would exceed maxWait, no
+	// token is taken and ok is false.
+	Reserve(ctx context.Context, key string, rate Rate, maxWait time.Duration) (wait time.Duration, ok bool, err error)
+}
+
+// Limiter type
+type Limiter struct {
+	store     Store
+	rates     map[Group]Rate
+	keyPrefix string
+	maxWait   time.Duration
+}
+
+// Option type
+type Option func(*Limiter)
+
+// New returns a Limiter that uses DefaultRates, an in-memory store, and blocks
+// until a request can be sent.
+func New(options ...Option) *Limiter {
+	l := &Limiter{
+		store:   NewMemoryStore(),
+		rates:   DefaultRates(),
+		maxWait: time.Duration(math.MaxInt64),
+	}
+	for _, option := range options {
+		option(l)
+	}
+	return l
+}
+
+// WithStore function
+func WithStore(store Store) Option {
+	return func(l *Limiter) {
+		l.store = store
+	}
+}
+
+// WithRate function
+// Overrides the rate of group. A zero Rate disables limiting for the group.
+func WithRate(group Group, rate Rate) Option {
+	return func(l *Limiter) {
+		l.rates[group] = rate
+	}
+}
+
+// WithKeyPrefix function
+// Bucket keys are prefixed with prefix. Use a distinct prefix, such as the
+// channel ID, for each channel that shares a Store.
+func WithKeyPrefix(prefix string) Option {
+	return func(l *Limiter) {
+		l.keyPrefix = prefix
+	}
+}
+
+// WithMaxWait function
+// Wait returns ErrLimited instead of waiting longer than d.
+func WithMaxWait(d time.Duration) Option {
+	return func(l *Limiter) {
+		l.maxWait = d
+	}
+}
+
+// WithFailFast function
+// Wait returns ErrLimited instead of waiting at all.
+func WithFailFast() Option {
+	return WithMaxWait(0)
+}
+
+// Wait blocks until req may be sent, or returns ErrLimited if that would take
+// longer than the configured maximum wait.
+func (l *Limiter) Wait(req *http.Request) error {
+	group := GroupOf(req)
+	rate, ok := l.rates[group]
+	if !ok || rate.interval() <= 0 {
+		return nil
+	}
+	ctx := req.Context()
+	wait, ok, err := l.store.Reserve(ctx, l.keyPrefix+string(group), rate, l.maxWait)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrLimited
+	}
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
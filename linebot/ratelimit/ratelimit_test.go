@@ -0,0 +1,144 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGroupOf(t *testing.T) {
+	tests := []struct {
+		method string
+		url    string
+		want   Group
+	}{
+		{http.MethodPost, "https://api.line.me/v2/bot/message/push", GroupPush},
+		{http.MethodPost, "https://api.line.me/v2/bot/message/multicast", GroupMulticast},
+		{http.MethodPost, "https://api.line.me/v2/bot/message/narrowcast", GroupNarrowcast},
+		{http.MethodPost, "https://api.line.me/v2/bot/message/broadcast", GroupBroadcast},
+		{http.MethodPost, "https://api.line.me/v2/bot/message/validate/push", GroupOther},
+		{http.MethodPost, "https://api.line.me/v2/bot/richmenu/bulk/link", GroupRichMenuBulk},
+		{http.MethodPost, "https://api.line.me/v2/bot/richmenu/bulk/unlink", GroupRichMenuBulk},
+		{http.MethodPost, "https://api.line.me/v2/bot/richmenu/batch", GroupRichMenuBatch},
+		{http.MethodPost, "https://api-data.line.me/v2/bot/audienceGroup/upload/byFile", GroupAudience},
+		{http.MethodGet, "https://api.line.me/v2/bot/audienceGroup/list", GroupOther},
+		{http.MethodPost, "http://localhost:8080/prefix/v2/bot/message/push", GroupPush},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.url, nil)
+		if got := GroupOf(req); got != tt.want {
+			t.Errorf("GroupOf(%s %s) = %s, want %s", tt.method, tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestMemoryStoreReserve(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	rate := Rate{Limit: 1, Per: time.Second, Burst: 2}
+	forever := time.Duration(1 << 62)
+
+	for i, want := range []time.Duration{0, 0, time.Second, 2 * time.Second} {
+		wait, ok, err := store.Reserve(context.Background(), "k", rate, forever)
+		if err != nil || !ok {
+			t.Fatalf("Reserve #%d: %v, %v", i, ok, err)
+		}
+		if wait != want {
+			t.Errorf("Reserve #%d: wait %v, want %v", i, wait, want)
+		}
+	}
+
+	// fail fast does not consume a token
+	if _, ok, _ := store.Reserve(context.Background(), "k", rate, 0); ok {
+		t.Errorf("Reserve should fail without waiting")
+	}
+	now = now.Add(3 * time.Second)
+	if wait, ok, _ := store.Reserve(context.Background(), "k", rate, 0); !ok || wait != 0 {
+		t.Errorf("Reserve after refill: %v, %v", wait, ok)
+	}
+
+	// buckets are independent
+	if wait, ok, _ := store.Reserve(context.Background(), "other", rate, 0); !ok || wait != 0 {
+		t.Errorf("Reserve on another key: %v, %v", wait, ok)
+	}
+}
+
+func TestLimiterFailFast(t *testing.T) {
+	limiter := New(
+		WithRate(GroupNarrowcast, Rate{Limit: 60, Per: time.Hour}),
+		WithFailFast(),
+	)
+	req := httptest.NewRequest(http.MethodPost, "https://api.line.me/v2/bot/message/narrowcast", nil)
+	if err := limiter.Wait(req); err != nil {
+		t.Fatalf("first request should pass: %v", err)
+	}
+	if err := limiter.Wait(req); !errors.Is(err, ErrLimited) {
+		t.Errorf("Expected ErrLimited, but got %v", err)
+	}
+	// other groups are not affected
+	push := httptest.NewRequest(http.MethodPost, "https://api.line.me/v2/bot/message/push", nil)
+	if err := limiter.Wait(push); err != nil {
+		t.Errorf("push should pass: %v", err)
+	}
+}
+
+func TestDefaultRatesAllowQuotaAtOnce(t *testing.T) {
+	limiter := New(WithFailFast())
+	for url, quota := range map[string]int{
+		"https://api.line.me/v2/bot/message/narrowcast":               60,
+		"https://api.line.me/v2/bot/message/broadcast":                60,
+		"https://api.line.me/v2/bot/richmenu/batch":                   3,
+		"https://api-data.line.me/v2/bot/audienceGroup/upload/byFile": 60,
+	} {
+		req := httptest.NewRequest(http.MethodPost, url, nil)
+		for i := 0; i < quota; i++ {
+			if err := limiter.Wait(req); err != nil {
+				t.Fatalf("%s: request #%d within the quota: %v", url, i+1, err)
+			}
+		}
+		if err := limiter.Wait(req); !errors.Is(err, ErrLimited) {
+			t.Errorf("%s: got %v after the quota, want ErrLimited", url, err)
+		}
+	}
+}
+
+func TestLimiterBlocks(t *testing.T) {
+	limiter := New(WithRate(GroupPush, Rate{Limit: 20, Per: time.Second}))
+	req := httptest.NewRequest(http.MethodPost, "https://api.line.me/v2/bot/message/push", nil)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("requests were not spaced: %v", elapsed)
+	}
+}
+
+func TestLimiterHonorsContext(t *testing.T) {
+	limiter := New(WithRate(GroupBroadcast, Rate{Limit: 1, Per: time.Hour}))
+	req := httptest.NewRequest(http.MethodPost, "https://api.line.me/v2/bot/message/broadcast", nil)
+	if err := limiter.Wait(req); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(req.WithContext(ctx)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, but got %v", err)
+	}
+}
+
+func TestLimiterWithZeroRateDisablesLimiting(t *testing.T) {
+	limiter := New(WithRate(GroupBroadcast, Rate{}), WithFailFast())
+	req := httptest.NewRequest(http.MethodPost, "https://api.line.me/v2/bot/message/broadcast", nil)
+	for i := 0; i < 100; i++ {
+		if err := limiter.Wait(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+// Package reply sends the response to a webhook event, by reply while its
+// reply token is usable, and by push otherwise.
+//
+// A reply token can be used once, shortly after the event was received. A
+// Responder is built from the event when it is received, collects up to 5
+// messages, and sends them once. If the token is stale, or the LINE Platform
+// rejects it, the messages are pushed to the source of the event instead, as
+// long as the FallbackPolicy allows it, since pushed messages count against
+// the monthly quota.
+//
+//	responder := reply.NewResponder(bot, event)
+//	// ... slow work ...
+//	result, err := responder.Reply(ctx, messaging_api.TextMessage{Text: "Done!"})
+//	if err == nil && result.Method == reply.MethodPush {
+//		log.Printf("replied by push, request ID %s", result.RequestId)
+//	}
+package reply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+	"github.com/line/line-bot-sdk-go/v8/linebot/webhook"
+)
+
+const (
+	// MaxMessages is the maximum number of messages of a reply or a push.
+	MaxMessages = 5
+	// DefaultTokenTTL is how long a reply token is considered usable by default.
+	// It is a little shorter than the lifetime of reply tokens, so that a reply
+	// sent just before the token expires is not rejected.
+	DefaultTokenTTL = 50 * time.Second
+)
+
+// invalidReplyTokenMessage is the error message of the LINE Platform for a
+// reply token that is unknown, expired or already used.
+const invalidReplyTokenMessage = "Invalid reply token"
+
+var (
+	// ErrNoReplyToken is the reason for a push when the event has no reply token.
+	ErrNoReplyToken = errors.New("reply: the event has no reply token")
+	// ErrTokenExpired is the reason for a push when the reply token is older
+	// than the TTL of the Responder. The reply is not attempted.
+	ErrTokenExpired = errors.New("reply: the reply token has expired")
+	// ErrTokenRejected is the reason for a push when the LINE Platform
+	// rejected the reply token, because it was used or had expired.
+	ErrTokenRejected = errors.New("reply: the reply token was rejected")
+	// ErrAlreadySent is returned when the messages of a Responder have already been sent.
+	ErrAlreadySent = errors.New("reply: the messages have already been sent")
+	// ErrTooManyMessages is returned when more than MaxMessages messages are added.
+	ErrTooManyMessages = errors.New("reply: too many messages")
+	// ErrNoMessages is returned by Send when no messages have been added.
+	ErrNoMessages = errors.New("reply: no messages to send")
+)
+
+// Method is the way the messages were sent.
+type Method string
+
+// Method constants
+const (
+	MethodNone  Method = ""
+	MethodReply Method = "reply"
+	MethodPush  Method = "push"
+)
+
+// Result is the outcome of sending the messages of a Responder.
+type Result struct {
+	Method Method
+	// To is the user, group or room ID the messages were pushed to.
+	To string
+	// Reason is why the messages were pushed rather than replied:
+	// ErrNoReplyToken, ErrTokenExpired, or an error wrapping ErrTokenRejected.
+	Reason error
+	// RequestId is the x-line-request-id header of the response.
+	RequestId    string
+	SentMessages []messaging_api.SentMessage
+}
+
+// FallbackPolicy decides whether the messages that cannot be replied are
+// pushed to the chat to. reason is ErrNoReplyToken, ErrTokenExpired, or an
+// error wrapping ErrTokenRejected.
+type FallbackPolicy func(ctx context.Context, to string, reason error) bool
+
+// AlwaysPush is a FallbackPolicy that always pushes the messages that cannot be replied.
+func AlwaysPush(ctx context.Context, to string, reason error) bool {
+	return true
+}
+
+// NeverPush is a FallbackPolicy that never pushes messages, so that no quota is used.
+func NeverPush(ctx context.Context, to string, reason error) bool {
+	return false
+}
+
+// Responder sends the response to one webhook event.
+type Responder struct {
+	client     *messaging_api.MessagingApiAPI
+	replyToken string
+	to         string
+	receivedAt time.Time
+	ttl        time.Duration
+	fallback   FallbackPolicy
+	now        func() time.Time
+
+	notificationDisabled bool
+
+	mu       sync.Mutex
+	messages []messaging_api.MessageInterface
+	result   *Result
+}
+
+// ResponderOption type
+type ResponderOption func(*Responder)
+
+// WithTokenTTL function
+// Sets how long after the event was received the reply token is used.
+// After that, the messages are pushed without trying to reply. The default is DefaultTokenTTL.
+func WithTokenTTL(ttl time.Duration) ResponderOption {
+	return func(r *Responder) {
+		r.ttl = ttl
+	}
+}
+
+// WithReceivedAt function
+// Sets when the event was received. The default is when the Responder is created.
+func WithReceivedAt(t time.Time) ResponderOption {
+	return func(r *Responder) {
+		r.receivedAt = t
+	}
+}
+
+// WithFallbackPolicy function
+// Sets the policy that decides whether the messages that cannot be replied are pushed. The default is AlwaysPush.
+func WithFallbackPolicy(policy FallbackPolicy) ResponderOption {
+	return func(r *Responder) {
+		r.fallback = policy
+	}
+}
+
+// WithNotificationDisabled function
+// Sends the messages without notifying the users.
+func WithNotificationDisabled() ResponderOption {
+	return func(r *Responder) {
+		r.notificationDisabled = true
+	}
+}
+
+// WithClock function
+// Sets the function that returns the current time.
+func WithClock(now func() time.Time) ResponderOption {
+	return func(r *Responder) {
+		r.now = now
+	}
+}
+
+// NewResponder function
+// Returns a Responder for the event, which should have just been received.
+func NewResponder(client *messaging_api.MessagingApiAPI, event webhook.EventInterface, options ...ResponderOption) *Responder {
+	r := &Responder{
+		client:     client,
+		replyToken: webhook.GetReplyToken(event),
+		ttl:        DefaultTokenTTL,
+		fallback:   AlwaysPush,
+		now:        time.Now,
+	}
+	if source := webhook.GetSource(event); source != nil {
+		r.to = webhook.GetSourceId(source)
+	}
+	for _, option := range options {
+		option(r)
+	}
+	if r.receivedAt.IsZero() {
+		r.receivedAt = r.now()
+	}
+	return r
+}
+
+// Add method
+// Adds messages to send. It returns ErrTooManyMessages if there would be more
+// than MaxMessages, and ErrAlreadySent after the messages have been sent.
+func (r *Responder) Add(messages ...messaging_api.MessageInterface) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.result != nil {
+		return ErrAlreadySent
+	}
+	if len(r.messages)+len(messages) > MaxMessages {
+		return fmt.Errorf("%w: %d messages, at most %d can be sent", ErrTooManyMessages, len(r.messages)+len(messages), MaxMessages)
+	}
+	r.messages = append(r.messages, messages...)
+	return nil
+}
+
+// Reply method
+// Adds the messages and sends all the messages added.
+func (r *Responder) Reply(ctx context.Context, messages ...messaging_api.MessageInterface) (*Result, error) {
+	if err := r.Add(messages...); err != nil {
+		return nil, err
+	}
+	return r.Send(ctx)
+}
+
+// Send method
+// Sends the messages added, by reply if the reply token is usable, or else
+// by push if the fallback policy allows it. The messages are sent only once:
+// later calls return ErrAlreadySent, even if the first one failed.
+//
+// If the messages cannot be replied and are not pushed, the error is the
+// reason, such as ErrTokenExpired.
+func (r *Responder) Send(ctx context.Context) (*Result, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.result != nil {
+		return nil, ErrAlreadySent
+	}
+	if len(r.messages) == 0 {
+		return nil, ErrNoMessages
+	}
+	r.result = &Result{}
+
+	var reason error
+	switch {
+	case r.replyToken == "":
+		reason = ErrNoReplyToken
+	case !r.now().Before(r.receivedAt.Add(r.ttl)):
+		reason = ErrTokenExpired
+	default:
+		res, body, err := r.client.ReplyMessageWithHttpInfoCtx(ctx, &messaging_api.ReplyMessageRequest{
+			ReplyToken:           r.replyToken,
+			Messages:             r.messages,
+			NotificationDisabled: r.notificationDisabled,
+		})
+		if !isRejectedToken(err) {
+			if body == nil {
+				return r.record(MethodReply, res, nil, err)
+			}
+			return r.record(MethodReply, res, body.SentMessages, err)
+		}
+		reason = fmt.Errorf("%w: %w", ErrTokenRejected, err)
+	}
+
+	r.result.Reason = reason
+	if r.to == "" || !r.fallback(ctx, r.to, reason) {
+		return nil, reason
+	}
+	r.result.To = r.to
+	res, body, err := r.client.PushMessageWithHttpInfoCtx(ctx, &messaging_api.PushMessageRequest{
+		To:                   r.to,
+		Messages:             r.messages,
+		NotificationDisabled: r.notificationDisabled,
+	}, "")
+	if body == nil {
+		return r.record(MethodPush, res, nil, err)
+	}
+	return r.record(MethodPush, res, body.SentMessages, err)
+}
+
+// Result method
+// Returns the result of Send, or nil if the messages have not been sent or could not be sent.
+func (r *Responder) Result() *Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.result == nil || r.result.Method == MethodNone {
+		return nil
+	}
+	result := *r.result
+	return &result
+}
+
+func (r *Responder) record(method Method, res *http.Response, sentMessages []messaging_api.SentMessage, err error) (*Result, error) {
+	if err != nil {
+		return nil, err
+	}
+	r.result.Method = method
+	r.result.SentMessages = sentMessages
+	if res != nil {
+		r.result.RequestId = res.Header.Get("x-line-request-id")
+	}
+	result := *r.result
+	return &result, nil
+}
+
+// isRejectedToken reports whether the reply failed because of the reply token.
+func isRejectedToken(err error) bool {
+	var apiErr *messaging_api.APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest &&
+		apiErr.Response != nil && apiErr.Response.Message == invalidReplyTokenMessage
+}
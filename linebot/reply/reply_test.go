@@ -0,0 +1,178 @@
+package reply
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/linetest"
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+	"github.com/line/line-bot-sdk-go/v8/linebot/webhook"
+	"github.com/line/line-bot-sdk-go/v8/linebot/webhook/webhooktest"
+)
+
+func newClient(t *testing.T) (*linetest.Server, *messaging_api.MessagingApiAPI) {
+	server := linetest.NewServer()
+	t.Cleanup(server.Close)
+	bot, err := messaging_api.NewMessagingApiAPI("token", messaging_api.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server, bot
+}
+
+func hello() messaging_api.MessageInterface {
+	return messaging_api.TextMessage{Text: "hello"}
+}
+
+func TestReply(t *testing.T) {
+	server, bot := newClient(t)
+	event := webhooktest.Text(webhooktest.User("U1"), "hi")
+	server.AddReplyToken(event.ReplyToken, "U1")
+
+	responder := NewResponder(bot, event)
+	if err := responder.Add(hello(), hello()); err != nil {
+		t.Fatal(err)
+	}
+	result, err := responder.Send(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Method != MethodReply || result.Reason != nil || len(result.SentMessages) != 2 || result.RequestId == "" {
+		t.Errorf("got %+v, want a reply", result)
+	}
+	sent := server.Sent()
+	if len(sent) != 1 || sent[0].Kind != linetest.KindReply || sent[0].RequestId != result.RequestId {
+		t.Errorf("got %+v, want one reply", sent)
+	}
+	if got := responder.Result(); got == nil || got.Method != MethodReply {
+		t.Errorf("got %+v, want the result of the reply", got)
+	}
+
+	if _, err := responder.Reply(context.Background(), hello()); !errors.Is(err, ErrAlreadySent) {
+		t.Errorf("got %v, want ErrAlreadySent", err)
+	}
+	if _, err := responder.Send(context.Background()); !errors.Is(err, ErrAlreadySent) {
+		t.Errorf("got %v, want ErrAlreadySent", err)
+	}
+}
+
+func TestFallback(t *testing.T) {
+	now := time.Now()
+	for _, tt := range []struct {
+		name    string
+		event   func(server *linetest.Server) webhook.EventInterface
+		options []ResponderOption
+		reason  error
+	}{
+		{
+			name: "expired",
+			event: func(server *linetest.Server) webhook.EventInterface {
+				event := webhooktest.Text(webhooktest.User("U1"), "hi")
+				server.AddReplyToken(event.ReplyToken, "U1")
+				return event
+			},
+			options: []ResponderOption{WithReceivedAt(now.Add(-DefaultTokenTTL))},
+			reason:  ErrTokenExpired,
+		},
+		{
+			name: "rejected",
+			event: func(server *linetest.Server) webhook.EventInterface {
+				return webhooktest.Text(webhooktest.User("U1"), "hi")
+			},
+			reason: ErrTokenRejected,
+		},
+		{
+			name: "no reply token",
+			event: func(server *linetest.Server) webhook.EventInterface {
+				return webhooktest.Unfollow(webhooktest.User("U1"))
+			},
+			reason: ErrNoReplyToken,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			server, bot := newClient(t)
+			var policyReason error
+			options := append(tt.options,
+				WithClock(func() time.Time { return now }),
+				WithFallbackPolicy(func(ctx context.Context, to string, reason error) bool {
+					policyReason = reason
+					return to == "U1"
+				}))
+			responder := NewResponder(bot, tt.event(server), options...)
+			result, err := responder.Reply(context.Background(), hello())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Method != MethodPush || result.To != "U1" || !errors.Is(result.Reason, tt.reason) || !errors.Is(policyReason, tt.reason) {
+				t.Errorf("got %+v, want a push because of %v", result, tt.reason)
+			}
+			sent := server.Sent()
+			if len(sent) != 1 || sent[0].Kind != linetest.KindPush || sent[0].To[0] != "U1" {
+				t.Errorf("got %+v, want one push", sent)
+			}
+		})
+	}
+}
+
+func TestIsRejectedToken(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"invalid reply token", &messaging_api.APIError{StatusCode: http.StatusBadRequest, Response: &messaging_api.ErrorResponse{Message: invalidReplyTokenMessage}}, true},
+		{"other bad request", &messaging_api.APIError{StatusCode: http.StatusBadRequest, Response: &messaging_api.ErrorResponse{Message: "The request body has 1 error(s)"}}, false},
+		{"no response", &messaging_api.APIError{StatusCode: http.StatusBadRequest}, false},
+		{"other status", &messaging_api.APIError{StatusCode: http.StatusInternalServerError, Response: &messaging_api.ErrorResponse{Message: invalidReplyTokenMessage}}, false},
+	} {
+		if got := isRejectedToken(tt.err); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNeverPush(t *testing.T) {
+	server, bot := newClient(t)
+	event := webhooktest.Text(webhooktest.User("U1"), "hi")
+	token := server.NewReplyToken("U1")
+	event.ReplyToken = token
+
+	// The reply token is used by someone else first.
+	if _, err := bot.ReplyMessage(&messaging_api.ReplyMessageRequest{ReplyToken: token, Messages: []messaging_api.MessageInterface{hello()}}); err != nil {
+		t.Fatal(err)
+	}
+	server.ClearSent()
+
+	responder := NewResponder(bot, event, WithFallbackPolicy(NeverPush))
+	_, err := responder.Reply(context.Background(), hello())
+	var apiErr *messaging_api.APIError
+	if !errors.Is(err, ErrTokenRejected) || !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("got %v, want the reply token rejected", err)
+	}
+	if sent := server.Sent(); len(sent) != 0 {
+		t.Errorf("got %+v, want nothing sent", sent)
+	}
+	if result := responder.Result(); result != nil {
+		t.Errorf("got %+v, want no result", result)
+	}
+}
+
+func TestAdd(t *testing.T) {
+	_, bot := newClient(t)
+	responder := NewResponder(bot, webhooktest.Text(webhooktest.User("U1"), "hi"))
+	if _, err := responder.Send(context.Background()); !errors.Is(err, ErrNoMessages) {
+		t.Errorf("got %v, want ErrNoMessages", err)
+	}
+	if err := responder.Add(hello(), hello(), hello()); err != nil {
+		t.Fatal(err)
+	}
+	if err := responder.Add(hello(), hello(), hello()); !errors.Is(err, ErrTooManyMessages) {
+		t.Errorf("got %v, want ErrTooManyMessages", err)
+	}
+	if err := responder.Add(hello(), hello()); err != nil {
+		t.Errorf("got %v, want the fifth message added", err)
+	}
+}
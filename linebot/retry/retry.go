@@ -0,0 +1,217 @@
+// Package retry provides the retry policy used by the generated API clients.
+//
+// A request is retried on network errors, 429 and 5xx responses, but only when
+// retrying it is safe: the method is idempotent, or the request carries an
+// X-Line-Retry-Key header. Endpoints that accept a retry key (push, multicast,
+// narrowcast and broadcast) get a freshly generated key when the caller passes
+// an empty one, and the same key is reused for every attempt of that call.
+package retry
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math"
+	mathrand "math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	retryKeyHeader          = "X-Line-Retry-Key"
+	requestIDHeader         = "X-Line-Request-Id"
+	acceptedRequestIDHeader = "X-Line-Accepted-Request-Id"
+)
+
+// Policy type
+type Policy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay computed from InitialBackoff and Multiplier.
+	// It does not cap a delay requested by a Retry-After header.
+	MaxBackoff time.Duration
+
+	// Multiplier is the factor by which the delay grows after each retry.
+	Multiplier float64
+
+	// Jitter is the fraction, between 0 and 1, of the delay that is randomized.
+	Jitter float64
+}
+
+// NewPolicy returns a Policy with reasonable defaults: up to 4 attempts,
+// starting at 500ms and doubling up to 10s, with 20% jitter.
+func NewPolicy() *Policy {
+	return &Policy{
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// NewRetryKey returns a random UUID (version 4) for the X-Line-Retry-Key header.
+func NewRetryKey() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// Do sends req with send, retrying according to the policy.
+//
+// When a retried request carrying a retry key gets a 409 response whose
+// x-line-accepted-request-id refers to one of the earlier attempts, the earlier
+// attempt has been accepted by the LINE Platform. In that case Do returns a
+// 200 response with an empty JSON object as the body, keeping the headers of
+// the 409 response.
+func (p *Policy) Do(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	if values, ok := req.Header[retryKeyHeader]; ok && (len(values) == 0 || values[0] == "") {
+		req.Header.Set(retryKeyHeader, NewRetryKey())
+	}
+	if !p.retryable(req) {
+		return send(req)
+	}
+
+	ctx := req.Context()
+	var attemptRequestIDs []string
+	unknownAttempt := false
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 {
+			var err error
+			attemptReq, err = rewind(req)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		res, err := send(attemptReq)
+		last := attempt >= p.MaxAttempts
+		if err != nil {
+			if last || ctx.Err() != nil {
+				return res, err
+			}
+			unknownAttempt = true
+			if err := sleep(ctx, p.backoff(attempt)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if attempt > 1 && res.StatusCode == http.StatusConflict {
+			accepted := res.Header.Get(acceptedRequestIDHeader)
+			if accepted != "" && (unknownAttempt || slices.Contains(attemptRequestIDs, accepted)) {
+				return accept(res), nil
+			}
+		}
+		if last || !retryableStatus(res.StatusCode) {
+			return res, nil
+		}
+
+		attemptRequestIDs = append(attemptRequestIDs, res.Header.Get(requestIDHeader))
+		delay, ok := retryAfter(res.Header.Get("Retry-After"), time.Now())
+		if !ok {
+			delay = p.backoff(attempt)
+		}
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *Policy) retryable(req *http.Request) bool {
+	if p.MaxAttempts <= 1 {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get(retryKeyHeader) != ""
+}
+
+// backoff returns the delay after the given attempt, which starts at 1.
+func (p *Policy) backoff(attempt int) time.Duration {
+	d := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		d -= d * p.Jitter * mathrand.Float64()
+	}
+	return time.Duration(d)
+}
+
+func retryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode/100 == 5
+}
+
+// retryAfter parses a Retry-After header, which is either a number of seconds or an HTTP date.
+func retryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+func rewind(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	return r, nil
+}
+
+func accept(res *http.Response) *http.Response {
+	_, _ = io.Copy(io.Discard, res.Body)
+	_ = res.Body.Close()
+	body := []byte("{}")
+	accepted := *res
+	accepted.Status = "200 OK"
+	accepted.StatusCode = http.StatusOK
+	accepted.Body = io.NopCloser(bytes.NewReader(body))
+	accepted.ContentLength = int64(len(body))
+	return &accepted
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,249 @@
+package retry
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func testPolicy() *Policy {
+	return &Policy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+		Multiplier:     2,
+		Jitter:         0.5,
+	}
+}
+
+func newPostRequest(t *testing.T, retryKey string) *http.Request {
+	req, err := http.NewRequest(http.MethodPost, "https://api.line.me/v2/bot/message/push", bytes.NewBufferString(`{"to":"U1"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Line-Retry-Key", retryKey)
+	return req
+}
+
+func response(statusCode int, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader(nil)),
+	}
+}
+
+func TestNewRetryKey(t *testing.T) {
+	re := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	key := NewRetryKey()
+	if !re.MatchString(key) {
+		t.Errorf("invalid UUID: %s", key)
+	}
+	if key == NewRetryKey() {
+		t.Errorf("keys should be unique")
+	}
+}
+
+func TestDoReusesGeneratedRetryKey(t *testing.T) {
+	var keys []string
+	var bodies []string
+	send := func(req *http.Request) (*http.Response, error) {
+		keys = append(keys, req.Header.Get("X-Line-Retry-Key"))
+		body, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(body))
+		if len(keys) < 3 {
+			return response(http.StatusInternalServerError, nil), nil
+		}
+		return response(http.StatusOK, nil), nil
+	}
+	res, err := testPolicy().Do(newPostRequest(t, ""), send)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("status: %d", res.StatusCode)
+	}
+	if len(keys) != 3 {
+		t.Fatalf("attempts: %d", len(keys))
+	}
+	if keys[0] == "" || keys[0] != keys[1] || keys[1] != keys[2] {
+		t.Errorf("retry key must be generated once and reused: %v", keys)
+	}
+	for _, body := range bodies {
+		if body != `{"to":"U1"}` {
+			t.Errorf("body: %s", body)
+		}
+	}
+}
+
+func TestDoKeepsCallerRetryKey(t *testing.T) {
+	var key string
+	send := func(req *http.Request) (*http.Response, error) {
+		key = req.Header.Get("X-Line-Retry-Key")
+		return response(http.StatusOK, nil), nil
+	}
+	if _, err := testPolicy().Do(newPostRequest(t, "123e4567-e89b-12d3-a456-426614174000"), send); err != nil {
+		t.Fatal(err)
+	}
+	if key != "123e4567-e89b-12d3-a456-426614174000" {
+		t.Errorf("key: %s", key)
+	}
+}
+
+func TestDoRetriesNetworkErrors(t *testing.T) {
+	attempts := 0
+	send := func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return nil, errors.New("connection reset")
+	}
+	_, err := testPolicy().Do(newPostRequest(t, ""), send)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if attempts != 3 {
+		t.Errorf("attempts: %d", attempts)
+	}
+}
+
+func TestDoDoesNotRetryPostWithoutRetryKey(t *testing.T) {
+	attempts := 0
+	send := func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return response(http.StatusInternalServerError, nil), nil
+	}
+	req, err := http.NewRequest(http.MethodPost, "https://api.line.me/v2/bot/message/reply", bytes.NewBufferString(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := testPolicy().Do(req, send)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 1 || res.StatusCode != http.StatusInternalServerError {
+		t.Errorf("attempts: %d, status: %d", attempts, res.StatusCode)
+	}
+}
+
+func TestDoDoesNotRetryClientErrors(t *testing.T) {
+	attempts := 0
+	send := func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return response(http.StatusBadRequest, nil), nil
+	}
+	res, err := testPolicy().Do(newPostRequest(t, ""), send)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 1 || res.StatusCode != http.StatusBadRequest {
+		t.Errorf("attempts: %d, status: %d", attempts, res.StatusCode)
+	}
+}
+
+func TestDoTreatsAcceptedConflictAsSuccess(t *testing.T) {
+	attempts := 0
+	send := func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			return response(http.StatusInternalServerError, http.Header{"X-Line-Request-Id": {"req-1"}}), nil
+		}
+		return response(http.StatusConflict, http.Header{"X-Line-Accepted-Request-Id": {"req-1"}}), nil
+	}
+	res, err := testPolicy().Do(newPostRequest(t, ""), send)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("status: %d", res.StatusCode)
+	}
+	if res.Header.Get("X-Line-Accepted-Request-Id") != "req-1" {
+		t.Errorf("headers should be kept: %v", res.Header)
+	}
+	body, _ := io.ReadAll(res.Body)
+	if string(body) != "{}" {
+		t.Errorf("body: %s", body)
+	}
+}
+
+func TestDoKeepsUnrelatedConflict(t *testing.T) {
+	attempts := 0
+	send := func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			return response(http.StatusInternalServerError, http.Header{"X-Line-Request-Id": {"req-1"}}), nil
+		}
+		return response(http.StatusConflict, http.Header{"X-Line-Accepted-Request-Id": {"other"}}), nil
+	}
+	res, err := testPolicy().Do(newPostRequest(t, ""), send)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusConflict {
+		t.Errorf("status: %d", res.StatusCode)
+	}
+}
+
+func TestDoHonorsRetryAfter(t *testing.T) {
+	attempts := 0
+	var last time.Time
+	var waited time.Duration
+	send := func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			last = time.Now()
+			return response(http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}}), nil
+		}
+		waited = time.Since(last)
+		return response(http.StatusOK, nil), nil
+	}
+	req := httptest.NewRequest(http.MethodGet, "https://api.line.me/v2/bot/info", nil)
+	if _, err := testPolicy().Do(req, send); err != nil {
+		t.Fatal(err)
+	}
+	if waited < time.Second {
+		t.Errorf("Retry-After was not honored: %v", waited)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{"-1", 0, false},
+		{"Mon, 01 Jan 2024 00:00:10 GMT", 10 * time.Second, true},
+		{"invalid", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := retryAfter(tt.value, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("retryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := &Policy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+	for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 5: time.Second} {
+		if got := p.backoff(attempt); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := p.backoff(1); got < 50*time.Millisecond || got > 100*time.Millisecond {
+			t.Fatalf("backoff with jitter out of range: %v", got)
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package richmenu
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg" // register the formats accepted by SetRichMenuImage
+	_ "image/png"
+	"unicode/utf8"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+const (
+	// MaxAreas is the maximum number of areas of a rich menu.
+	MaxAreas = 20
+	// MaxChatBarTextLength is the maximum length of the chat bar text of a rich menu.
+	MaxChatBarTextLength = 14
+	// MaxImageSize is the maximum size of the image of a rich menu, in bytes.
+	MaxImageSize = 1 << 20
+	// MinWidth and MaxWidth are the bounds of the width of a rich menu.
+	MinWidth = 800
+	MaxWidth = 2500
+	// MinHeight is the minimum height of a rich menu.
+	MinHeight = 250
+	// MinAspectRatio is the minimum ratio of the width to the height of a rich menu.
+	MinAspectRatio = 1.45
+)
+
+var (
+	// SizeLarge is the size of a full rich menu.
+	SizeLarge = messaging_api.RichMenuSize{Width: 2500, Height: 1686}
+	// SizeCompact is the size of a half-height rich menu.
+	SizeCompact = messaging_api.RichMenuSize{Width: 2500, Height: 843}
+)
+
+// Template divides a rich menu into rows, and each row into columns. Rows
+// and columns are sized in proportion to their weights, and the areas are
+// numbered from left to right, then top to bottom.
+//
+//	// A short row of two areas on top of a tall row of three.
+//	richmenu.Template{Rows: []int{1, 2}, Columns: [][]int{{1, 1}, {1, 1, 1}}}
+type Template struct {
+	// Rows are the weights of the heights of the rows.
+	Rows []int
+	// Columns are the weights of the widths of the columns of each row.
+	Columns [][]int
+}
+
+// The templates of the LINE Official Account Manager.
+var (
+	// TemplateFull is a single area.
+	TemplateFull = Grid(1, 1)
+	// TemplateGrid6 is two rows of three areas.
+	TemplateGrid6 = Grid(2, 3)
+	// TemplateGrid4 is two rows of two areas.
+	TemplateGrid4 = Grid(2, 2)
+	// TemplateOnePlusThree is a wide area on top of three areas.
+	TemplateOnePlusThree = Template{Rows: []int{1, 1}, Columns: [][]int{{1}, {1, 1, 1}}}
+	// TemplateOnePlusTwo is a wide area on top of two areas.
+	TemplateOnePlusTwo = Template{Rows: []int{1, 1}, Columns: [][]int{{1}, {1, 1}}}
+	// TemplateTwoPlusOne is two areas on top of a wide area.
+	TemplateTwoPlusOne = Template{Rows: []int{1, 1}, Columns: [][]int{{1, 1}, {1}}}
+	// TemplateColumns3 is three areas side by side.
+	TemplateColumns3 = Grid(1, 3)
+	// TemplateColumns2 is two areas side by side.
+	TemplateColumns2 = Grid(1, 2)
+	// TemplateRows2 is two areas on top of each other.
+	TemplateRows2 = Grid(2, 1)
+	// TemplateWideLeft is an area of two thirds of the width, then one of a third.
+	TemplateWideLeft = Template{Rows: []int{1}, Columns: [][]int{{2, 1}}}
+	// TemplateWideRight is an area of a third of the width, then one of two thirds.
+	TemplateWideRight = Template{Rows: []int{1}, Columns: [][]int{{1, 2}}}
+)
+
+// Grid function
+// Returns a template of rows of columns of equal sizes.
+func Grid(rows, columns int) Template {
+	t := Template{Rows: make([]int, rows), Columns: make([][]int, rows)}
+	for i := range t.Rows {
+		t.Rows[i] = 1
+		t.Columns[i] = make([]int, columns)
+		for j := range t.Columns[i] {
+			t.Columns[i][j] = 1
+		}
+	}
+	return t
+}
+
+// Len returns the number of areas of the template.
+func (t Template) Len() int {
+	n := 0
+	for _, columns := range t.Columns {
+		n += len(columns)
+	}
+	return n
+}
+
+// Bounds returns the bounds of the areas of the template in a rich menu of
+// the given size. Adjacent areas share their edges, so that the areas cover
+// the whole menu without gaps or overlaps.
+func (t Template) Bounds(size messaging_api.RichMenuSize) ([]messaging_api.RichMenuBounds, error) {
+	if len(t.Rows) == 0 || len(t.Rows) != len(t.Columns) {
+		return nil, fmt.Errorf("template must have at least one row and columns for each of its %d rows, got %d", len(t.Rows), len(t.Columns))
+	}
+	if n := t.Len(); n > MaxAreas {
+		return nil, fmt.Errorf("template must have at most %d areas, got %d", MaxAreas, n)
+	}
+	ys, err := split(size.Height, t.Rows)
+	if err != nil {
+		return nil, fmt.Errorf("rows: %w", err)
+	}
+	var bounds []messaging_api.RichMenuBounds
+	for i, columns := range t.Columns {
+		xs, err := split(size.Width, columns)
+		if err != nil {
+			return nil, fmt.Errorf("columns[%d]: %w", i, err)
+		}
+		for j := range columns {
+			bounds = append(bounds, messaging_api.RichMenuBounds{
+				X:      xs[j],
+				Y:      ys[i],
+				Width:  xs[j+1] - xs[j],
+				Height: ys[i+1] - ys[i],
+			})
+		}
+	}
+	return bounds, nil
+}
+
+// Areas returns the areas of the template in a rich menu of the given size,
+// with the actions in the order of the areas.
+func (t Template) Areas(size messaging_api.RichMenuSize, actions ...messaging_api.ActionInterface) ([]messaging_api.RichMenuArea, error) {
+	bounds, err := t.Bounds(size)
+	if err != nil {
+		return nil, err
+	}
+	if len(actions) != len(bounds) {
+		return nil, fmt.Errorf("template has %d areas, got %d actions", len(bounds), len(actions))
+	}
+	areas := make([]messaging_api.RichMenuArea, len(bounds))
+	for i := range bounds {
+		areas[i] = messaging_api.RichMenuArea{Bounds: &bounds[i], Action: actions[i]}
+	}
+	return areas, nil
+}
+
+// split returns the edges of the parts of length total, sized by their weights.
+func split(total int64, weights []int) ([]int64, error) {
+	if len(weights) == 0 {
+		return nil, errors.New("must have at least one weight")
+	}
+	var sum int64
+	for _, w := range weights {
+		if w <= 0 {
+			return nil, fmt.Errorf("weights must be positive, got %d", w)
+		}
+		sum += int64(w)
+	}
+	if sum > total {
+		return nil, fmt.Errorf("cannot split %d pixels into %d parts", total, sum)
+	}
+	edges := make([]int64, len(weights)+1)
+	var cumulative int64
+	for i, w := range weights {
+		cumulative += int64(w)
+		edges[i+1] = total * cumulative / sum
+	}
+	return edges, nil
+}
+
+// ValidateSize checks the size of a rich menu: its width, height and aspect ratio.
+func ValidateSize(size *messaging_api.RichMenuSize) error {
+	if size == nil {
+		return errors.New("size: must be set")
+	}
+	var errs []error
+	if size.Width < MinWidth || size.Width > MaxWidth {
+		errs = append(errs, fmt.Errorf("size.width: must be between %d and %d, got %d", MinWidth, MaxWidth, size.Width))
+	}
+	if size.Height < MinHeight {
+		errs = append(errs, fmt.Errorf("size.height: must be at least %d, got %d", MinHeight, size.Height))
+	} else if float64(size.Width)/float64(size.Height) < MinAspectRatio {
+		errs = append(errs, fmt.Errorf("size: the aspect ratio must be at least %g, got %dx%d", MinAspectRatio, size.Width, size.Height))
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateAreas checks that there are at most MaxAreas areas, that they
+// have actions, and that their bounds are inside the size and do not overlap.
+func ValidateAreas(size messaging_api.RichMenuSize, areas []messaging_api.RichMenuArea) error {
+	var errs []error
+	if len(areas) > MaxAreas {
+		errs = append(errs, fmt.Errorf("areas: must have at most %d areas, got %d", MaxAreas, len(areas)))
+	}
+	for i, area := range areas {
+		if area.Action == nil {
+			errs = append(errs, fmt.Errorf("areas[%d].action: must be set", i))
+		}
+		b := area.Bounds
+		switch {
+		case b == nil:
+			errs = append(errs, fmt.Errorf("areas[%d].bounds: must be set", i))
+			continue
+		case b.Width <= 0 || b.Height <= 0:
+			errs = append(errs, fmt.Errorf("areas[%d].bounds: must have a positive width and height, got %dx%d", i, b.Width, b.Height))
+			continue
+		case b.X < 0 || b.Y < 0 || b.X+b.Width > size.Width || b.Y+b.Height > size.Height:
+			errs = append(errs, fmt.Errorf("areas[%d].bounds: must be within the size %dx%d", i, size.Width, size.Height))
+		}
+		for j := range i {
+			if c := areas[j].Bounds; c != nil && overlap(b, c) {
+				errs = append(errs, fmt.Errorf("areas[%d].bounds: overlaps areas[%d]", i, j))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func overlap(a, b *messaging_api.RichMenuBounds) bool {
+	return a.X < b.X+b.Width && b.X < a.X+a.Width && a.Y < b.Y+b.Height && b.Y < a.Y+a.Height
+}
+
+// ValidateRequest checks a rich menu before it is created: its size, areas, name and chat bar text.
+func ValidateRequest(request *messaging_api.RichMenuRequest) error {
+	errs := []error{ValidateSize(request.Size)}
+	if request.Size != nil {
+		errs = append(errs, ValidateAreas(*request.Size, request.Areas))
+	}
+	if n := utf8.RuneCountInString(request.Name); n == 0 || n > MaxNameLength {
+		errs = append(errs, fmt.Errorf("name: must have 1 to %d characters, got %d", MaxNameLength, n))
+	}
+	if n := utf8.RuneCountInString(request.ChatBarText); n == 0 || n > MaxChatBarTextLength {
+		errs = append(errs, fmt.Errorf("chatBarText: must have 1 to %d characters, got %d", MaxChatBarTextLength, n))
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateImage checks the image of a rich menu of the given size before it
+// is uploaded with SetRichMenuImage: it must be a JPEG or PNG image of at most
+// MaxImageSize bytes with the dimensions of the menu. It returns the content
+// type of the image.
+func ValidateImage(data []byte, size messaging_api.RichMenuSize) (string, error) {
+	if len(data) > MaxImageSize {
+		return "", fmt.Errorf("image: must have at most %d bytes, got %d", MaxImageSize, len(data))
+	}
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("image: must be a JPEG or PNG image: %w", err)
+	}
+	var contentType string
+	switch format {
+	case "jpeg":
+		contentType = "image/jpeg"
+	case "png":
+		contentType = "image/png"
+	default:
+		return "", fmt.Errorf("image: must be a JPEG or PNG image, got %s", format)
+	}
+	if int64(config.Width) != size.Width || int64(config.Height) != size.Height {
+		return "", fmt.Errorf("image: must be %dx%d, got %dx%d", size.Width, size.Height, config.Width, config.Height)
+	}
+	return contentType, nil
+}
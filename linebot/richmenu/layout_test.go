@@ -0,0 +1,148 @@
+package richmenu
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"strings"
+	"testing"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+func TestTemplateBounds(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		template Template
+		size     messaging_api.RichMenuSize
+		want     string
+	}{
+		{
+			name:     "grid 6",
+			template: TemplateGrid6,
+			size:     SizeLarge,
+			want:     "[{0 0 833 843} {833 0 833 843} {1666 0 834 843} {0 843 833 843} {833 843 833 843} {1666 843 834 843}]",
+		},
+		{
+			name:     "one plus three",
+			template: TemplateOnePlusThree,
+			size:     SizeLarge,
+			want:     "[{0 0 2500 843} {0 843 833 843} {833 843 833 843} {1666 843 834 843}]",
+		},
+		{
+			name:     "wide left",
+			template: TemplateWideLeft,
+			size:     SizeCompact,
+			want:     "[{0 0 1666 843} {1666 0 834 843}]",
+		},
+		{
+			name:     "custom",
+			template: Template{Rows: []int{1, 2}, Columns: [][]int{{1, 1}, {1, 1, 1}}},
+			size:     messaging_api.RichMenuSize{Width: 1200, Height: 810},
+			want:     "[{0 0 600 270} {600 0 600 270} {0 270 400 540} {400 270 400 540} {800 270 400 540}]",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			bounds, err := tc.template.Bounds(tc.size)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := fmt.Sprint(bounds); got != tc.want {
+				t.Errorf("got %s, want %s", got, tc.want)
+			}
+			actions := make([]messaging_api.ActionInterface, tc.template.Len())
+			for i := range actions {
+				actions[i] = &messaging_api.PostbackAction{Data: fmt.Sprint(i)}
+			}
+			areas, err := tc.template.Areas(tc.size, actions...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := ValidateAreas(tc.size, areas); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestTemplateErrors(t *testing.T) {
+	for _, tc := range []struct {
+		template Template
+		want     string
+	}{
+		{Template{}, "template must have at least one row and columns for each of its 0 rows, got 0"},
+		{Grid(3, 7), "template must have at most 20 areas, got 21"},
+		{Template{Rows: []int{1}, Columns: [][]int{{1, 0}}}, "columns[0]: weights must be positive, got 0"},
+	} {
+		if _, err := tc.template.Bounds(SizeLarge); err == nil || err.Error() != tc.want {
+			t.Errorf("got %v, want %s", err, tc.want)
+		}
+	}
+	if _, err := TemplateGrid4.Areas(SizeLarge, &messaging_api.PostbackAction{}); err == nil || err.Error() != "template has 4 areas, got 1 actions" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateRequest(t *testing.T) {
+	action := &messaging_api.MessageAction{Text: "a"}
+	err := ValidateRequest(&messaging_api.RichMenuRequest{
+		Size:        &messaging_api.RichMenuSize{Width: 2600, Height: 1800},
+		Name:        "menu",
+		ChatBarText: strings.Repeat("a", 15),
+		Areas: []messaging_api.RichMenuArea{
+			{Bounds: &messaging_api.RichMenuBounds{Width: 1300, Height: 1800}, Action: action},
+			{Bounds: &messaging_api.RichMenuBounds{X: 1200, Width: 1400, Height: 1800}, Action: action},
+			{Bounds: &messaging_api.RichMenuBounds{X: 2000, Y: 1000, Width: 1000, Height: 100}},
+			{Bounds: &messaging_api.RichMenuBounds{Width: 0, Height: 10}, Action: action},
+		},
+	})
+	want := `size.width: must be between 800 and 2500, got 2600
+size: the aspect ratio must be at least 1.45, got 2600x1800
+areas[1].bounds: overlaps areas[0]
+areas[2].action: must be set
+areas[2].bounds: must be within the size 2600x1800
+areas[2].bounds: overlaps areas[1]
+areas[3].bounds: must have a positive width and height, got 0x10
+chatBarText: must have 1 to 14 characters, got 15`
+	if err == nil || err.Error() != want {
+		t.Errorf("got\n%v\nwant\n%s", err, want)
+	}
+
+	if err := ValidateAreas(SizeCompact, make([]messaging_api.RichMenuArea, 21)); err == nil || !strings.HasPrefix(err.Error(), "areas: must have at most 20 areas, got 21") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateImage(t *testing.T) {
+	encode := func(encode func(*bytes.Buffer, image.Image) error, width, height int) []byte {
+		var buf bytes.Buffer
+		if err := encode(&buf, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+	jpg := func(buf *bytes.Buffer, img image.Image) error { return jpeg.Encode(buf, img, nil) }
+	gf := func(buf *bytes.Buffer, img image.Image) error { return gif.Encode(buf, img, nil) }
+
+	if contentType, err := ValidateImage(encode(jpg, 2500, 843), SizeCompact); err != nil || contentType != "image/jpeg" {
+		t.Errorf("got %q, %v", contentType, err)
+	}
+	if contentType, err := ValidateImage(menuImage, SizeCompact); err != nil || contentType != "image/png" {
+		t.Errorf("got %q, %v", contentType, err)
+	}
+	for _, tc := range []struct {
+		data []byte
+		want string
+	}{
+		{encode(jpg, 2500, 1686), "image: must be 2500x843, got 2500x1686"},
+		{encode(gf, 2500, 843), "image: must be a JPEG or PNG image, got gif"},
+		{[]byte("not an image"), "image: must be a JPEG or PNG image: image: unknown format"},
+		{make([]byte, MaxImageSize+1), "image: must have at most 1048576 bytes, got 1048577"},
+	} {
+		if _, err := ValidateImage(tc.data, SizeCompact); err == nil || err.Error() != tc.want {
+			t.Errorf("got %v, want %s", err, tc.want)
+		}
+	}
+}
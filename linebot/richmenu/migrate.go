@@ -0,0 +1,244 @@
+package richmenu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+const (
+	// MaxBatchOperations is the maximum number of operations of a rich menu batch control.
+	MaxBatchOperations = 1000
+	// MaxBulkUsers is the maximum number of users of a bulk link or unlink.
+	MaxBulkUsers = 500
+)
+
+// ErrBatchFailed is returned when the LINE Platform reports that a rich menu batch control failed.
+var ErrBatchFailed = errors.New("rich menu batch control failed")
+
+// Progress is reported by a Migrator while it runs.
+type Progress struct {
+	// Batch is the number of the current batch, starting at 1, out of Batches.
+	Batch   int
+	Batches int
+	// RequestId is the request ID of the current batch, once it is submitted.
+	RequestId string
+	Phase     messaging_api.RichMenuBatchProgressPhase
+	// Done is the number of operations or users of the finished batches, out of Total.
+	Done  int
+	Total int
+}
+
+// BatchResult is the outcome of a batch submitted by a Migrator.
+type BatchResult struct {
+	RequestId string
+	// Operations are the operations of a rich menu batch control.
+	Operations []messaging_api.RichMenuBatchOperationInterface
+	// UserIds are the users of a bulk link or unlink.
+	UserIds []string
+	// Phase is the final phase of a rich menu batch control. The progress of
+	// a bulk link or unlink cannot be polled, so its phase is succeeded once
+	// the LINE Platform accepted it.
+	Phase         messaging_api.RichMenuBatchProgressPhase
+	AcceptedTime  time.Time
+	CompletedTime time.Time
+}
+
+// MigrationResult is the outcome of the batches submitted by a Migrator, in order.
+type MigrationResult struct {
+	Batches []BatchResult
+}
+
+// Succeeded reports whether all the batches succeeded.
+func (r *MigrationResult) Succeeded() bool {
+	for _, b := range r.Batches {
+		if b.Phase != messaging_api.RichMenuBatchProgressPhase_SUCCEEDED {
+			return false
+		}
+	}
+	return len(r.Batches) > 0
+}
+
+// Migrator moves users between rich menus with the batch control and bulk
+// link endpoints. It splits the operations and users into batches of the
+// allowed size, validates the batch controls before submitting them, and
+// waits for each batch control to complete before submitting the next one,
+// as the LINE Platform runs only one at a time.
+type Migrator struct {
+	client          *messaging_api.MessagingApiAPI
+	pollInterval    time.Duration
+	maxPollInterval time.Duration
+	progress        func(Progress)
+}
+
+// MigratorOption type
+type MigratorOption func(*Migrator)
+
+// WithPollInterval function
+// The progress of a batch control is polled after interval, then after twice
+// as long each time, up to max, until the batch control completes or the
+// context is done.
+func WithPollInterval(interval, max time.Duration) MigratorOption {
+	return func(m *Migrator) {
+		m.pollInterval = interval
+		m.maxPollInterval = max
+	}
+}
+
+// WithProgress function
+// Sets a function called after each submission and poll.
+func WithProgress(progress func(Progress)) MigratorOption {
+	return func(m *Migrator) {
+		m.progress = progress
+	}
+}
+
+// NewMigrator function
+// By default, the progress is polled after 1s, then with delays doubling up to 30s.
+func NewMigrator(client *messaging_api.MessagingApiAPI, options ...MigratorOption) *Migrator {
+	m := &Migrator{
+		client:          client,
+		pollInterval:    time.Second,
+		maxPollInterval: 30 * time.Second,
+		progress:        func(Progress) {},
+	}
+	for _, option := range options {
+		option(m)
+	}
+	return m
+}
+
+// Migrate runs the operations as rich menu batch controls of at most
+// MaxBatchOperations operations. All the batches are validated before the
+// first one is submitted. If a batch fails, the following ones are not
+// submitted and the error wraps ErrBatchFailed.
+func (m *Migrator) Migrate(ctx context.Context, operations ...messaging_api.RichMenuBatchOperationInterface) (*MigrationResult, error) {
+	if len(operations) == 0 {
+		return nil, errors.New("operations must not be empty")
+	}
+	batches := chunk(operations, MaxBatchOperations)
+	for i, operations := range batches {
+		if _, err := m.client.ValidateRichMenuBatchRequestCtx(ctx, &messaging_api.RichMenuBatchRequest{Operations: operations}); err != nil {
+			return nil, fmt.Errorf("batch %d: validate: %w", i+1, err)
+		}
+	}
+
+	result := &MigrationResult{}
+	progress := Progress{Batches: len(batches), Total: len(operations)}
+	for i, operations := range batches {
+		progress.Batch = i + 1
+		res, _, err := m.client.RichMenuBatchWithHttpInfoCtx(ctx, &messaging_api.RichMenuBatchRequest{Operations: operations})
+		if err != nil {
+			return result, fmt.Errorf("batch %d: %w", progress.Batch, err)
+		}
+		batch := BatchResult{
+			RequestId:  requestId(res),
+			Operations: operations,
+			Phase:      messaging_api.RichMenuBatchProgressPhase_ONGOING,
+		}
+		progress.RequestId, progress.Phase = batch.RequestId, batch.Phase
+		m.progress(progress)
+
+		for interval := m.pollInterval; batch.Phase == messaging_api.RichMenuBatchProgressPhase_ONGOING; interval = min(interval*2, m.maxPollInterval) {
+			if err := sleep(ctx, interval); err != nil {
+				return result, err
+			}
+			status, err := m.client.GetRichMenuBatchProgressCtx(ctx, batch.RequestId)
+			if err != nil {
+				return result, fmt.Errorf("batch %d: get progress of %s: %w", progress.Batch, batch.RequestId, err)
+			}
+			batch.Phase, batch.AcceptedTime, batch.CompletedTime = status.Phase, status.AcceptedTime, status.CompletedTime
+			if batch.Phase != messaging_api.RichMenuBatchProgressPhase_ONGOING {
+				progress.Done += len(operations)
+			}
+			progress.Phase = batch.Phase
+			m.progress(progress)
+		}
+		result.Batches = append(result.Batches, batch)
+		if batch.Phase != messaging_api.RichMenuBatchProgressPhase_SUCCEEDED {
+			return result, fmt.Errorf("batch %d: %w: request ID %s, phase %s", progress.Batch, ErrBatchFailed, batch.RequestId, batch.Phase)
+		}
+	}
+	return result, nil
+}
+
+// LinkUsers links the rich menu to the users, in bulk links of at most MaxBulkUsers users.
+func (m *Migrator) LinkUsers(ctx context.Context, richMenuId string, userIds []string) (*MigrationResult, error) {
+	return m.bulk(ctx, userIds, func(userIds []string) (*http.Response, error) {
+		res, _, err := m.client.LinkRichMenuIdToUsersWithHttpInfoCtx(ctx, &messaging_api.RichMenuBulkLinkRequest{RichMenuId: richMenuId, UserIds: userIds})
+		return res, err
+	})
+}
+
+// UnlinkUsers unlinks the rich menus of the users, in bulk unlinks of at most MaxBulkUsers users.
+func (m *Migrator) UnlinkUsers(ctx context.Context, userIds []string) (*MigrationResult, error) {
+	return m.bulk(ctx, userIds, func(userIds []string) (*http.Response, error) {
+		res, _, err := m.client.UnlinkRichMenuIdFromUsersWithHttpInfoCtx(ctx, &messaging_api.RichMenuBulkUnlinkRequest{UserIds: userIds})
+		return res, err
+	})
+}
+
+func (m *Migrator) bulk(ctx context.Context, userIds []string, send func([]string) (*http.Response, error)) (*MigrationResult, error) {
+	if len(userIds) == 0 {
+		return nil, errors.New("user IDs must not be empty")
+	}
+	for i, userId := range userIds {
+		if userId == "" {
+			return nil, fmt.Errorf("userIds[%d]: must not be empty", i)
+		}
+	}
+	batches := chunk(userIds, MaxBulkUsers)
+	result := &MigrationResult{}
+	progress := Progress{Batches: len(batches), Total: len(userIds)}
+	for i, userIds := range batches {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		progress.Batch = i + 1
+		res, err := send(userIds)
+		if err != nil {
+			return result, fmt.Errorf("batch %d: %w", progress.Batch, err)
+		}
+		batch := BatchResult{
+			RequestId: requestId(res),
+			UserIds:   userIds,
+			Phase:     messaging_api.RichMenuBatchProgressPhase_SUCCEEDED,
+		}
+		result.Batches = append(result.Batches, batch)
+		progress.RequestId, progress.Phase = batch.RequestId, batch.Phase
+		progress.Done += len(userIds)
+		m.progress(progress)
+	}
+	return result, nil
+}
+
+func chunk[T any](items []T, size int) [][]T {
+	var chunks [][]T
+	for size < len(items) {
+		chunks = append(chunks, items[:size:size])
+		items = items[size:]
+	}
+	return append(chunks, items)
+}
+
+func requestId(res *http.Response) string {
+	if res == nil {
+		return ""
+	}
+	return res.Header.Get("X-Line-Request-Id")
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
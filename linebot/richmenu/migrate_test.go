@@ -0,0 +1,175 @@
+package richmenu
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+func newMigrator(t *testing.T, handler http.HandlerFunc, progress *[]Progress) *Migrator {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client, err := messaging_api.NewMessagingApiAPI("token", messaging_api.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewMigrator(client,
+		WithPollInterval(time.Millisecond, time.Millisecond),
+		WithProgress(func(p Progress) { *progress = append(*progress, p) }),
+	)
+}
+
+func TestMigrate(t *testing.T) {
+	var calls []string
+	polls := map[string]int{}
+	var progress []Progress
+	m := newMigrator(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v2/bot/richmenu/validate/batch" || r.URL.Path == "/v2/bot/richmenu/batch":
+			var req struct {
+				Operations []json.RawMessage `json:"operations"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			calls = append(calls, fmt.Sprintf("%s %d", r.URL.Path, len(req.Operations)))
+			w.Header().Set("X-Line-Request-Id", fmt.Sprintf("req-%d", len(calls)))
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{}`))
+		case strings.HasPrefix(r.URL.Path, "/v2/bot/richmenu/progress/batch"):
+			id := r.URL.Query().Get("requestId")
+			polls[id]++
+			phase := "ongoing"
+			if polls[id] == 2 {
+				phase = "succeeded"
+			}
+			fmt.Fprintf(w, `{"phase":%q,"acceptedTime":"2024-01-01T00:00:00Z","completedTime":"2024-01-01T00:01:00Z"}`, phase)
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+	}, &progress)
+
+	operations := make([]messaging_api.RichMenuBatchOperationInterface, 1500)
+	for i := range operations {
+		operations[i] = &messaging_api.RichMenuBatchLinkOperation{From: fmt.Sprintf("from-%d", i), To: "to"}
+	}
+	result, err := m.Migrate(context.Background(), operations...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantCalls := []string{
+		"/v2/bot/richmenu/validate/batch 1000",
+		"/v2/bot/richmenu/validate/batch 500",
+		"/v2/bot/richmenu/batch 1000",
+		"/v2/bot/richmenu/batch 500",
+	}
+	if fmt.Sprint(calls) != fmt.Sprint(wantCalls) {
+		t.Errorf("got calls %v, want %v", calls, wantCalls)
+	}
+	if !result.Succeeded() || len(result.Batches) != 2 || result.Batches[1].RequestId != "req-4" || len(result.Batches[1].Operations) != 500 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if !result.Batches[0].CompletedTime.Equal(time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC)) {
+		t.Errorf("unexpected completed time: %v", result.Batches[0].CompletedTime)
+	}
+	var got []string
+	for _, p := range progress {
+		got = append(got, fmt.Sprintf("%d/%d %s %s %d/%d", p.Batch, p.Batches, p.RequestId, p.Phase, p.Done, p.Total))
+	}
+	want := []string{
+		"1/2 req-3 ongoing 0/1500",
+		"1/2 req-3 ongoing 0/1500",
+		"1/2 req-3 succeeded 1000/1500",
+		"2/2 req-4 ongoing 1000/1500",
+		"2/2 req-4 ongoing 1000/1500",
+		"2/2 req-4 succeeded 1500/1500",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got progress\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestMigrateFailed(t *testing.T) {
+	var progress []Progress
+	m := newMigrator(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/bot/richmenu/batch":
+			w.Header().Set("X-Line-Request-Id", "req")
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{}`))
+		case "/v2/bot/richmenu/progress/batch":
+			w.Write([]byte(`{"phase":"failed","acceptedTime":"2024-01-01T00:00:00Z"}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}, &progress)
+
+	result, err := m.Migrate(context.Background(), &messaging_api.RichMenuBatchUnlinkAllOperation{})
+	if !errors.Is(err, ErrBatchFailed) || err.Error() != "batch 1: rich menu batch control failed: request ID req, phase failed" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Succeeded() || result.Batches[0].Phase != messaging_api.RichMenuBatchProgressPhase_FAILED {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestMigrateInvalid(t *testing.T) {
+	var progress []Progress
+	m := newMigrator(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/bot/richmenu/validate/batch" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message":"The request body has 1 error(s)"}`))
+	}, &progress)
+
+	_, err := m.Migrate(context.Background(), &messaging_api.RichMenuBatchUnlinkOperation{})
+	var apiError *messaging_api.APIError
+	if !errors.As(err, &apiError) || apiError.StatusCode != http.StatusBadRequest {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestLinkUsers(t *testing.T) {
+	var sizes []int
+	var progress []Progress
+	m := newMigrator(t, func(w http.ResponseWriter, r *http.Request) {
+		var req messaging_api.RichMenuBulkLinkRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if r.URL.Path != "/v2/bot/richmenu/bulk/link" || req.RichMenuId != "richmenu-1" {
+			t.Errorf("unexpected request: %s %+v", r.URL, req)
+		}
+		sizes = append(sizes, len(req.UserIds))
+		w.Header().Set("X-Line-Request-Id", fmt.Sprintf("req-%d", len(sizes)))
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{}`))
+	}, &progress)
+
+	userIds := make([]string, 1201)
+	for i := range userIds {
+		userIds[i] = fmt.Sprintf("U%d", i)
+	}
+	result, err := m.LinkUsers(context.Background(), "richmenu-1", userIds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(sizes) != "[500 500 201]" {
+		t.Errorf("got batches of %v", sizes)
+	}
+	if !result.Succeeded() || result.Batches[2].RequestId != "req-3" || result.Batches[2].UserIds[0] != "U1000" {
+		t.Errorf("unexpected result: %+v", result.Batches[2])
+	}
+	if last := progress[len(progress)-1]; last.Done != 1201 || last.Total != 1201 || last.Batch != 3 {
+		t.Errorf("unexpected progress: %+v", last)
+	}
+
+	if _, err := m.UnlinkUsers(context.Background(), []string{"U1", ""}); err == nil || err.Error() != "userIds[1]: must not be empty" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,441 @@
+// Package richmenu helps to lay out, deploy and roll out rich menus.
+//
+// Rich menus cannot be modified once created, so changing one means creating
+// a new menu, uploading its image, moving the aliases and the default menu
+// to it and deleting the old one. A Reconciler does this from a Spec, the
+// desired set of menus, aliases and default menu, and only creates the menus
+// whose content changed. A Template computes the areas of a menu, and a
+// Migrator moves the users linked to a menu to another one.
+package richmenu
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+// MaxNameLength is the maximum length of the name of a rich menu.
+const MaxNameLength = 300
+
+var (
+	// hashTag is appended to the names of the rich menus created by a Reconciler.
+	hashTag   = regexp.MustCompile(` \[h:([0-9a-f]{16})\]$`)
+	aliasIdRe = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+)
+
+// Menu is a rich menu of a Spec.
+type Menu struct {
+	// Key identifies the menu in the Spec.
+	Key     string
+	Request messaging_api.RichMenuRequest
+	// Image is the JPEG or PNG image of the menu.
+	Image []byte
+}
+
+// hash returns the content hash of the menu: its definition and its image.
+func (m *Menu) hash() (string, error) {
+	definition, err := json.Marshal(&m.Request)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write(definition)
+	h.Write([]byte{0})
+	h.Write(m.Image)
+	return hex.EncodeToString(h.Sum(nil))[:16], nil
+}
+
+// Spec is the desired state of the rich menus of a channel.
+type Spec struct {
+	Menus []Menu
+	// Aliases maps rich menu alias IDs to the keys of their menus.
+	Aliases map[string]string
+	// Default is the key of the default rich menu. If it is empty, the default rich menu is left as is.
+	Default string
+}
+
+func (s *Spec) validate() error {
+	var errs []error
+	keys := map[string]bool{}
+	for i, m := range s.Menus {
+		switch {
+		case m.Key == "":
+			errs = append(errs, fmt.Errorf("menus[%d]: key must not be empty", i))
+		case keys[m.Key]:
+			errs = append(errs, fmt.Errorf("menus[%d]: duplicate key %q", i, m.Key))
+		}
+		keys[m.Key] = true
+		errs = append(errs, prefixed(fmt.Sprintf("menu %q: ", m.Key), ValidateRequest(&m.Request))...)
+		if n := len([]rune(m.Request.Name)); n > MaxNameLength-len(" [h:0123456789abcdef]") {
+			errs = append(errs, fmt.Errorf("menu %q: name must have at most %d characters to leave room for the content hash, got %d",
+				m.Key, MaxNameLength-len(" [h:0123456789abcdef]"), n))
+		}
+		if m.Request.Size != nil {
+			if _, err := ValidateImage(m.Image, *m.Request.Size); err != nil {
+				errs = append(errs, fmt.Errorf("menu %q: %w", m.Key, err))
+			}
+		}
+	}
+	for _, aliasId := range sortedKeys(s.Aliases) {
+		if !aliasIdRe.MatchString(aliasId) {
+			errs = append(errs, fmt.Errorf("alias %q: must have 1 to 32 lowercase letters, digits, hyphens or underscores", aliasId))
+		}
+		if !keys[s.Aliases[aliasId]] {
+			errs = append(errs, fmt.Errorf("alias %q: unknown menu %q", aliasId, s.Aliases[aliasId]))
+		}
+	}
+	if s.Default != "" && !keys[s.Default] {
+		errs = append(errs, fmt.Errorf("default: unknown menu %q", s.Default))
+	}
+	return errors.Join(errs...)
+}
+
+// StepKind is the kind of a Step.
+type StepKind string
+
+// StepKind constants
+const (
+	StepCreateMenu  StepKind = "create menu"
+	StepCreateAlias StepKind = "create alias"
+	StepUpdateAlias StepKind = "update alias"
+	StepSetDefault  StepKind = "set default"
+	StepDeleteAlias StepKind = "delete alias"
+	StepDeleteMenu  StepKind = "delete menu"
+)
+
+// Step is a change of a Plan.
+type Step struct {
+	Kind StepKind
+	// Key is the key of the menu that is created, or that the alias or the default is moved to.
+	Key string
+	// AliasId is the alias that is created, updated or deleted.
+	AliasId string
+	// RichMenuId is the menu that is deleted, or the menu the alias or the default is moved from.
+	RichMenuId string
+}
+
+func (s Step) String() string {
+	switch s.Kind {
+	case StepCreateMenu:
+		return fmt.Sprintf("create menu %q", s.Key)
+	case StepCreateAlias:
+		return fmt.Sprintf("create alias %q -> %q", s.AliasId, s.Key)
+	case StepUpdateAlias:
+		return fmt.Sprintf("update alias %q: %s -> %q", s.AliasId, s.RichMenuId, s.Key)
+	case StepSetDefault:
+		if s.RichMenuId == "" {
+			return fmt.Sprintf("set default -> %q", s.Key)
+		}
+		return fmt.Sprintf("set default: %s -> %q", s.RichMenuId, s.Key)
+	case StepDeleteAlias:
+		return fmt.Sprintf("delete alias %q", s.AliasId)
+	case StepDeleteMenu:
+		return fmt.Sprintf("delete menu %s", s.RichMenuId)
+	}
+	return string(s.Kind)
+}
+
+// Plan is the changes that make the rich menus of a channel match a Spec, in order.
+type Plan struct {
+	Steps []Step
+	// RichMenuIds maps the keys of the menus to their rich menu IDs. The
+	// menus that have yet to be created are missing until the plan is applied.
+	RichMenuIds map[string]string
+	// Kept lists the menus created by a Reconciler that are no longer in the
+	// Spec but are not deleted, because an alias outside of the Spec or the
+	// default rich menu refers to them.
+	Kept []string
+
+	menus map[string]*Menu
+	names map[string]string
+}
+
+// Empty reports whether the rich menus already match the Spec.
+func (p *Plan) Empty() bool {
+	return len(p.Steps) == 0
+}
+
+func (p *Plan) String() string {
+	if p.Empty() {
+		return "no changes\n"
+	}
+	var sb strings.Builder
+	for _, step := range p.Steps {
+		sb.WriteString(step.String())
+		sb.WriteByte('\n')
+	}
+	for _, id := range p.Kept {
+		fmt.Fprintf(&sb, "keep menu %s: still referred to\n", id)
+	}
+	return sb.String()
+}
+
+// Reconciler makes the rich menus of a channel match a Spec.
+//
+// It tags the names of the menus it creates with the hash of their content,
+// so that unchanged menus are found with GetRichMenuList without downloading
+// their images. Menus without the tag are never modified nor deleted.
+//
+// The changes are ordered so that users never see a missing menu: all the
+// new menus are created and their images uploaded first, then the aliases
+// and the default rich menu are moved, and the menus created by a Reconciler
+// that are no longer used are deleted last. If creating a menu fails, the
+// menus created so far are deleted and nothing is moved.
+type Reconciler struct {
+	client       *messaging_api.MessagingApiAPI
+	blob         *messaging_api.MessagingApiBlobAPI
+	dryRun       io.Writer
+	pruneAliases bool
+}
+
+// ReconcilerOption type
+type ReconcilerOption func(*Reconciler)
+
+// WithDryRun function
+// Makes Reconcile print the plan to w instead of applying it.
+func WithDryRun(w io.Writer) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.dryRun = w
+	}
+}
+
+// WithPruneAliases function
+// Deletes the aliases outside of the Spec that refer to menus created by a
+// Reconciler that are no longer used, so that those menus can be deleted.
+func WithPruneAliases() ReconcilerOption {
+	return func(r *Reconciler) {
+		r.pruneAliases = true
+	}
+}
+
+// NewReconciler function
+func NewReconciler(client *messaging_api.MessagingApiAPI, blob *messaging_api.MessagingApiBlobAPI, options ...ReconcilerOption) *Reconciler {
+	r := &Reconciler{
+		client: client,
+		blob:   blob,
+	}
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
+
+// Plan returns the changes that Reconcile would make.
+func (r *Reconciler) Plan(ctx context.Context, spec *Spec) (*Plan, error) {
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+	menus, err := r.client.GetRichMenuListCtx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get rich menu list: %w", err)
+	}
+	aliases, err := r.client.GetRichMenuAliasListCtx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get rich menu alias list: %w", err)
+	}
+	var defaultId string
+	if resp, err := r.client.GetDefaultRichMenuIdCtx(ctx); err == nil {
+		defaultId = resp.RichMenuId
+	} else if !messaging_api.IsNotFound(err) {
+		return nil, fmt.Errorf("get default rich menu ID: %w", err)
+	}
+
+	plan := &Plan{
+		RichMenuIds: map[string]string{},
+		menus:       map[string]*Menu{},
+		names:       map[string]string{},
+	}
+
+	// The managed menus by content hash, and the menus that are still used.
+	existing := map[string]string{}
+	managed := map[string]bool{}
+	for _, m := range menus.Richmenus {
+		if match := hashTag.FindStringSubmatch(m.Name); match != nil {
+			managed[m.RichMenuId] = true
+			if _, ok := existing[match[1]]; !ok {
+				existing[match[1]] = m.RichMenuId
+			}
+		}
+	}
+	used := map[string]bool{}
+	for i := range spec.Menus {
+		m := &spec.Menus[i]
+		hash, err := m.hash()
+		if err != nil {
+			return nil, fmt.Errorf("menu %q: %w", m.Key, err)
+		}
+		plan.menus[m.Key] = m
+		plan.names[m.Key] = fmt.Sprintf("%s [h:%s]", m.Request.Name, hash)
+		if id, ok := existing[hash]; ok {
+			plan.RichMenuIds[m.Key] = id
+			used[id] = true
+		} else {
+			plan.Steps = append(plan.Steps, Step{Kind: StepCreateMenu, Key: m.Key})
+		}
+	}
+
+	current := map[string]string{}
+	for _, a := range aliases.Aliases {
+		current[a.RichMenuAliasId] = a.RichMenuId
+	}
+	for _, aliasId := range sortedKeys(spec.Aliases) {
+		key := spec.Aliases[aliasId]
+		from, ok := current[aliasId]
+		switch {
+		case !ok:
+			plan.Steps = append(plan.Steps, Step{Kind: StepCreateAlias, AliasId: aliasId, Key: key})
+		case from != plan.RichMenuIds[key]:
+			plan.Steps = append(plan.Steps, Step{Kind: StepUpdateAlias, AliasId: aliasId, Key: key, RichMenuId: from})
+		}
+	}
+	if spec.Default != "" {
+		if id, ok := plan.RichMenuIds[spec.Default]; !ok || id != defaultId {
+			plan.Steps = append(plan.Steps, Step{Kind: StepSetDefault, Key: spec.Default, RichMenuId: defaultId})
+		}
+	} else if defaultId != "" {
+		used[defaultId] = true
+	}
+
+	// The menus still referred to by aliases outside of the Spec are kept, unless the aliases are pruned.
+	for _, aliasId := range sortedKeys(current) {
+		id := current[aliasId]
+		if _, ok := spec.Aliases[aliasId]; ok || !managed[id] || used[id] {
+			continue
+		}
+		if r.pruneAliases {
+			plan.Steps = append(plan.Steps, Step{Kind: StepDeleteAlias, AliasId: aliasId, RichMenuId: id})
+		} else {
+			used[id] = true
+		}
+	}
+	inSpec := map[string]bool{}
+	for _, id := range plan.RichMenuIds {
+		inSpec[id] = true
+	}
+	for _, m := range menus.Richmenus {
+		if !managed[m.RichMenuId] || inSpec[m.RichMenuId] {
+			continue
+		}
+		if used[m.RichMenuId] {
+			plan.Kept = append(plan.Kept, m.RichMenuId)
+			continue
+		}
+		plan.Steps = append(plan.Steps, Step{Kind: StepDeleteMenu, RichMenuId: m.RichMenuId})
+	}
+	return plan, nil
+}
+
+// Reconcile makes the rich menus of the channel match spec, and returns the applied plan.
+// With WithDryRun, it only prints the plan.
+//
+// If it fails after the menus are created, such as while moving an alias,
+// the remaining changes are not applied and nothing is deleted, so running
+// it again completes the changes.
+func (r *Reconciler) Reconcile(ctx context.Context, spec *Spec) (*Plan, error) {
+	plan, err := r.Plan(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	if r.dryRun != nil {
+		_, err := io.WriteString(r.dryRun, plan.String())
+		return plan, err
+	}
+	return plan, r.Apply(ctx, plan)
+}
+
+// Apply applies a plan returned by Plan. It fills in the rich menu IDs of the created menus.
+func (r *Reconciler) Apply(ctx context.Context, plan *Plan) error {
+	var created []string
+	for _, step := range plan.Steps {
+		if step.Kind != StepCreateMenu {
+			continue
+		}
+		id, err := r.create(ctx, plan.menus[step.Key], plan.names[step.Key])
+		if id != "" {
+			created = append(created, id)
+		}
+		if err != nil {
+			// Roll back, so that a failed deployment leaves no unused menus behind.
+			for _, id := range created {
+				if _, deleteErr := r.client.DeleteRichMenuCtx(ctx, id); deleteErr != nil {
+					err = errors.Join(err, fmt.Errorf("delete rich menu %s: %w", id, deleteErr))
+				}
+			}
+			return fmt.Errorf("%s: %w", step, err)
+		}
+		plan.RichMenuIds[step.Key] = id
+	}
+
+	for _, step := range plan.Steps {
+		var err error
+		id := plan.RichMenuIds[step.Key]
+		switch step.Kind {
+		case StepCreateAlias:
+			_, err = r.client.CreateRichMenuAliasCtx(ctx, &messaging_api.CreateRichMenuAliasRequest{RichMenuAliasId: step.AliasId, RichMenuId: id})
+		case StepUpdateAlias:
+			_, err = r.client.UpdateRichMenuAliasCtx(ctx, step.AliasId, &messaging_api.UpdateRichMenuAliasRequest{RichMenuId: id})
+		case StepSetDefault:
+			_, err = r.client.SetDefaultRichMenuCtx(ctx, id)
+		case StepDeleteAlias:
+			_, err = r.client.DeleteRichMenuAliasCtx(ctx, step.AliasId)
+		case StepDeleteMenu:
+			_, err = r.client.DeleteRichMenuCtx(ctx, step.RichMenuId)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", step, err)
+		}
+	}
+	return nil
+}
+
+// create creates the menu and uploads its image. It returns the ID of the menu if it was created, even on error.
+func (r *Reconciler) create(ctx context.Context, m *Menu, name string) (string, error) {
+	contentType, err := ValidateImage(m.Image, *m.Request.Size)
+	if err != nil {
+		return "", err
+	}
+	request := m.Request
+	request.Name = name
+	resp, err := r.client.CreateRichMenuCtx(ctx, &request)
+	if err != nil {
+		return "", err
+	}
+	if _, err := r.blob.SetRichMenuImageCtx(ctx, resp.RichMenuId, contentType, bytes.NewReader(m.Image)); err != nil {
+		return resp.RichMenuId, fmt.Errorf("set rich menu image: %w", err)
+	}
+	return resp.RichMenuId, nil
+}
+
+// prefixed returns the errors joined in err, with the prefix.
+func prefixed(prefix string, err error) []error {
+	if err == nil {
+		return nil
+	}
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	for i, err := range errs {
+		errs[i] = fmt.Errorf("%s%w", prefix, err)
+	}
+	return errs
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,296 @@
+package richmenu
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+// fakeServer keeps the rich menus, aliases and default rich menu of a channel.
+type fakeServer struct {
+	t       *testing.T
+	menus   []messaging_api.RichMenuResponse
+	images  map[string]string
+	aliases map[string]string
+	def     string
+	next    int
+	calls   []string
+	fail    string
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	return &fakeServer{t: t, images: map[string]string{}, aliases: map[string]string{}}
+}
+
+func (f *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	call := r.Method + " " + r.URL.Path
+	if r.Method != http.MethodGet {
+		f.calls = append(f.calls, call)
+	}
+	if f.fail != "" && strings.HasPrefix(call, f.fail) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"message":"failure"}`))
+		return
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v2/bot/richmenu/list", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(messaging_api.RichMenuListResponse{Richmenus: f.menus})
+	})
+	mux.HandleFunc("GET /v2/bot/richmenu/alias/list", func(w http.ResponseWriter, r *http.Request) {
+		var resp messaging_api.RichMenuAliasListResponse
+		for aliasId, id := range f.aliases {
+			resp.Aliases = append(resp.Aliases, messaging_api.RichMenuAliasResponse{RichMenuAliasId: aliasId, RichMenuId: id})
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+	mux.HandleFunc("GET /v2/bot/user/all/richmenu", func(w http.ResponseWriter, r *http.Request) {
+		if f.def == "" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"no default rich menu"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(messaging_api.RichMenuIdResponse{RichMenuId: f.def})
+	})
+	mux.HandleFunc("POST /v2/bot/richmenu", func(w http.ResponseWriter, r *http.Request) {
+		var req messaging_api.RichMenuRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			f.t.Error(err)
+		}
+		f.next++
+		id := fmt.Sprintf("richmenu-%d", f.next)
+		f.menus = append(f.menus, messaging_api.RichMenuResponse{RichMenuId: id, Name: req.Name, Size: req.Size, ChatBarText: req.ChatBarText})
+		json.NewEncoder(w).Encode(messaging_api.RichMenuIdResponse{RichMenuId: id})
+	})
+	mux.HandleFunc("POST /v2/bot/richmenu/{id}/{content}", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		f.images[r.PathValue("id")] = r.Header.Get("Content-Type") + ":" + string(body)
+		w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("POST /v2/bot/richmenu/alias", func(w http.ResponseWriter, r *http.Request) {
+		var req messaging_api.CreateRichMenuAliasRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		f.aliases[req.RichMenuAliasId] = req.RichMenuId
+		w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("POST /v2/bot/richmenu/alias/{aliasId}", func(w http.ResponseWriter, r *http.Request) {
+		var req messaging_api.UpdateRichMenuAliasRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		f.aliases[r.PathValue("aliasId")] = req.RichMenuId
+		w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("DELETE /v2/bot/richmenu/alias/{aliasId}", func(w http.ResponseWriter, r *http.Request) {
+		delete(f.aliases, r.PathValue("aliasId"))
+		w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("DELETE /v2/bot/richmenu/{id}", func(w http.ResponseWriter, r *http.Request) {
+		for i, m := range f.menus {
+			if m.RichMenuId == r.PathValue("id") {
+				f.menus = append(f.menus[:i], f.menus[i+1:]...)
+				break
+			}
+		}
+		w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("POST /v2/bot/user/all/richmenu/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.def = r.PathValue("id")
+		w.Write([]byte(`{}`))
+	})
+	mux.ServeHTTP(w, r)
+}
+
+func newReconciler(t *testing.T, f *fakeServer, options ...ReconcilerOption) *Reconciler {
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+	client, err := messaging_api.NewMessagingApiAPI("token", messaging_api.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob, err := messaging_api.NewMessagingApiBlobAPI("token", messaging_api.WithBlobEndpoint(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewReconciler(client, blob, options...)
+}
+
+// menuImage is a blank PNG image of a compact rich menu.
+var menuImage = func() []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 2500, 843))); err != nil {
+		panic(err)
+	}
+	return buf.Bytes()
+}()
+
+func menu(key, chatBarText string) Menu {
+	return Menu{
+		Key: key,
+		Request: messaging_api.RichMenuRequest{
+			Size:        &messaging_api.RichMenuSize{Width: 2500, Height: 843},
+			Name:        key,
+			ChatBarText: chatBarText,
+			Areas: []messaging_api.RichMenuArea{{
+				Bounds: &messaging_api.RichMenuBounds{Width: 2500, Height: 843},
+				Action: &messaging_api.RichMenuSwitchAction{RichMenuAliasId: "b", Data: "b"},
+			}},
+		},
+		Image: menuImage,
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	f := newFakeServer(t)
+	f.menus = append(f.menus, messaging_api.RichMenuResponse{RichMenuId: "manual", Name: "manual"})
+	r := newReconciler(t, f)
+	ctx := context.Background()
+
+	spec := &Spec{
+		Menus:   []Menu{menu("a", "Menu"), menu("b", "Menu")},
+		Aliases: map[string]string{"a": "a", "b": "b"},
+		Default: "a",
+	}
+	plan, err := r.Reconcile(ctx, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `create menu "a"
+create menu "b"
+create alias "a" -> "a"
+create alias "b" -> "b"
+set default -> "a"
+`
+	if plan.String() != want {
+		t.Errorf("got plan\n%s\nwant\n%s", plan, want)
+	}
+	if f.aliases["a"] != "richmenu-1" || f.aliases["b"] != "richmenu-2" || f.def != "richmenu-1" {
+		t.Errorf("unexpected state: aliases %v, default %s", f.aliases, f.def)
+	}
+	if f.images["richmenu-1"] != "image/png:"+string(menuImage) {
+		t.Errorf("unexpected image: %.20q", f.images["richmenu-1"])
+	}
+	if plan.RichMenuIds["b"] != "richmenu-2" {
+		t.Errorf("unexpected IDs: %v", plan.RichMenuIds)
+	}
+
+	// Nothing changed.
+	f.calls = nil
+	plan, err = r.Reconcile(ctx, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Empty() || len(f.calls) != 0 {
+		t.Errorf("got plan %s and calls %v, want no changes", plan, f.calls)
+	}
+
+	// Only the changed menu is created, and the old one is deleted after the alias moved.
+	spec.Menus[1] = menu("b", "Other")
+	f.calls = nil
+	if _, err := r.Reconcile(ctx, spec); err != nil {
+		t.Fatal(err)
+	}
+	wantCalls := []string{
+		"POST /v2/bot/richmenu",
+		"POST /v2/bot/richmenu/richmenu-3/content",
+		"POST /v2/bot/richmenu/alias/b",
+		"DELETE /v2/bot/richmenu/richmenu-2",
+	}
+	if fmt.Sprint(f.calls) != fmt.Sprint(wantCalls) {
+		t.Errorf("got calls %v, want %v", f.calls, wantCalls)
+	}
+	if len(f.menus) != 3 || f.menus[0].RichMenuId != "manual" {
+		t.Errorf("unexpected menus: %v", f.menus)
+	}
+}
+
+func TestReconcileDryRun(t *testing.T) {
+	f := newFakeServer(t)
+	f.menus = []messaging_api.RichMenuResponse{{RichMenuId: "old", Name: "old [h:0123456789abcdef]"}}
+	f.aliases["legacy"] = "old"
+	var out strings.Builder
+	r := newReconciler(t, f, WithDryRun(&out))
+
+	if _, err := r.Reconcile(context.Background(), &Spec{Menus: []Menu{menu("a", "Menu")}, Default: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	want := `create menu "a"
+set default -> "a"
+keep menu old: still referred to
+`
+	if out.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", out.String(), want)
+	}
+	if len(f.calls) != 0 {
+		t.Errorf("unexpected calls in a dry run: %v", f.calls)
+	}
+
+	plan, err := NewReconciler(r.client, r.blob, WithPruneAliases()).Plan(context.Background(), &Spec{Menus: []Menu{menu("a", "Menu")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = `create menu "a"
+delete alias "legacy"
+delete menu old
+`
+	if plan.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", plan, want)
+	}
+}
+
+func TestReconcileRollback(t *testing.T) {
+	f := newFakeServer(t)
+	f.fail = "POST /v2/bot/richmenu/richmenu-2/content"
+	r := newReconciler(t, f)
+
+	_, err := r.Reconcile(context.Background(), &Spec{
+		Menus:   []Menu{menu("a", "Menu"), menu("b", "Menu")},
+		Default: "a",
+	})
+	if err == nil || !strings.HasPrefix(err.Error(), `create menu "b": set rich menu image:`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(f.menus) != 0 || f.def != "" {
+		t.Errorf("menus were not rolled back: %v, default %q", f.menus, f.def)
+	}
+}
+
+func TestCreateValidatesImageFirst(t *testing.T) {
+	f := newFakeServer(t)
+	r := newReconciler(t, f)
+
+	m := menu("a", "Menu")
+	m.Image = []byte("not an image")
+	if id, err := r.create(context.Background(), &m, "a"); err == nil || id != "" {
+		t.Errorf("got %q, %v, want an image error", id, err)
+	}
+	if len(f.calls) != 0 || len(f.menus) != 0 {
+		t.Errorf("the menu was created before its image was validated: %v", f.calls)
+	}
+}
+
+func TestSpecValidate(t *testing.T) {
+	err := (&Spec{
+		Menus:   []Menu{menu("a", "Menu"), menu("a", "Menu"), {Key: ""}},
+		Aliases: map[string]string{"Bad alias": "a", "c": "c"},
+		Default: "d",
+	}).validate()
+	want := `menus[1]: duplicate key "a"
+menus[2]: key must not be empty
+menu "": size: must be set
+menu "": name: must have 1 to 300 characters, got 0
+menu "": chatBarText: must have 1 to 14 characters, got 0
+alias "Bad alias": must have 1 to 32 lowercase letters, digits, hyphens or underscores
+alias "c": unknown menu "c"
+default: unknown menu "d"`
+	if err == nil || err.Error() != want {
+		t.Errorf("got\n%v\nwant\n%s", err, want)
+	}
+}
@@ -32,24 +32,27 @@ import (
 	"path"
 
 	"github.com/line/line-bot-sdk-go/v8/linebot"
+	"github.com/line/line-bot-sdk-go/v8/linebot/channel_access_token"
+	"github.com/line/line-bot-sdk-go/v8/linebot/ratelimit"
+	"github.com/line/line-bot-sdk-go/v8/linebot/retry"
 )
 
 type ShopAPI struct {
 	httpClient   *http.Client
 	endpoint     *url.URL
 	channelToken string
+	tokenSource  channel_access_token.TokenSource
 	ctx          context.Context
+	retryPolicy  *retry.Policy
+	rateLimiter  *ratelimit.Limiter
 }
 
 // ShopAPIOption type
 type ShopAPIOption func(*ShopAPI) error
 
 // New returns a new bot client instance.
+// channelToken may be empty if a TokenSource is given with WithTokenSource.
 func NewShopAPI(channelToken string, options ...ShopAPIOption) (*ShopAPI, error) {
-	if channelToken == "" {
-		return nil, errors.New("missing channel access token")
-	}
-
 	c := &ShopAPI{
 		channelToken: channelToken,
 		httpClient:   http.DefaultClient,
@@ -67,24 +70,61 @@ func NewShopAPI(channelToken string, options ...ShopAPIOption) (*ShopAPI, error)
 			return nil, err
 		}
 	}
+	if c.channelToken == "" && c.tokenSource == nil {
+		return nil, errors.New("missing channel access token")
+	}
 	return c, nil
 }
 
 // WithContext method
+// The context is stored on the client and shared by every call made through it.
+// To use a different context for each call, use the methods with the Ctx suffix instead.
 func (call *ShopAPI) WithContext(ctx context.Context) *ShopAPI {
 	call.ctx = ctx
 	return call
 }
 
 func (client *ShopAPI) Do(req *http.Request) (*http.Response, error) {
-	if client.channelToken != "" {
+	if client.ctx != nil {
+		req = req.WithContext(client.ctx)
+	}
+	return client.do(req)
+}
+
+// do sends req with the context it already carries.
+func (client *ShopAPI) do(req *http.Request) (*http.Response, error) {
+	if client.retryPolicy != nil {
+		return client.retryPolicy.Do(req, client.send)
+	}
+	return client.send(req)
+}
+
+// send sends a single attempt of req.
+func (client *ShopAPI) send(req *http.Request) (*http.Response, error) {
+	if client.rateLimiter != nil {
+		if err := client.rateLimiter.Wait(req); err != nil {
+			return nil, err
+		}
+	}
+	if client.tokenSource != nil {
+		token, err := client.tokenSource.Token(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else if client.channelToken != "" {
 		req.Header.Set("Authorization", "Bearer "+client.channelToken)
 	}
 	req.Header.Set("User-Agent", "LINE-BotSDK-Go/"+linebot.GetVersion())
+	return client.httpClient.Do(req)
+}
+
+// requestContext returns the context set by WithContext, or context.Background if there is none.
+func (client *ShopAPI) requestContext() context.Context {
 	if client.ctx != nil {
-		req = req.WithContext(client.ctx)
+		return client.ctx
 	}
-	return client.httpClient.Do(req)
+	return context.Background()
 }
 
 func (client *ShopAPI) Url(endpointPath string) string {
@@ -102,6 +142,34 @@ func WithHTTPClient(c *http.Client) ShopAPIOption {
 	}
 }
 
+// WithTokenSource function
+// Every request uses a token obtained from ts instead of the channel access token given to NewShopAPI.
+func WithTokenSource(ts channel_access_token.TokenSource) ShopAPIOption {
+	return func(client *ShopAPI) error {
+		client.tokenSource = ts
+		return nil
+	}
+}
+
+// WithRetryPolicy function
+// Failed requests are retried according to policy. See the retry package for which requests are retried.
+func WithRetryPolicy(policy *retry.Policy) ShopAPIOption {
+	return func(client *ShopAPI) error {
+		client.retryPolicy = policy
+		return nil
+	}
+}
+
+// WithRateLimiter function
+// Every request, including retries, waits for limiter before it is sent.
+// Share one limiter between the clients of the same channel.
+func WithRateLimiter(limiter *ratelimit.Limiter) ShopAPIOption {
+	return func(client *ShopAPI) error {
+		client.rateLimiter = limiter
+		return nil
+	}
+}
+
 // WithEndpoint function
 func WithEndpoint(endpoint string) ShopAPIOption {
 	return func(client *ShopAPI) error {
@@ -133,6 +201,20 @@ func (client *ShopAPI) MissionStickerV3(
 	return body, error
 }
 
+// MissionStickerV3Ctx
+// The same as MissionStickerV3, but the request uses ctx instead of the context set by WithContext.
+func (client *ShopAPI) MissionStickerV3Ctx(
+	ctx context.Context,
+	missionStickerRequest *MissionStickerRequest,
+
+) (struct{}, error) {
+	_, body, error := client.MissionStickerV3WithHttpInfoCtx(
+		ctx,
+		missionStickerRequest,
+	)
+	return body, error
+}
+
 // MissionStickerV3
 // If you want to take advantage of the HTTPResponse object for status codes and headers, use this signature.
 //
@@ -145,6 +227,19 @@ func (client *ShopAPI) MissionStickerV3WithHttpInfo(
 
 	missionStickerRequest *MissionStickerRequest,
 
+) (*http.Response, struct{}, error) {
+	return client.MissionStickerV3WithHttpInfoCtx(
+		client.requestContext(),
+		missionStickerRequest,
+	)
+}
+
+// MissionStickerV3WithHttpInfoCtx
+// The same as MissionStickerV3WithHttpInfo, but the request uses ctx instead of the context set by WithContext.
+func (client *ShopAPI) MissionStickerV3WithHttpInfoCtx(
+	ctx context.Context,
+	missionStickerRequest *MissionStickerRequest,
+
 ) (*http.Response, struct{}, error) {
 	path := "/shop/v3/mission"
 
@@ -153,13 +248,13 @@ func (client *ShopAPI) MissionStickerV3WithHttpInfo(
 	if err := enc.Encode(missionStickerRequest); err != nil {
 		return nil, struct{}{}, err
 	}
-	req, err := http.NewRequest(http.MethodPost, client.Url(path), &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.Url(path), &buf)
 	if err != nil {
 		return nil, struct{}{}, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	res, err := client.Do(req)
+	res, err := client.do(req)
 
 	if err != nil {
 		return res, struct{}{}, err
@@ -172,7 +267,7 @@ func (client *ShopAPI) MissionStickerV3WithHttpInfo(
 			return res, struct{}{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 		res.Body = io.NopCloser(bodyReader)
-		return res, struct{}{}, fmt.Errorf("unexpected status code: %d, %s", res.StatusCode, string(bodyBytes))
+		return res, struct{}{}, newAPIError(res, bodyBytes)
 	}
 
 	defer res.Body.Close()
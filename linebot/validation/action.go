@@ -0,0 +1,91 @@
+package validation
+
+import (
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+// datetimeLayouts are the formats of the initial, max and min of a datetime picker, by mode.
+var datetimeLayouts = map[messaging_api.DatetimePickerActionMODE]string{
+	messaging_api.DatetimePickerActionMODE_DATE:     "2006-01-02",
+	messaging_api.DatetimePickerActionMODE_TIME:     "15:04",
+	messaging_api.DatetimePickerActionMODE_DATETIME: "2006-01-02T15:04",
+}
+
+// actions checks that there are min to max actions.
+func (v *validator) actions(path string, actions []messaging_api.ActionInterface, min, max, maxLabel int) {
+	if len(actions) < min || len(actions) > max {
+		if min == max {
+			v.add(path, "must have %d actions, got %d", min, len(actions))
+		} else {
+			v.add(path, "must have %d to %d actions, got %d", min, max, len(actions))
+		}
+	}
+	for i, a := range actions {
+		v.action(index(path, i), a, maxLabel, true)
+	}
+}
+
+// action checks an action of a message, whose label has at most maxLabel characters.
+func (v *validator) action(path string, a messaging_api.ActionInterface, maxLabel int, labelRequired bool) {
+	var label string
+	if p, ok := as[messaging_api.PostbackAction](a); ok {
+		label = p.Label
+		v.length(join(path, "data"), p.Data, 1, 300)
+		v.maxLength(join(path, "displayText"), p.DisplayText, 300)
+		v.maxLength(join(path, "text"), p.Text, 300)
+		if p.DisplayText != "" && p.Text != "" {
+			v.add(join(path, "text"), "must not be set with displayText")
+		}
+		v.enum(join(path, "inputOption"), string(p.InputOption), "closeRichMenu", "openRichMenu", "openKeyboard", "openVoice")
+		v.maxLength(join(path, "fillInText"), p.FillInText, 300)
+	} else if m, ok := as[messaging_api.MessageAction](a); ok {
+		label = m.Label
+		v.length(join(path, "text"), m.Text, 1, 300)
+	} else if u, ok := as[messaging_api.UriAction](a); ok {
+		label = u.Label
+		v.uri(join(path, "uri"), u.Uri)
+		if u.AltUri != nil {
+			v.uri(join(join(path, "altUri"), "desktop"), u.AltUri.Desktop)
+		}
+	} else if d, ok := as[messaging_api.DatetimePickerAction](a); ok {
+		label = d.Label
+		v.length(join(path, "data"), d.Data, 1, 300)
+		layout, ok := datetimeLayouts[d.Mode]
+		if !ok {
+			v.add(join(path, "mode"), "must be date, time or datetime, got %q", d.Mode)
+		}
+		for _, p := range []struct{ name, value string }{{"initial", d.Initial}, {"max", d.Max}, {"min", d.Min}} {
+			if ok && p.value != "" {
+				if _, err := time.Parse(layout, p.value); err != nil {
+					v.add(join(path, p.name), "must be in the format %s", layout)
+				}
+			}
+		}
+	} else if c, ok := as[messaging_api.CameraAction](a); ok {
+		label = c.Label
+	} else if c, ok := as[messaging_api.CameraRollAction](a); ok {
+		label = c.Label
+	} else if l, ok := as[messaging_api.LocationAction](a); ok {
+		label = l.Label
+	} else if c, ok := as[messaging_api.ClipboardAction](a); ok {
+		label = c.Label
+		v.length(join(path, "clipboardText"), c.ClipboardText, 1, 1000)
+	} else if _, ok := as[messaging_api.RichMenuSwitchAction](a); ok {
+		v.add(join(path, "type"), "richmenuswitch is only allowed in rich menus")
+		return
+	} else if a == nil {
+		v.add(path, "must not be empty")
+		return
+	} else {
+		v.add(join(path, "type"), "unknown action type %q", a.GetType())
+		return
+	}
+
+	if labelRequired {
+		v.length(join(path, "label"), label, 1, maxLabel)
+	} else {
+		v.maxLength(join(path, "label"), label, maxLabel)
+	}
+}
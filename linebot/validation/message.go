@@ -0,0 +1,320 @@
+package validation
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"unicode/utf16"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/flex"
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+const (
+	maxEmojis        = 20
+	maxSubstitutions = 100
+	maxTemplateAlt   = 400
+	maxImagemapAlt   = 1500
+	maxFlexAlt       = 1500
+	maxActionLabel   = 20
+	maxImagemapAreas = 50
+	imagemapWidth    = 1040
+	maxCarouselCols  = 10
+)
+
+var substitutionKey = regexp.MustCompile(`^[A-Za-z0-9_]{1,20}$`)
+
+func (v *validator) message(path string, m messaging_api.MessageInterface) {
+	if t, ok := as[messaging_api.TextMessage](m); ok {
+		v.text(path, t)
+		v.common(path, t.QuickReply, t.Sender)
+	} else if t, ok := as[messaging_api.TextMessageV2](m); ok {
+		v.textV2(path, t)
+		v.common(path, t.QuickReply, t.Sender)
+	} else if s, ok := as[messaging_api.StickerMessage](m); ok {
+		v.required(join(path, "packageId"), s.PackageId)
+		v.required(join(path, "stickerId"), s.StickerId)
+		v.common(path, s.QuickReply, s.Sender)
+	} else if i, ok := as[messaging_api.ImageMessage](m); ok {
+		v.httpsURL(join(path, "originalContentUrl"), i.OriginalContentUrl, MaxURLLength, true)
+		v.httpsURL(join(path, "previewImageUrl"), i.PreviewImageUrl, MaxURLLength, true)
+		v.common(path, i.QuickReply, i.Sender)
+	} else if video, ok := as[messaging_api.VideoMessage](m); ok {
+		v.httpsURL(join(path, "originalContentUrl"), video.OriginalContentUrl, MaxURLLength, true)
+		v.httpsURL(join(path, "previewImageUrl"), video.PreviewImageUrl, MaxURLLength, true)
+		v.maxLength(join(path, "trackingId"), video.TrackingId, 100)
+		v.common(path, video.QuickReply, video.Sender)
+	} else if a, ok := as[messaging_api.AudioMessage](m); ok {
+		v.httpsURL(join(path, "originalContentUrl"), a.OriginalContentUrl, MaxURLLength, true)
+		if a.Duration <= 0 {
+			v.add(join(path, "duration"), "must be a positive number of milliseconds")
+		}
+		v.common(path, a.QuickReply, a.Sender)
+	} else if l, ok := as[messaging_api.LocationMessage](m); ok {
+		v.length(join(path, "title"), l.Title, 1, 100)
+		v.length(join(path, "address"), l.Address, 1, 100)
+		if l.Latitude < -90 || l.Latitude > 90 {
+			v.add(join(path, "latitude"), "must be between -90 and 90")
+		}
+		if l.Longitude < -180 || l.Longitude > 180 {
+			v.add(join(path, "longitude"), "must be between -180 and 180")
+		}
+		v.common(path, l.QuickReply, l.Sender)
+	} else if i, ok := as[messaging_api.ImagemapMessage](m); ok {
+		v.imagemap(path, i)
+		v.common(path, i.QuickReply, i.Sender)
+	} else if t, ok := as[messaging_api.TemplateMessage](m); ok {
+		v.length(join(path, "altText"), t.AltText, 1, maxTemplateAlt)
+		v.template(join(path, "template"), t.Template)
+		v.common(path, t.QuickReply, t.Sender)
+	} else if f, ok := as[messaging_api.FlexMessage](m); ok {
+		v.length(join(path, "altText"), f.AltText, 1, maxFlexAlt)
+		v.flex(join(path, "contents"), f.Contents)
+		v.common(path, f.QuickReply, f.Sender)
+	} else if c, ok := as[messaging_api.CouponMessage](m); ok {
+		v.required(join(path, "couponId"), c.CouponId)
+		v.common(path, c.QuickReply, c.Sender)
+	} else if m == nil {
+		v.add(path, "must not be empty")
+	} else {
+		v.add(join(path, "type"), "unknown message type %q", m.GetType())
+	}
+}
+
+// common checks the properties shared by all messages.
+func (v *validator) common(path string, quickReply *messaging_api.QuickReply, sender *messaging_api.Sender) {
+	if quickReply != nil {
+		v.quickReply(join(path, "quickReply"), quickReply)
+	}
+	if sender != nil {
+		path := join(path, "sender")
+		v.maxLength(join(path, "name"), sender.Name, 20)
+		v.httpsURL(join(path, "iconUrl"), sender.IconUrl, MaxURLLength, false)
+	}
+}
+
+func (v *validator) quickReply(path string, q *messaging_api.QuickReply) {
+	items := join(path, "items")
+	if len(q.Items) == 0 || len(q.Items) > MaxQuickReplyItems {
+		v.add(items, "must have 1 to %d items, got %d", MaxQuickReplyItems, len(q.Items))
+	}
+	for i, item := range q.Items {
+		path := index(items, i)
+		v.httpsURL(join(path, "imageUrl"), item.ImageUrl, MaxURLLength, false)
+		v.action(join(path, "action"), item.Action, maxActionLabel, true)
+	}
+}
+
+func (v *validator) text(path string, t *messaging_api.TextMessage) {
+	v.length(join(path, "text"), t.Text, 1, MaxTextLength)
+	emojis := join(path, "emojis")
+	if len(t.Emojis) > maxEmojis {
+		v.add(emojis, "must have at most %d emojis, got %d", maxEmojis, len(t.Emojis))
+	}
+	// The indexes of emojis are in UTF-16 code units.
+	text := utf16.Encode([]rune(t.Text))
+	for i, e := range t.Emojis {
+		path := index(emojis, i)
+		if e.Index < 0 || int(e.Index) >= len(text) || text[e.Index] != '$' {
+			v.add(join(path, "index"), "must be the index of a $ in the text")
+		}
+		v.required(join(path, "productId"), e.ProductId)
+		v.required(join(path, "emojiId"), e.EmojiId)
+	}
+}
+
+func (v *validator) textV2(path string, t *messaging_api.TextMessageV2) {
+	v.length(join(path, "text"), t.Text, 1, MaxTextLength)
+	keys, err := messaging_api.TextV2Placeholders(t.Text)
+	if err != nil {
+		v.add(join(path, "text"), "%v", err)
+	}
+	for _, key := range keys {
+		if _, ok := t.Substitution[key]; !ok {
+			v.add(join(path, "text"), "{%s} has no substitution", key)
+		}
+	}
+
+	substitution := join(path, "substitution")
+	if len(t.Substitution) > maxSubstitutions {
+		v.add(substitution, "must have at most %d entries, got %d", maxSubstitutions, len(t.Substitution))
+	}
+	names := make([]string, 0, len(t.Substitution))
+	for key := range t.Substitution {
+		names = append(names, key)
+	}
+	slices.Sort(names)
+	for _, key := range names {
+		path := join(substitution, key)
+		if !substitutionKey.MatchString(key) {
+			v.add(path, "key must have 1 to 20 letters, digits or underscores")
+		}
+		if !slices.Contains(keys, key) {
+			v.add(path, "is not used in the text")
+		}
+		s := t.Substitution[key]
+		if m, ok := as[messaging_api.MentionSubstitutionObject](s); ok {
+			if u, ok := as[messaging_api.UserMentionTarget](m.Mentionee); ok {
+				v.required(join(join(path, "mentionee"), "userId"), u.UserId)
+			} else if _, ok := as[messaging_api.AllMentionTarget](m.Mentionee); !ok {
+				v.add(join(path, "mentionee"), "must be a user or all")
+			}
+		} else if e, ok := as[messaging_api.EmojiSubstitutionObject](s); ok {
+			v.required(join(path, "productId"), e.ProductId)
+			v.required(join(path, "emojiId"), e.EmojiId)
+		} else {
+			v.add(path, "must be a mention or an emoji")
+		}
+	}
+}
+
+func (v *validator) imagemap(path string, m *messaging_api.ImagemapMessage) {
+	v.httpsURL(join(path, "baseUrl"), m.BaseUrl, MaxURLLength, true)
+	v.length(join(path, "altText"), m.AltText, 1, maxImagemapAlt)
+
+	var width, height int32
+	if m.BaseSize == nil {
+		v.add(join(path, "baseSize"), "must not be empty")
+	} else {
+		width, height = m.BaseSize.Width, m.BaseSize.Height
+		if width != imagemapWidth {
+			v.add(join(join(path, "baseSize"), "width"), "must be %d, got %d", imagemapWidth, width)
+		}
+		if height <= 0 {
+			v.add(join(join(path, "baseSize"), "height"), "must be positive")
+		}
+	}
+
+	actions := join(path, "actions")
+	if len(m.Actions) > maxImagemapAreas {
+		v.add(actions, "must have at most %d actions, got %d", maxImagemapAreas, len(m.Actions))
+	}
+	for i, a := range m.Actions {
+		path := index(actions, i)
+		if u, ok := as[messaging_api.UriImagemapAction](a); ok {
+			v.uri(join(path, "linkUri"), u.LinkUri)
+			v.area(join(path, "area"), u.Area, width, height)
+		} else if msg, ok := as[messaging_api.MessageImagemapAction](a); ok {
+			v.length(join(path, "text"), msg.Text, 1, 400)
+			v.area(join(path, "area"), msg.Area, width, height)
+		} else if c, ok := as[messaging_api.ClipboardImagemapAction](a); ok {
+			v.length(join(path, "clipboardText"), c.ClipboardText, 1, 1000)
+			v.area(join(path, "area"), c.Area, width, height)
+		} else if a == nil {
+			v.add(path, "must not be empty")
+		} else {
+			v.add(join(path, "type"), "unknown imagemap action type %q", a.GetType())
+		}
+	}
+
+	if video := m.Video; video != nil {
+		path := join(path, "video")
+		v.httpsURL(join(path, "originalContentUrl"), video.OriginalContentUrl, MaxURLLength, true)
+		v.httpsURL(join(path, "previewImageUrl"), video.PreviewImageUrl, MaxURLLength, true)
+		v.area(join(path, "area"), video.Area, width, height)
+		if link := video.ExternalLink; link != nil {
+			path := join(path, "externalLink")
+			v.uri(join(path, "linkUri"), link.LinkUri)
+			v.length(join(path, "label"), link.Label, 1, 30)
+		}
+	}
+}
+
+// area checks that a is within an imagemap of width and height, if they are known.
+func (v *validator) area(path string, a *messaging_api.ImagemapArea, width, height int32) {
+	if a == nil {
+		v.add(path, "must not be empty")
+		return
+	}
+	if a.X < 0 || a.Y < 0 || a.Width <= 0 || a.Height <= 0 {
+		v.add(path, "must have a non-negative position and a positive size")
+		return
+	}
+	if width > 0 && height > 0 && (a.X+a.Width > width || a.Y+a.Height > height) {
+		v.add(path, "must be within the base size %dx%d", width, height)
+	}
+}
+
+func (v *validator) template(path string, t messaging_api.TemplateInterface) {
+	if b, ok := as[messaging_api.ButtonsTemplate](t); ok {
+		v.httpsURL(join(path, "thumbnailImageUrl"), b.ThumbnailImageUrl, MaxURLLength, false)
+		v.enum(join(path, "imageAspectRatio"), b.ImageAspectRatio, "rectangle", "square")
+		v.enum(join(path, "imageSize"), b.ImageSize, "cover", "contain")
+		v.maxLength(join(path, "title"), b.Title, 40)
+		maxText := 160
+		if b.ThumbnailImageUrl != "" || b.Title != "" {
+			maxText = 60
+		}
+		v.length(join(path, "text"), b.Text, 1, maxText)
+		if b.DefaultAction != nil {
+			v.action(join(path, "defaultAction"), b.DefaultAction, maxActionLabel, false)
+		}
+		v.actions(join(path, "actions"), b.Actions, 1, 4, maxActionLabel)
+	} else if c, ok := as[messaging_api.ConfirmTemplate](t); ok {
+		v.length(join(path, "text"), c.Text, 1, 240)
+		v.actions(join(path, "actions"), c.Actions, 2, 2, maxActionLabel)
+	} else if c, ok := as[messaging_api.CarouselTemplate](t); ok {
+		v.enum(join(path, "imageAspectRatio"), c.ImageAspectRatio, "rectangle", "square")
+		v.enum(join(path, "imageSize"), c.ImageSize, "cover", "contain")
+		columns := join(path, "columns")
+		if len(c.Columns) == 0 || len(c.Columns) > maxCarouselCols {
+			v.add(columns, "must have 1 to %d columns, got %d", maxCarouselCols, len(c.Columns))
+		}
+		for i, column := range c.Columns {
+			path := index(columns, i)
+			v.httpsURL(join(path, "thumbnailImageUrl"), column.ThumbnailImageUrl, MaxURLLength, false)
+			v.maxLength(join(path, "title"), column.Title, 40)
+			maxText := 120
+			if column.ThumbnailImageUrl != "" || column.Title != "" {
+				maxText = 60
+			}
+			v.length(join(path, "text"), column.Text, 1, maxText)
+			if column.DefaultAction != nil {
+				v.action(join(path, "defaultAction"), column.DefaultAction, maxActionLabel, false)
+			}
+			v.actions(join(path, "actions"), column.Actions, 1, 3, maxActionLabel)
+			// All the columns must have the same layout.
+			first := c.Columns[0]
+			if len(column.Actions) != len(first.Actions) {
+				v.add(join(path, "actions"), "must have as many actions as the first column")
+			}
+			if (column.ThumbnailImageUrl == "") != (first.ThumbnailImageUrl == "") {
+				v.add(join(path, "thumbnailImageUrl"), "must be set in all the columns or none")
+			}
+			if (column.Title == "") != (first.Title == "") {
+				v.add(join(path, "title"), "must be set in all the columns or none")
+			}
+		}
+	} else if c, ok := as[messaging_api.ImageCarouselTemplate](t); ok {
+		columns := join(path, "columns")
+		if len(c.Columns) == 0 || len(c.Columns) > maxCarouselCols {
+			v.add(columns, "must have 1 to %d columns, got %d", maxCarouselCols, len(c.Columns))
+		}
+		for i, column := range c.Columns {
+			path := index(columns, i)
+			v.httpsURL(join(path, "imageUrl"), column.ImageUrl, MaxURLLength, true)
+			v.action(join(path, "action"), column.Action, 12, false)
+		}
+	} else if t == nil {
+		v.add(path, "must not be empty")
+	} else {
+		v.add(join(path, "type"), "unknown template type %q", t.GetType())
+	}
+}
+
+func (v *validator) flex(path string, c messaging_api.FlexContainerInterface) {
+	if c == nil {
+		v.add(path, "must not be empty")
+		return
+	}
+	var errs flex.ValidationErrors
+	if err := flex.Validate(c); errors.As(err, &errs) {
+		for _, e := range errs {
+			property := path
+			if e.Property != "" {
+				property = path + "." + e.Property
+			}
+			v.errs = append(v.errs, &Error{Property: property, Message: e.Message})
+		}
+	}
+}
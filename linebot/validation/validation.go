@@ -0,0 +1,251 @@
+// Package validation checks messages against the documented limits of the
+// Messaging API, without calling the LINE Platform.
+//
+// Unlike the ValidatePush, ValidateReply, ValidateMulticast,
+// ValidateNarrowcast and ValidateBroadcast endpoints, it needs neither a
+// network nor a channel access token, so it can be used in unit tests. The
+// errors have the same properties as the messaging_api.ErrorDetail returned
+// by the LINE Platform, such as "messages[0].text". Lengths are counted in
+// characters.
+//
+// The LINE Platform may enforce limits that cannot be checked locally, such
+// as the size and format of images, so passing validation does not
+// guarantee that a message is accepted.
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+const (
+	// MaxMessages is the maximum number of messages in a request.
+	MaxMessages = 5
+	// MaxMulticastRecipients is the maximum number of user IDs of a multicast.
+	MaxMulticastRecipients = 500
+	// MaxTextLength is the maximum length of the text of a text message.
+	MaxTextLength = 5000
+	// MaxQuickReplyItems is the maximum number of quick reply buttons.
+	MaxQuickReplyItems = 13
+	// MaxURLLength is the maximum length of the URLs of images, videos and audio.
+	MaxURLLength = 2000
+)
+
+// Error is a violation of a limit of the Messaging API.
+type Error struct {
+	// Property is the JSON path of the offending property, in the format of
+	// messaging_api.ErrorDetail.Property, such as "messages[0].text".
+	Property string
+	Message  string
+}
+
+func (e *Error) Error() string {
+	if e.Property == "" {
+		return e.Message
+	}
+	return e.Property + ": " + e.Message
+}
+
+// Errors holds all the violations found in a request or a message.
+type Errors []*Error
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Details returns the errors in the format of the details of an error response of the LINE Platform.
+func (e Errors) Details() []messaging_api.ErrorDetail {
+	details := make([]messaging_api.ErrorDetail, len(e))
+	for i, err := range e {
+		details[i] = messaging_api.ErrorDetail{Property: err.Property, Message: err.Message}
+	}
+	return details
+}
+
+// Message checks a message. The properties of the errors are relative to the message, such as "text".
+func Message(message messaging_api.MessageInterface) error {
+	v := &validator{}
+	v.message("", message)
+	return v.result()
+}
+
+// Messages checks the messages of a request: 1 to MaxMessages messages. The
+// properties of the errors start with "messages".
+func Messages(messages []messaging_api.MessageInterface) error {
+	v := &validator{}
+	v.messages(messages)
+	return v.result()
+}
+
+// PushMessageRequest checks a request of PushMessage.
+func PushMessageRequest(r *messaging_api.PushMessageRequest) error {
+	v := &validator{}
+	v.required("to", r.To)
+	v.messages(r.Messages)
+	v.customAggregationUnits(r.CustomAggregationUnits)
+	return v.result()
+}
+
+// ReplyMessageRequest checks a request of ReplyMessage.
+func ReplyMessageRequest(r *messaging_api.ReplyMessageRequest) error {
+	v := &validator{}
+	v.required("replyToken", r.ReplyToken)
+	v.messages(r.Messages)
+	return v.result()
+}
+
+// MulticastRequest checks a request of Multicast.
+func MulticastRequest(r *messaging_api.MulticastRequest) error {
+	v := &validator{}
+	v.messages(r.Messages)
+	if len(r.To) == 0 || len(r.To) > MaxMulticastRecipients {
+		v.add("to", "must have 1 to %d user IDs, got %d", MaxMulticastRecipients, len(r.To))
+	}
+	for i, to := range r.To {
+		v.required(index("to", i), to)
+	}
+	v.customAggregationUnits(r.CustomAggregationUnits)
+	return v.result()
+}
+
+// NarrowcastRequest checks a request of Narrowcast.
+func NarrowcastRequest(r *messaging_api.NarrowcastRequest) error {
+	v := &validator{}
+	v.messages(r.Messages)
+	return v.result()
+}
+
+// BroadcastRequest checks a request of Broadcast.
+func BroadcastRequest(r *messaging_api.BroadcastRequest) error {
+	v := &validator{}
+	v.messages(r.Messages)
+	return v.result()
+}
+
+type validator struct {
+	errs Errors
+}
+
+func (v *validator) result() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+func (v *validator) add(property, format string, args ...any) {
+	v.errs = append(v.errs, &Error{Property: property, Message: fmt.Sprintf(format, args...)})
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func index(path string, i int) string {
+	return fmt.Sprintf("%s[%d]", path, i)
+}
+
+// as returns v as a *T if it is a T or a non-nil *T. The generated types are used both ways.
+func as[T any](v any) (*T, bool) {
+	switch t := v.(type) {
+	case T:
+		return &t, true
+	case *T:
+		return t, t != nil
+	}
+	return nil, false
+}
+
+func (v *validator) messages(messages []messaging_api.MessageInterface) {
+	if len(messages) == 0 || len(messages) > MaxMessages {
+		v.add("messages", "must have 1 to %d messages, got %d", MaxMessages, len(messages))
+	}
+	for i, m := range messages {
+		v.message(index("messages", i), m)
+	}
+}
+
+func (v *validator) customAggregationUnits(units []string) {
+	if len(units) > 1 {
+		v.add("customAggregationUnits", "must have at most 1 unit, got %d", len(units))
+	}
+	for i, unit := range units {
+		v.length(index("customAggregationUnits", i), unit, 1, 30)
+	}
+}
+
+func (v *validator) required(property, s string) {
+	if s == "" {
+		v.add(property, "must not be empty")
+	}
+}
+
+// length checks that s has min to max characters.
+func (v *validator) length(property, s string, min, max int) {
+	n := utf8.RuneCountInString(s)
+	switch {
+	case n == 0 && min > 0:
+		v.add(property, "must not be empty")
+	case n < min || n > max:
+		v.add(property, "must have %d to %d characters, got %d", min, max, n)
+	}
+}
+
+// maxLength checks that s has at most max characters.
+func (v *validator) maxLength(property, s string, max int) {
+	v.length(property, s, 0, max)
+}
+
+// httpsURL checks that s is an HTTPS URL of at most max characters.
+func (v *validator) httpsURL(property, s string, max int, required bool) {
+	if s == "" {
+		if required {
+			v.add(property, "must not be empty")
+		}
+		return
+	}
+	if !strings.HasPrefix(s, "https://") {
+		v.add(property, "must be an HTTPS URL")
+	}
+	v.maxLength(property, s, max)
+}
+
+// uri checks the URI of an action: an http, https, line or tel URI of at most 1000 characters.
+func (v *validator) uri(property, s string) {
+	if s == "" {
+		v.add(property, "must not be empty")
+		return
+	}
+	scheme, _, ok := strings.Cut(s, ":")
+	switch strings.ToLower(scheme) {
+	case "http", "https", "line", "tel":
+	default:
+		ok = false
+	}
+	if !ok {
+		v.add(property, "must be an http, https, line or tel URI")
+	}
+	v.maxLength(property, s, 1000)
+}
+
+func (v *validator) enum(property, value string, allowed ...string) {
+	if value == "" {
+		return
+	}
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.add(property, "must be one of %s, got %q", strings.Join(allowed, ", "), value)
+}
@@ -0,0 +1,202 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+func TestMessages(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		messages []messaging_api.MessageInterface
+		want     []string
+	}{
+		{
+			name: "valid",
+			messages: []messaging_api.MessageInterface{
+				messaging_api.TextMessage{Text: "$ hi", Emojis: []messaging_api.Emoji{{Index: 0, ProductId: "p", EmojiId: "001"}}},
+				&messaging_api.TextMessageV2{Text: "Hi {user} {{literal}}", Substitution: map[string]messaging_api.SubstitutionObjectInterface{
+					"user": &messaging_api.MentionSubstitutionObject{Mentionee: &messaging_api.AllMentionTarget{}},
+				}},
+				&messaging_api.ImageMessage{OriginalContentUrl: "https://example.com/a.jpg", PreviewImageUrl: "https://example.com/p.jpg"},
+				&messaging_api.StickerMessage{PackageId: "1", StickerId: "1"},
+				&messaging_api.CouponMessage{CouponId: "c"},
+			},
+		},
+		{
+			name:     "too many",
+			messages: make([]messaging_api.MessageInterface, 6),
+			want: []string{
+				"messages: must have 1 to 5 messages, got 6",
+				"messages[0]: must not be empty",
+				"messages[1]: must not be empty",
+				"messages[2]: must not be empty",
+				"messages[3]: must not be empty",
+				"messages[4]: must not be empty",
+				"messages[5]: must not be empty",
+			},
+		},
+		{
+			name: "text",
+			messages: []messaging_api.MessageInterface{
+				&messaging_api.TextMessage{Text: strings.Repeat("あ", 5001)},
+				&messaging_api.TextMessage{Text: "😀$", Emojis: []messaging_api.Emoji{{Index: 1, ProductId: "p", EmojiId: "001"}}},
+			},
+			want: []string{
+				"messages[0].text: must have 1 to 5000 characters, got 5001",
+				"messages[1].emojis[0].index: must be the index of a $ in the text",
+			},
+		},
+		{
+			name: "text v2",
+			messages: []messaging_api.MessageInterface{
+				&messaging_api.TextMessageV2{Text: "{a} {b}", Substitution: map[string]messaging_api.SubstitutionObjectInterface{
+					"a":     &messaging_api.EmojiSubstitutionObject{ProductId: "p"},
+					"bad-k": &messaging_api.MentionSubstitutionObject{Mentionee: &messaging_api.UserMentionTarget{UserId: "U1"}},
+				}},
+				&messaging_api.TextMessageV2{Text: "}"},
+			},
+			want: []string{
+				"messages[0].text: {b} has no substitution",
+				"messages[0].substitution.a.emojiId: must not be empty",
+				"messages[0].substitution.bad-k: key must have 1 to 20 letters, digits or underscores",
+				"messages[0].substitution.bad-k: is not used in the text",
+				"messages[1].text: unmatched }, use }} for a literal brace",
+			},
+		},
+		{
+			name: "quick reply",
+			messages: []messaging_api.MessageInterface{
+				&messaging_api.TextMessage{Text: "hi", QuickReply: &messaging_api.QuickReply{Items: []messaging_api.QuickReplyItem{
+					{ImageUrl: "http://example.com/a.png", Action: &messaging_api.MessageAction{Label: strings.Repeat("a", 21), Text: "a"}},
+					{Action: &messaging_api.CameraAction{}},
+					{Action: &messaging_api.RichMenuSwitchAction{Label: "a", RichMenuAliasId: "a", Data: "a"}},
+				}}},
+			},
+			want: []string{
+				"messages[0].quickReply.items[0].imageUrl: must be an HTTPS URL",
+				"messages[0].quickReply.items[0].action.label: must have 1 to 20 characters, got 21",
+				"messages[0].quickReply.items[1].action.label: must not be empty",
+				"messages[0].quickReply.items[2].action.type: richmenuswitch is only allowed in rich menus",
+			},
+		},
+		{
+			name: "media",
+			messages: []messaging_api.MessageInterface{
+				&messaging_api.VideoMessage{OriginalContentUrl: "http://example.com/a.mp4", PreviewImageUrl: "https://example.com/" + strings.Repeat("a", 2000)},
+				&messaging_api.AudioMessage{OriginalContentUrl: "https://example.com/a.m4a"},
+				&messaging_api.LocationMessage{Title: "a", Latitude: 91},
+			},
+			want: []string{
+				"messages[0].originalContentUrl: must be an HTTPS URL",
+				"messages[0].previewImageUrl: must have 0 to 2000 characters, got 2020",
+				"messages[1].duration: must be a positive number of milliseconds",
+				"messages[2].address: must not be empty",
+				"messages[2].latitude: must be between -90 and 90",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assertErrors(t, Messages(tc.messages), tc.want)
+		})
+	}
+}
+
+func TestTemplateMessage(t *testing.T) {
+	postback := &messaging_api.PostbackAction{Label: "Buy", Data: "action=buy"}
+	err := Message(&messaging_api.TemplateMessage{
+		AltText: strings.Repeat("a", 401),
+		Template: &messaging_api.CarouselTemplate{Columns: []messaging_api.CarouselColumn{
+			{Title: "A", Text: "a", Actions: []messaging_api.ActionInterface{postback, &messaging_api.UriAction{Label: "Open", Uri: "ftp://example.com"}}},
+			{Text: strings.Repeat("b", 61), Actions: []messaging_api.ActionInterface{postback}},
+		}},
+	})
+	assertErrors(t, err, []string{
+		"altText: must have 1 to 400 characters, got 401",
+		"template.columns[0].actions[1].uri: must be an http, https, line or tel URI",
+		"template.columns[1].actions: must have as many actions as the first column",
+		"template.columns[1].title: must be set in all the columns or none",
+	})
+
+	err = Message(&messaging_api.TemplateMessage{
+		AltText: "a",
+		Template: &messaging_api.ConfirmTemplate{Text: "Sure?", Actions: []messaging_api.ActionInterface{
+			&messaging_api.DatetimePickerAction{Label: "When", Data: "a", Mode: messaging_api.DatetimePickerActionMODE_DATE, Initial: "2024-01-01T00:00"},
+		}},
+	})
+	assertErrors(t, err, []string{
+		"template.actions: must have 2 actions, got 1",
+		"template.actions[0].initial: must be in the format 2006-01-02",
+	})
+}
+
+func TestImagemapMessage(t *testing.T) {
+	err := Message(&messaging_api.ImagemapMessage{
+		BaseUrl:  "https://example.com/imagemap",
+		AltText:  "a",
+		BaseSize: &messaging_api.ImagemapBaseSize{Width: 1040, Height: 520},
+		Actions: []messaging_api.ImagemapActionInterface{
+			&messaging_api.UriImagemapAction{LinkUri: "https://example.com", Area: &messaging_api.ImagemapArea{Width: 520, Height: 520}},
+			&messaging_api.MessageImagemapAction{Text: "a", Area: &messaging_api.ImagemapArea{X: 520, Width: 521, Height: 520}},
+		},
+	})
+	assertErrors(t, err, []string{"actions[1].area: must be within the base size 1040x520"})
+}
+
+func TestFlexMessage(t *testing.T) {
+	err := Messages([]messaging_api.MessageInterface{
+		&messaging_api.TextMessage{Text: "hi"},
+		&messaging_api.FlexMessage{AltText: "a", Contents: &messaging_api.FlexBubble{Body: &messaging_api.FlexBox{
+			Layout:   messaging_api.FlexBoxLAYOUT_VERTICAL,
+			Contents: []messaging_api.FlexComponentInterface{&messaging_api.FlexText{Text: "a", Size: "huge"}},
+		}}},
+	})
+	assertErrors(t, err, []string{`messages[1].contents.body.contents[0].size: must be one of xxs, xs, sm, md, lg, xl, xxl, 3xl, 4xl, 5xl or pixels such as 10px, got "huge"`})
+}
+
+func TestRequests(t *testing.T) {
+	hi := []messaging_api.MessageInterface{&messaging_api.TextMessage{Text: "hi"}}
+
+	assertErrors(t, PushMessageRequest(&messaging_api.PushMessageRequest{Messages: hi}), []string{"to: must not be empty"})
+	assertErrors(t, ReplyMessageRequest(&messaging_api.ReplyMessageRequest{ReplyToken: "r"}), []string{"messages: must have 1 to 5 messages, got 0"})
+	to := make([]string, 501)
+	for i := range to {
+		to[i] = "U"
+	}
+	assertErrors(t, MulticastRequest(&messaging_api.MulticastRequest{Messages: hi, To: to}), []string{"to: must have 1 to 500 user IDs, got 501"})
+	assertErrors(t, BroadcastRequest(&messaging_api.BroadcastRequest{Messages: hi}), nil)
+	assertErrors(t, NarrowcastRequest(&messaging_api.NarrowcastRequest{Messages: hi}), nil)
+
+	var errs Errors
+	if err := PushMessageRequest(&messaging_api.PushMessageRequest{Messages: hi}); !errors.As(err, &errs) {
+		t.Fatalf("got %T, want Errors", err)
+	}
+	if details := errs.Details(); len(details) != 1 || details[0].Property != "to" || details[0].Message != "must not be empty" {
+		t.Errorf("unexpected details: %+v", details)
+	}
+}
+
+func assertErrors(t *testing.T, err error, want []string) {
+	t.Helper()
+	if len(want) == 0 {
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		return
+	}
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("got %v, want Errors", err)
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("got %v, want %q", errs, want)
+	}
+	for i, e := range errs {
+		if e.Error() != want[i] {
+			t.Errorf("got %q, want %q", e, want[i])
+		}
+	}
+}
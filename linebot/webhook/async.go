@@ -0,0 +1,247 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// ErrWorkerPoolClosed is returned by Enqueue after Shutdown has been called.
+	ErrWorkerPoolClosed = errors.New("webhook: worker pool is shut down")
+	// ErrWorkerPoolFull is returned by EnqueueAll when the queues cannot hold all the events.
+	ErrWorkerPoolFull = errors.New("webhook: worker pool is full")
+)
+
+type job struct {
+	ctx   context.Context
+	event EventInterface
+	// release cancels ctx and frees its resources.
+	release func()
+}
+
+// WorkerPool runs an EventHandler for webhook events on a bounded number of goroutines.
+//
+// Events from the same source, that is the same user, group or room, are
+// handled one at a time in the order they were enqueued. Events from
+// different sources are handled concurrently.
+type WorkerPool struct {
+	handler     EventHandler
+	concurrency int
+	queueSize   int
+	handleError func(error, EventInterface)
+
+	queues []chan job
+	next   atomic.Uint64
+	wg     sync.WaitGroup
+
+	// ctx is canceled when Shutdown gives up waiting for the events in flight.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// mu guards closed and the sends to the queues. space is signaled when
+	// a worker takes an event from its queue.
+	mu     sync.Mutex
+	space  *sync.Cond
+	closed bool
+}
+
+// WorkerPoolOption type
+type WorkerPoolOption func(*WorkerPool)
+
+// NewWorkerPool function
+func NewWorkerPool(handler EventHandler, options ...WorkerPoolOption) *WorkerPool {
+	p := &WorkerPool{
+		handler:     handler,
+		concurrency: 10,
+		queueSize:   100,
+	}
+	for _, option := range options {
+		option(p)
+	}
+	if p.concurrency < 1 {
+		p.concurrency = 1
+	}
+	if p.queueSize < 1 {
+		p.queueSize = 1
+	}
+
+	p.space = sync.NewCond(&p.mu)
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+	p.queues = make([]chan job, p.concurrency)
+	for i := range p.queues {
+		p.queues[i] = make(chan job, p.queueSize)
+		p.wg.Add(1)
+		go p.work(p.queues[i])
+	}
+	return p
+}
+
+// WithConcurrency function
+// Sets the number of events handled at the same time. The default is 10.
+func WithConcurrency(n int) WorkerPoolOption {
+	return func(p *WorkerPool) {
+		p.concurrency = n
+	}
+}
+
+// WithQueueSize function
+// Sets the number of events each worker holds before Enqueue blocks. The default is 100, and the minimum is 1.
+func WithQueueSize(n int) WorkerPoolOption {
+	return func(p *WorkerPool) {
+		p.queueSize = n
+	}
+}
+
+// WithEventErrorHandler function
+// Sets the function called with the errors returned by the handler, and with its panics.
+func WithEventErrorHandler(f func(error, EventInterface)) WorkerPoolOption {
+	return func(p *WorkerPool) {
+		p.handleError = f
+	}
+}
+
+// Enqueue queues event to be handled. It blocks while the queue of the
+// event's source is full, until ctx is done.
+//
+// The handler is called with a context that carries the values of ctx, but
+// is only canceled when Shutdown stops waiting for the events in flight.
+func (p *WorkerPool) Enqueue(ctx context.Context, event EventInterface) error {
+	return p.enqueue(ctx, []EventInterface{event}, true)
+}
+
+// EnqueueAll queues all the events, or none of them. It does not block: if
+// the queues cannot hold all the events at once, it returns ErrWorkerPoolFull
+// without queueing any, so that the events can be redelivered as a whole
+// without being handled twice.
+//
+// See Enqueue for the context passed to the handler.
+func (p *WorkerPool) EnqueueAll(ctx context.Context, events []EventInterface) error {
+	return p.enqueue(ctx, events, false)
+}
+
+func (p *WorkerPool) enqueue(ctx context.Context, events []EventInterface, wait bool) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if wait {
+		stop := context.AfterFunc(ctx, func() {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			p.space.Broadcast()
+		})
+		defer stop()
+	}
+
+	queues := make([]chan job, len(events))
+	for i, event := range events {
+		queues[i] = p.queue(event)
+	}
+	for {
+		if p.closed {
+			return ErrWorkerPoolClosed
+		}
+		if fits(queues) {
+			break
+		}
+		if !wait {
+			return ErrWorkerPoolFull
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		p.space.Wait()
+	}
+
+	// Only the holders of p.mu send to the queues, so the sends do not block.
+	for i, event := range events {
+		jobCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		stop := context.AfterFunc(p.ctx, cancel)
+		release := func() {
+			stop()
+			cancel()
+		}
+		queues[i] <- job{ctx: jobCtx, event: event, release: release}
+	}
+	return nil
+}
+
+// fits reports whether the queues have room for one event per element.
+func fits(queues []chan job) bool {
+	needed := map[chan job]int{}
+	for _, queue := range queues {
+		needed[queue]++
+	}
+	for queue, n := range needed {
+		if cap(queue)-len(queue) < n {
+			return false
+		}
+	}
+	return true
+}
+
+// Shutdown stops accepting events and waits until the events already
+// enqueued have been handled. If ctx is done first, the contexts passed to
+// the handler are canceled and ctx.Err() is returned.
+func (p *WorkerPool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		for _, queue := range p.queues {
+			close(queue)
+		}
+		p.space.Broadcast()
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		p.cancel()
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		return ctx.Err()
+	}
+}
+
+// queue returns the queue of the worker that handles the events of the event's source.
+func (p *WorkerPool) queue(event EventInterface) chan job {
+	var i uint64
+	if id := GetSourceId(GetSource(event)); id != "" {
+		h := fnv.New64a()
+		h.Write([]byte(id))
+		i = h.Sum64()
+	} else {
+		i = p.next.Add(1)
+	}
+	return p.queues[i%uint64(len(p.queues))]
+}
+
+func (p *WorkerPool) work(queue chan job) {
+	defer p.wg.Done()
+	for j := range queue {
+		p.mu.Lock()
+		p.space.Broadcast()
+		p.mu.Unlock()
+		if err := p.handle(j); err != nil && p.handleError != nil {
+			p.handleError(err, j.event)
+		}
+	}
+}
+
+func (p *WorkerPool) handle(j job) (err error) {
+	defer j.release()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("webhook: panic in event handler: %v", r)
+		}
+	}()
+	return p.handler(j.ctx, j.event)
+}
@@ -0,0 +1,154 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DedupStore records the webhook event IDs that have been handled.
+type DedupStore interface {
+	// MarkSeen marks id as seen for ttl, and reports whether it had already
+	// been marked. It must be atomic, so that only one of several concurrent
+	// calls with the same id gets seen == false.
+	MarkSeen(ctx context.Context, id string, ttl time.Duration) (seen bool, err error)
+	// Forget unmarks id, so that the event can be handled again when it is
+	// redelivered.
+	Forget(ctx context.Context, id string) error
+}
+
+// RedeliveryHookFunc is called for every redelivered event, with whether it had been seen before.
+type RedeliveryHookFunc func(ctx context.Context, event EventInterface, seen bool)
+
+type dedup struct {
+	store      DedupStore
+	ttl        time.Duration
+	redelivery RedeliveryHookFunc
+}
+
+// DedupOption type
+type DedupOption func(*dedup)
+
+// WithDedupTTL function
+// Sets how long an event ID is remembered. The default is 24 hours.
+func WithDedupTTL(ttl time.Duration) DedupOption {
+	return func(d *dedup) {
+		d.ttl = ttl
+	}
+}
+
+// WithRedeliveryHook function
+// Sets the function called for every redelivered event.
+func WithRedeliveryHook(f RedeliveryHookFunc) DedupOption {
+	return func(d *dedup) {
+		d.redelivery = f
+	}
+}
+
+// Dedup returns a Middleware that skips the events whose webhook event ID
+// has already been seen, so that redelivered events are handled only once.
+// Events without a webhook event ID are always handled.
+//
+// An event is marked as seen before it is handled, so that concurrent
+// deliveries of the same event are not handled twice. If the handler returns
+// an error, the event is forgotten, so that it is handled again when it is
+// redelivered.
+//
+// If the store fails, the event is handled as if it had not been seen, and
+// the store error is returned along with the error of the handler.
+func Dedup(store DedupStore, options ...DedupOption) Middleware {
+	d := &dedup{
+		store: store,
+		ttl:   24 * time.Hour,
+	}
+	for _, option := range options {
+		option(d)
+	}
+	return func(next EventHandler) EventHandler {
+		return func(ctx context.Context, event EventInterface) error {
+			id := GetWebhookEventId(event)
+			if id == "" {
+				return next(ctx, event)
+			}
+			seen, err := d.store.MarkSeen(ctx, id, d.ttl)
+			if err != nil {
+				return errors.Join(err, next(ctx, event))
+			}
+			if d.redelivery != nil {
+				if dc := GetDeliveryContext(event); dc != nil && dc.IsRedelivery {
+					d.redelivery(ctx, event, seen)
+				}
+			}
+			if seen {
+				return nil
+			}
+			if err := next(ctx, event); err != nil {
+				return errors.Join(err, d.store.Forget(ctx, id))
+			}
+			return nil
+		}
+	}
+}
+
+// MemoryDedupStore is a DedupStore that keeps the event IDs in memory.
+// It only knows the events handled by the current process.
+type MemoryDedupStore struct {
+	mu        sync.Mutex
+	expiry    map[string]time.Time
+	nextSweep time.Time
+	now       func() time.Time
+}
+
+// MemoryDedupStoreOption type
+type MemoryDedupStoreOption func(*MemoryDedupStore)
+
+// WithDedupClock function
+// Sets the function that returns the current time.
+func WithDedupClock(now func() time.Time) MemoryDedupStoreOption {
+	return func(s *MemoryDedupStore) {
+		s.now = now
+	}
+}
+
+// NewMemoryDedupStore function
+func NewMemoryDedupStore(options ...MemoryDedupStoreOption) *MemoryDedupStore {
+	s := &MemoryDedupStore{
+		expiry: map[string]time.Time{},
+		now:    time.Now,
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+// MarkSeen method
+func (s *MemoryDedupStore) MarkSeen(ctx context.Context, id string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if !now.Before(s.nextSweep) {
+		for k, expiry := range s.expiry {
+			if !now.Before(expiry) {
+				delete(s.expiry, k)
+			}
+		}
+		s.nextSweep = now.Add(time.Minute)
+	}
+
+	if expiry, ok := s.expiry[id]; ok && now.Before(expiry) {
+		return true, nil
+	}
+	s.expiry[id] = now.Add(ttl)
+	return false, nil
+}
+
+// Forget method
+func (s *MemoryDedupStore) Forget(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.expiry, id)
+	return nil
+}
@@ -0,0 +1,344 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// EventHandler handles a single webhook event.
+type EventHandler func(ctx context.Context, event EventInterface) error
+
+// Middleware wraps an EventHandler, for example to log or recover from panics.
+type Middleware func(next EventHandler) EventHandler
+
+// SourceFilter reports whether an event from source should be handled.
+type SourceFilter func(source SourceInterface) bool
+
+// FromUser is a SourceFilter that accepts events from one-on-one chats.
+func FromUser(source SourceInterface) bool {
+	switch source.(type) {
+	case UserSource, *UserSource:
+		return true
+	}
+	return false
+}
+
+// FromGroup is a SourceFilter that accepts events from group chats.
+func FromGroup(source SourceInterface) bool {
+	switch source.(type) {
+	case GroupSource, *GroupSource:
+		return true
+	}
+	return false
+}
+
+// FromRoom is a SourceFilter that accepts events from multi-person chats.
+func FromRoom(source SourceInterface) bool {
+	switch source.(type) {
+	case RoomSource, *RoomSource:
+		return true
+	}
+	return false
+}
+
+type route struct {
+	// handle returns false if the event is not of the type the route handles.
+	handle  func(ctx context.Context, event EventInterface) (bool, error)
+	filters []SourceFilter
+}
+
+func (r route) accepts(event EventInterface) bool {
+	if len(r.filters) == 0 {
+		return true
+	}
+	source := GetSource(event)
+	for _, filter := range r.filters {
+		if filter(source) {
+			return true
+		}
+	}
+	return false
+}
+
+// Dispatcher routes webhook events to handlers registered by event type and,
+// for message events, by message type.
+//
+// For each event, the first registered handler that matches its type and
+// source is called. Handlers registered with OnMessage are only tried after
+// the handlers for specific message types. If no handler matches, the
+// fallback handler is called.
+type Dispatcher struct {
+	routes        []route
+	messageRoutes []route
+	middlewares   []Middleware
+	fallback      EventHandler
+	handleError   func(error, EventInterface)
+}
+
+// NewDispatcher function
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{}
+}
+
+// Use appends middlewares. The first middleware is the outermost one.
+func (d *Dispatcher) Use(middlewares ...Middleware) {
+	d.middlewares = append(d.middlewares, middlewares...)
+}
+
+// Fallback sets the handler called for events that no other handler matches.
+func (d *Dispatcher) Fallback(f EventHandler) {
+	d.fallback = f
+}
+
+// HandleError sets the function called with the errors returned by handlers in HandleEvents.
+func (d *Dispatcher) HandleError(f func(error, EventInterface)) {
+	d.handleError = f
+}
+
+// Dispatch calls the handler for event.
+func (d *Dispatcher) Dispatch(ctx context.Context, event EventInterface) error {
+	h := d.dispatch
+	for i := len(d.middlewares) - 1; i >= 0; i-- {
+		h = d.middlewares[i](h)
+	}
+	return h(ctx, event)
+}
+
+// DispatchAll calls the handlers for every event in cb in order, and returns the joined errors.
+func (d *Dispatcher) DispatchAll(ctx context.Context, cb *CallbackRequest) error {
+	var errs []error
+	for _, event := range cb.Events {
+		if err := d.Dispatch(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// HandleEvents is an EventsHandlerFunc, to be passed to WebhookHandler.HandleEvents.
+func (d *Dispatcher) HandleEvents(cb *CallbackRequest, r *http.Request) {
+	for _, event := range cb.Events {
+		if err := d.Dispatch(r.Context(), event); err != nil && d.handleError != nil {
+			d.handleError(err, event)
+		}
+	}
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, event EventInterface) error {
+	for _, routes := range [][]route{d.routes, d.messageRoutes} {
+		for _, r := range routes {
+			if !r.accepts(event) {
+				continue
+			}
+			if handled, err := r.handle(ctx, event); handled {
+				return err
+			}
+		}
+	}
+	if d.fallback != nil {
+		return d.fallback(ctx, event)
+	}
+	return nil
+}
+
+// asEvent returns event as *T, whether it holds a T or a *T.
+func asEvent[T any](event any) (*T, bool) {
+	switch v := event.(type) {
+	case T:
+		return &v, true
+	case *T:
+		return v, v != nil
+	}
+	return nil, false
+}
+
+func on[T any](d *Dispatcher, f func(context.Context, *T) error, filters []SourceFilter) {
+	d.routes = append(d.routes, route{
+		handle: func(ctx context.Context, event EventInterface) (bool, error) {
+			e, ok := asEvent[T](event)
+			if !ok {
+				return false, nil
+			}
+			return true, f(ctx, e)
+		},
+		filters: filters,
+	})
+}
+
+func onMessage[T any](d *Dispatcher, f func(context.Context, *MessageEvent, T) error, filters []SourceFilter) {
+	d.routes = append(d.routes, route{
+		handle: func(ctx context.Context, event EventInterface) (bool, error) {
+			e, ok := asEvent[MessageEvent](event)
+			if !ok {
+				return false, nil
+			}
+			message, ok := asEvent[T](e.Message)
+			if !ok {
+				return false, nil
+			}
+			return true, f(ctx, e, *message)
+		},
+		filters: filters,
+	})
+}
+
+// OnMessage registers a handler for message events of any message type
+// that no handler for a specific message type matches.
+func (d *Dispatcher) OnMessage(f func(context.Context, *MessageEvent) error, filters ...SourceFilter) {
+	d.messageRoutes = append(d.messageRoutes, route{
+		handle: func(ctx context.Context, event EventInterface) (bool, error) {
+			e, ok := asEvent[MessageEvent](event)
+			if !ok {
+				return false, nil
+			}
+			return true, f(ctx, e)
+		},
+		filters: filters,
+	})
+}
+
+// OnText method
+func (d *Dispatcher) OnText(f func(context.Context, *MessageEvent, TextMessageContent) error, filters ...SourceFilter) {
+	onMessage(d, f, filters)
+}
+
+// OnImage method
+func (d *Dispatcher) OnImage(f func(context.Context, *MessageEvent, ImageMessageContent) error, filters ...SourceFilter) {
+	onMessage(d, f, filters)
+}
+
+// OnVideo method
+func (d *Dispatcher) OnVideo(f func(context.Context, *MessageEvent, VideoMessageContent) error, filters ...SourceFilter) {
+	onMessage(d, f, filters)
+}
+
+// OnAudio method
+func (d *Dispatcher) OnAudio(f func(context.Context, *MessageEvent, AudioMessageContent) error, filters ...SourceFilter) {
+	onMessage(d, f, filters)
+}
+
+// OnFile method
+func (d *Dispatcher) OnFile(f func(context.Context, *MessageEvent, FileMessageContent) error, filters ...SourceFilter) {
+	onMessage(d, f, filters)
+}
+
+// OnLocation method
+func (d *Dispatcher) OnLocation(f func(context.Context, *MessageEvent, LocationMessageContent) error, filters ...SourceFilter) {
+	onMessage(d, f, filters)
+}
+
+// OnSticker method
+func (d *Dispatcher) OnSticker(f func(context.Context, *MessageEvent, StickerMessageContent) error, filters ...SourceFilter) {
+	onMessage(d, f, filters)
+}
+
+// OnUnknownMessage registers a handler for message events of a message type this SDK does not know.
+func (d *Dispatcher) OnUnknownMessage(f func(context.Context, *MessageEvent, UnknownMessageContent) error, filters ...SourceFilter) {
+	onMessage(d, f, filters)
+}
+
+// OnFollow method
+func (d *Dispatcher) OnFollow(f func(context.Context, *FollowEvent) error, filters ...SourceFilter) {
+	on(d, f, filters)
+}
+
+// OnUnfollow method
+func (d *Dispatcher) OnUnfollow(f func(context.Context, *UnfollowEvent) error, filters ...SourceFilter) {
+	on(d, f, filters)
+}
+
+// OnJoin method
+func (d *Dispatcher) OnJoin(f func(context.Context, *JoinEvent) error, filters ...SourceFilter) {
+	on(d, f, filters)
+}
+
+// OnLeave method
+func (d *Dispatcher) OnLeave(f func(context.Context, *LeaveEvent) error, filters ...SourceFilter) {
+	on(d, f, filters)
+}
+
+// OnMemberJoined method
+func (d *Dispatcher) OnMemberJoined(f func(context.Context, *MemberJoinedEvent) error, filters ...SourceFilter) {
+	on(d, f, filters)
+}
+
+// OnMemberLeft method
+func (d *Dispatcher) OnMemberLeft(f func(context.Context, *MemberLeftEvent) error, filters ...SourceFilter) {
+	on(d, f, filters)
+}
+
+// OnPostback method
+func (d *Dispatcher) OnPostback(f func(context.Context, *PostbackEvent) error, filters ...SourceFilter) {
+	on(d, f, filters)
+}
+
+// OnBeacon method
+func (d *Dispatcher) OnBeacon(f func(context.Context, *BeaconEvent) error, filters ...SourceFilter) {
+	on(d, f, filters)
+}
+
+// OnAccountLink method
+func (d *Dispatcher) OnAccountLink(f func(context.Context, *AccountLinkEvent) error, filters ...SourceFilter) {
+	on(d, f, filters)
+}
+
+// OnThings method
+func (d *Dispatcher) OnThings(f func(context.Context, *ThingsEvent) error, filters ...SourceFilter) {
+	on(d, f, filters)
+}
+
+// OnUnsend method
+func (d *Dispatcher) OnUnsend(f func(context.Context, *UnsendEvent) error, filters ...SourceFilter) {
+	on(d, f, filters)
+}
+
+// OnMessageEdited method
+func (d *Dispatcher) OnMessageEdited(f func(context.Context, *MessageEditedEvent) error, filters ...SourceFilter) {
+	on(d, f, filters)
+}
+
+// OnVideoPlayComplete method
+func (d *Dispatcher) OnVideoPlayComplete(f func(context.Context, *VideoPlayCompleteEvent) error, filters ...SourceFilter) {
+	on(d, f, filters)
+}
+
+// OnMembership method
+func (d *Dispatcher) OnMembership(f func(context.Context, *MembershipEvent) error, filters ...SourceFilter) {
+	on(d, f, filters)
+}
+
+// OnModule method
+func (d *Dispatcher) OnModule(f func(context.Context, *ModuleEvent) error, filters ...SourceFilter) {
+	on(d, f, filters)
+}
+
+// OnActivated method
+func (d *Dispatcher) OnActivated(f func(context.Context, *ActivatedEvent) error, filters ...SourceFilter) {
+	on(d, f, filters)
+}
+
+// OnDeactivated method
+func (d *Dispatcher) OnDeactivated(f func(context.Context, *DeactivatedEvent) error, filters ...SourceFilter) {
+	on(d, f, filters)
+}
+
+// OnBotSuspended method
+func (d *Dispatcher) OnBotSuspended(f func(context.Context, *BotSuspendedEvent) error, filters ...SourceFilter) {
+	on(d, f, filters)
+}
+
+// OnBotResumed method
+func (d *Dispatcher) OnBotResumed(f func(context.Context, *BotResumedEvent) error, filters ...SourceFilter) {
+	on(d, f, filters)
+}
+
+// OnPnpDeliveryCompletion method
+func (d *Dispatcher) OnPnpDeliveryCompletion(f func(context.Context, *PnpDeliveryCompletionEvent) error, filters ...SourceFilter) {
+	on(d, f, filters)
+}
+
+// OnUnknown registers a handler for events of a type this SDK does not know.
+func (d *Dispatcher) OnUnknown(f func(context.Context, *UnknownEvent) error) {
+	on(d, f, nil)
+}
@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// The generated event types repeat the common event properties on every
+// concrete type instead of promoting them from Event, so these helpers read
+// them from whatever event they are given.
+
+// GetSource returns the source of the event, or nil if it has none.
+func GetSource(event EventInterface) SourceInterface {
+	if f, ok := eventField(event, "Source"); ok {
+		if source, ok := f.Interface().(SourceInterface); ok {
+			return source
+		}
+	}
+	return nil
+}
+
+// GetReplyToken returns the reply token of the event, or "" if it has none.
+func GetReplyToken(event EventInterface) string {
+	if f, ok := eventField(event, "ReplyToken"); ok && f.Kind() == reflect.String {
+		return f.String()
+	}
+	return ""
+}
+
+// GetWebhookEventId returns the webhook event ID of the event.
+// For an UnknownEvent, it is read from the raw properties.
+func GetWebhookEventId(event EventInterface) string {
+	if e, ok := asEvent[UnknownEvent](event); ok {
+		var id string
+		_ = json.Unmarshal(e.Raw["webhookEventId"], &id)
+		return id
+	}
+	if f, ok := eventField(event, "WebhookEventId"); ok && f.Kind() == reflect.String {
+		return f.String()
+	}
+	return ""
+}
+
+// GetDeliveryContext returns the delivery context of the event, or nil if it has none.
+func GetDeliveryContext(event EventInterface) *DeliveryContext {
+	if f, ok := eventField(event, "DeliveryContext"); ok {
+		if deliveryContext, ok := f.Interface().(*DeliveryContext); ok {
+			return deliveryContext
+		}
+	}
+	return nil
+}
+
+// GetSourceId returns the ID of the chat the event comes from: the group ID,
+// the room ID, or the user ID for one-on-one chats.
+func GetSourceId(source SourceInterface) string {
+	switch s := source.(type) {
+	case UserSource:
+		return s.UserId
+	case *UserSource:
+		return s.UserId
+	case GroupSource:
+		return s.GroupId
+	case *GroupSource:
+		return s.GroupId
+	case RoomSource:
+		return s.RoomId
+	case *RoomSource:
+		return s.RoomId
+	}
+	return ""
+}
+
+func eventField(event EventInterface, name string) (reflect.Value, bool) {
+	v := reflect.ValueOf(event)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	f := v.FieldByName(name)
+	return f, f.IsValid()
+}
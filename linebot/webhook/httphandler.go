@@ -31,6 +31,7 @@ type WebhookHandler struct {
 
 	handleEvents EventsHandlerFunc
 	handleError  ErrorHandlerFunc
+	pool         *WorkerPool
 }
 
 // New returns a new WebhookHandler instance.
@@ -49,6 +50,14 @@ func (wh *WebhookHandler) HandleEvents(f EventsHandlerFunc) {
 	wh.handleEvents = f
 }
 
+// HandleEventsAsync method
+// Events are handed to pool, and the response is sent without waiting for them to be handled.
+// When the pool is full or shut down, none of the events of the request are handed to it,
+// and the request is answered with 503 so that it can be redelivered.
+func (wh *WebhookHandler) HandleEventsAsync(pool *WorkerPool) {
+	wh.pool = pool
+}
+
 // HandleError method
 func (wh *WebhookHandler) HandleError(f ErrorHandlerFunc) {
 	wh.handleError = f
@@ -67,5 +76,24 @@ func (wh *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
+	if wh.pool != nil {
+		enqueueEvents(w, r, events, wh.pool, wh.handleError)
+		return
+	}
 	wh.handleEvents(events, r)
 }
+
+// enqueueEvents hands all the events of the webhook to pool, or none of them,
+// so that a redelivery of the webhook does not handle any event twice.
+func enqueueEvents(w http.ResponseWriter, r *http.Request, events *CallbackRequest, pool *WorkerPool, handleError ErrorHandlerFunc) {
+	if err := pool.EnqueueAll(r.Context(), events.Events); err != nil {
+		if handleError != nil {
+			handleError(err, r)
+		}
+		if err == ErrWorkerPoolClosed || err == ErrWorkerPoolFull {
+			w.WriteHeader(503)
+		} else {
+			w.WriteHeader(500)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+)
+
+// ErrUnknownDestination is returned by a ChannelResolver that does not know the destination.
+var ErrUnknownDestination = errors.New("unknown destination")
+
+// Channel is a LINE channel served by a MultiChannelHandler.
+type Channel struct {
+	// Destination is the user ID of the bot, found in CallbackRequest.Destination.
+	Destination string
+	// ChannelSecret is used to verify the signature of the webhooks for the channel.
+	ChannelSecret string
+	// Client is the Messaging API client of the channel. It is optional.
+	Client *messaging_api.MessagingApiAPI
+}
+
+// ChannelResolver looks up channels by destination.
+type ChannelResolver interface {
+	// ResolveChannel returns the channel of destination, or ErrUnknownDestination.
+	ResolveChannel(ctx context.Context, destination string) (*Channel, error)
+}
+
+// ChannelResolverFunc type
+type ChannelResolverFunc func(ctx context.Context, destination string) (*Channel, error)
+
+// ResolveChannel method
+func (f ChannelResolverFunc) ResolveChannel(ctx context.Context, destination string) (*Channel, error) {
+	return f(ctx, destination)
+}
+
+// ChannelMap is a ChannelResolver for a fixed set of channels, keyed by destination.
+type ChannelMap map[string]*Channel
+
+// ResolveChannel method
+func (m ChannelMap) ResolveChannel(ctx context.Context, destination string) (*Channel, error) {
+	if ch, ok := m[destination]; ok {
+		return ch, nil
+	}
+	return nil, ErrUnknownDestination
+}
+
+type channelContextKey struct{}
+
+// ContextWithChannel returns a copy of ctx that carries ch.
+func ContextWithChannel(ctx context.Context, ch *Channel) context.Context {
+	return context.WithValue(ctx, channelContextKey{}, ch)
+}
+
+// ChannelFromContext returns the channel a webhook was sent for.
+// The context of the requests passed to the events handler of a
+// MultiChannelHandler, and the contexts derived from them, carry the channel.
+func ChannelFromContext(ctx context.Context) (*Channel, bool) {
+	ch, ok := ctx.Value(channelContextKey{}).(*Channel)
+	return ch, ok
+}
+
+// MultiChannelHandler is an http.Handler that receives the webhooks of many
+// channels on one endpoint. The channel is resolved by the destination of
+// the webhook, and the signature is verified with its channel secret.
+type MultiChannelHandler struct {
+	resolver ChannelResolver
+
+	handleEvents EventsHandlerFunc
+	handleError  ErrorHandlerFunc
+	pool         *WorkerPool
+}
+
+// NewMultiChannelHandler function
+func NewMultiChannelHandler(resolver ChannelResolver) (*MultiChannelHandler, error) {
+	if resolver == nil {
+		return nil, errors.New("missing channel resolver")
+	}
+	return &MultiChannelHandler{
+		resolver: resolver,
+	}, nil
+}
+
+// HandleEvents method
+// The channel of the webhook can be read from the context of the request with ChannelFromContext.
+func (h *MultiChannelHandler) HandleEvents(f EventsHandlerFunc) {
+	h.handleEvents = f
+}
+
+// HandleEventsAsync method
+// See WebhookHandler.HandleEventsAsync. The contexts passed to the handler of pool carry the channel.
+func (h *MultiChannelHandler) HandleEventsAsync(pool *WorkerPool) {
+	h.pool = pool
+}
+
+// HandleError method
+func (h *MultiChannelHandler) HandleError(f ErrorHandlerFunc) {
+	h.handleError = f
+}
+
+func (h *MultiChannelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ch, events, err := h.parseRequest(r)
+	if err != nil {
+		if h.handleError != nil {
+			h.handleError(err, r)
+		}
+		if err == ErrInvalidSignature || errors.Is(err, ErrUnknownDestination) {
+			w.WriteHeader(400)
+		} else {
+			w.WriteHeader(500)
+		}
+		return
+	}
+	r = r.WithContext(ContextWithChannel(r.Context(), ch))
+	if h.pool != nil {
+		enqueueEvents(w, r, events, h.pool, h.handleError)
+		return
+	}
+	if h.handleEvents != nil {
+		h.handleEvents(events, r)
+	}
+}
+
+func (h *MultiChannelHandler) parseRequest(r *http.Request) (*Channel, *CallbackRequest, error) {
+	defer func() { _ = r.Body.Close() }()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The destination is read before the signature can be verified, and
+	// is only trusted once the signature matches the secret of its channel.
+	var head struct {
+		Destination string `json:"destination"`
+	}
+	if err := json.Unmarshal(body, &head); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal request body: %w, %s", err, body)
+	}
+	ch, err := h.resolver.ResolveChannel(r.Context(), head.Destination)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve channel for destination %q: %w", head.Destination, err)
+	}
+	if ch == nil {
+		return nil, nil, fmt.Errorf("failed to resolve channel for destination %q: %w", head.Destination, ErrUnknownDestination)
+	}
+	if !ValidateSignature(ch.ChannelSecret, r.Header.Get("x-line-signature"), body) {
+		return nil, nil, ErrInvalidSignature
+	}
+
+	var cb CallbackRequest
+	if err = json.Unmarshal(body, &cb); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal request body: %w, %s", err, body)
+	}
+	return ch, &cb, nil
+}
@@ -0,0 +1,237 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/webhook"
+)
+
+func textEvent(userId, text string) webhook.MessageEvent {
+	return webhook.MessageEvent{
+		Source:  webhook.UserSource{UserId: userId},
+		Message: webhook.TextMessageContent{Text: text},
+	}
+}
+
+func TestWorkerPoolOrdersEventsPerSource(t *testing.T) {
+	var mu sync.Mutex
+	got := map[string][]string{}
+	pool := webhook.NewWorkerPool(func(ctx context.Context, event webhook.EventInterface) error {
+		e := event.(webhook.MessageEvent)
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		id := webhook.GetSourceId(e.Source)
+		got[id] = append(got[id], e.Message.(webhook.TextMessageContent).Text)
+		return nil
+	}, webhook.WithConcurrency(4))
+
+	for i := 0; i < 10; i++ {
+		for _, user := range []string{"U1", "U2", "U3"} {
+			if err := pool.Enqueue(context.Background(), textEvent(user, fmt.Sprint(i))); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, user := range []string{"U1", "U2", "U3"} {
+		if s := strings.Join(got[user], ","); s != "0,1,2,3,4,5,6,7,8,9" {
+			t.Errorf("events of %s handled out of order: %s", user, s)
+		}
+	}
+	if err := pool.Enqueue(context.Background(), textEvent("U1", "late")); !errors.Is(err, webhook.ErrWorkerPoolClosed) {
+		t.Errorf("got %v, want ErrWorkerPoolClosed", err)
+	}
+}
+
+func TestWorkerPoolConcurrencyAndErrors(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	var errs atomic.Int32
+	pool := webhook.NewWorkerPool(func(ctx context.Context, event webhook.EventInterface) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		panic("boom")
+	}, webhook.WithConcurrency(2), webhook.WithEventErrorHandler(func(err error, event webhook.EventInterface) {
+		errs.Add(1)
+	}))
+
+	for i := 0; i < 20; i++ {
+		if err := pool.Enqueue(context.Background(), textEvent(fmt.Sprintf("U%d", i), "hi")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if maxRunning.Load() > 2 {
+		t.Errorf("%d events handled at the same time, want at most 2", maxRunning.Load())
+	}
+	if errs.Load() != 20 {
+		t.Errorf("got %d errors, want 20", errs.Load())
+	}
+}
+
+func TestWorkerPoolShutdownTimeout(t *testing.T) {
+	canceled := make(chan struct{})
+	pool := webhook.NewWorkerPool(func(ctx context.Context, event webhook.EventInterface) error {
+		<-ctx.Done()
+		close(canceled)
+		return ctx.Err()
+	})
+	if err := pool.Enqueue(context.Background(), textEvent("U1", "hi")); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := pool.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want DeadlineExceeded", err)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Errorf("the handler context was not canceled")
+	}
+}
+
+func TestWebhookHandlerAsync(t *testing.T) {
+	const channelSecret = "testsecret"
+	body := []byte(`{"destination":"U0123456789abcdef","events":[` +
+		`{"type":"message","mode":"active","timestamp":1,"webhookEventId":"1","deliveryContext":{"isRedelivery":false},` +
+		`"replyToken":"r","source":{"type":"user","userId":"U1"},"message":{"id":"1","type":"text","quoteToken":"q","text":"hi"}}]}`)
+
+	release := make(chan struct{})
+	handled := make(chan string, 1)
+	pool := webhook.NewWorkerPool(func(ctx context.Context, event webhook.EventInterface) error {
+		<-release
+		handled <- webhook.GetReplyToken(event)
+		return nil
+	})
+
+	handler, err := webhook.NewWebhookHandler(channelSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler.HandleEventsAsync(pool)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// The response is sent while the handler is still blocked.
+	res, err := http.DefaultClient.Do(makeRequest(t, server.URL, body, generateSignature(channelSecret, body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d; want %d", res.StatusCode, http.StatusOK)
+	}
+
+	res, err = http.DefaultClient.Do(makeRequest(t, server.URL, body, "invalid"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("StatusCode = %d; want %d", res.StatusCode, http.StatusBadRequest)
+	}
+
+	close(release)
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if token := <-handled; token != "r" {
+		t.Errorf("got reply token %q", token)
+	}
+
+	res, err = http.DefaultClient.Do(makeRequest(t, server.URL, body, generateSignature(channelSecret, body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("StatusCode = %d; want %d", res.StatusCode, http.StatusServiceUnavailable)
+	}
+}
+
+func TestWorkerPoolEnqueueAllWhenFull(t *testing.T) {
+	const channelSecret = "testsecret"
+	body := []byte(`{"destination":"U0123456789abcdef","events":[` +
+		`{"type":"message","mode":"active","timestamp":1,"webhookEventId":"1","deliveryContext":{"isRedelivery":false},` +
+		`"replyToken":"r1","source":{"type":"user","userId":"U1"},"message":{"id":"1","type":"text","quoteToken":"q","text":"B"}},` +
+		`{"type":"message","mode":"active","timestamp":1,"webhookEventId":"2","deliveryContext":{"isRedelivery":false},` +
+		`"replyToken":"r2","source":{"type":"user","userId":"U1"},"message":{"id":"2","type":"text","quoteToken":"q","text":"C"}}]}`)
+
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var got []string
+	pool := webhook.NewWorkerPool(func(ctx context.Context, event webhook.EventInterface) error {
+		started <- struct{}{}
+		<-release
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, event.(webhook.MessageEvent).Message.(webhook.TextMessageContent).Text)
+		return nil
+	}, webhook.WithConcurrency(1), webhook.WithQueueSize(1))
+
+	// The worker holds A, so the queue has room for one more event.
+	if err := pool.Enqueue(context.Background(), textEvent("U1", "A")); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+
+	// Neither event of a batch that does not fit is queued.
+	events := []webhook.EventInterface{textEvent("U1", "B"), textEvent("U1", "C")}
+	if err := pool.EnqueueAll(context.Background(), events); !errors.Is(err, webhook.ErrWorkerPoolFull) {
+		t.Errorf("got %v, want ErrWorkerPoolFull", err)
+	}
+
+	handler, err := webhook.NewWebhookHandler(channelSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var handlerErr error
+	handler.HandleError(func(err error, r *http.Request) { handlerErr = err })
+	handler.HandleEventsAsync(pool)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// The webhook is answered at once, so that it is redelivered as a whole.
+	res, err := http.DefaultClient.Do(makeRequest(t, server.URL, body, generateSignature(channelSecret, body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("StatusCode = %d; want %d", res.StatusCode, http.StatusServiceUnavailable)
+	}
+	if !errors.Is(handlerErr, webhook.ErrWorkerPoolFull) {
+		t.Errorf("got %v, want ErrWorkerPoolFull", handlerErr)
+	}
+
+	if err := pool.EnqueueAll(context.Background(), events[:1]); err != nil {
+		t.Errorf("got %v, want the event that fits queued", err)
+	}
+	close(release)
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if s := strings.Join(got, ","); s != "A,B" {
+		t.Errorf("got %s, want A,B", s)
+	}
+}
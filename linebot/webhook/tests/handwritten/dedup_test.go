@@ -0,0 +1,125 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/webhook"
+)
+
+func TestMemoryDedupStore(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := webhook.NewMemoryDedupStore(webhook.WithDedupClock(func() time.Time { return now }))
+	ctx := context.Background()
+
+	if seen, _ := s.MarkSeen(ctx, "a", time.Hour); seen {
+		t.Errorf("a should not have been seen")
+	}
+	if seen, _ := s.MarkSeen(ctx, "a", time.Hour); !seen {
+		t.Errorf("a should have been seen")
+	}
+
+	now = now.Add(time.Hour)
+	if seen, _ := s.MarkSeen(ctx, "a", time.Hour); seen {
+		t.Errorf("a should have expired")
+	}
+
+	if err := s.Forget(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if seen, _ := s.MarkSeen(ctx, "a", time.Hour); seen {
+		t.Errorf("a should have been forgotten")
+	}
+}
+
+type failingDedupStore struct{}
+
+func (failingDedupStore) MarkSeen(ctx context.Context, id string, ttl time.Duration) (bool, error) {
+	return false, errors.New("unavailable")
+}
+
+func (failingDedupStore) Forget(ctx context.Context, id string) error {
+	return errors.New("unavailable")
+}
+
+func TestDedup(t *testing.T) {
+	type report struct {
+		id   string
+		seen bool
+	}
+	var handled []string
+	var reports []report
+	d := webhook.NewDispatcher()
+	d.Use(webhook.Dedup(webhook.NewMemoryDedupStore(), webhook.WithRedeliveryHook(func(ctx context.Context, event webhook.EventInterface, seen bool) {
+		reports = append(reports, report{webhook.GetWebhookEventId(event), seen})
+	})))
+	d.Fallback(func(ctx context.Context, event webhook.EventInterface) error {
+		handled = append(handled, webhook.GetWebhookEventId(event))
+		return nil
+	})
+
+	events := []webhook.EventInterface{
+		webhook.FollowEvent{WebhookEventId: "1", DeliveryContext: &webhook.DeliveryContext{}},
+		webhook.FollowEvent{WebhookEventId: "1", DeliveryContext: &webhook.DeliveryContext{IsRedelivery: true}},
+		webhook.FollowEvent{WebhookEventId: "2", DeliveryContext: &webhook.DeliveryContext{IsRedelivery: true}},
+		webhook.UnknownEvent{Type: "unknown"},
+		webhook.UnknownEvent{Type: "unknown"},
+		webhook.UnknownEvent{Type: "unknown", Raw: map[string]json.RawMessage{"webhookEventId": json.RawMessage(`"3"`)}},
+		webhook.UnknownEvent{Type: "unknown", Raw: map[string]json.RawMessage{"webhookEventId": json.RawMessage(`"3"`)}},
+	}
+	for _, event := range events {
+		if err := d.Dispatch(context.Background(), event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(handled) != 5 || handled[0] != "1" || handled[1] != "2" || handled[4] != "3" {
+		t.Errorf("unexpected handled events: %q", handled)
+	}
+	if len(reports) != 2 || reports[0] != (report{"1", true}) || reports[1] != (report{"2", false}) {
+		t.Errorf("unexpected redelivery reports: %v", reports)
+	}
+}
+
+func TestDedupHandlerError(t *testing.T) {
+	failed := errors.New("failed")
+	var calls int
+	h := webhook.Dedup(webhook.NewMemoryDedupStore())(func(ctx context.Context, event webhook.EventInterface) error {
+		calls++
+		if calls == 1 {
+			return failed
+		}
+		return nil
+	})
+
+	event := webhook.FollowEvent{WebhookEventId: "1"}
+	if err := h(context.Background(), event); !errors.Is(err, failed) {
+		t.Errorf("got %v, want the handler error", err)
+	}
+	// The event failed, so its redelivery is handled again, but only once.
+	for i := 0; i < 2; i++ {
+		if err := h(context.Background(), event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("got %d calls, want the failed event handled again once", calls)
+	}
+}
+
+func TestDedupStoreError(t *testing.T) {
+	called := false
+	h := webhook.Dedup(failingDedupStore{})(func(ctx context.Context, event webhook.EventInterface) error {
+		called = true
+		return nil
+	})
+	if err := h(context.Background(), webhook.FollowEvent{WebhookEventId: "1"}); err == nil {
+		t.Errorf("expected the store error")
+	}
+	if !called {
+		t.Errorf("the event should be handled when the store fails")
+	}
+}
@@ -0,0 +1,213 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/webhook"
+)
+
+const dispatcherCallback = `{
+	"destination": "Uaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+	"events": [
+		{
+			"type": "message",
+			"mode": "active",
+			"timestamp": 1462629479859,
+			"webhookEventId": "01FZ74A0TDDPYRVKNK77XKC3ZR",
+			"deliveryContext": {"isRedelivery": false},
+			"replyToken": "token-text",
+			"source": {"type": "user", "userId": "U1"},
+			"message": {"id": "1", "type": "text", "quoteToken": "q", "text": "hello"}
+		},
+		{
+			"type": "message",
+			"mode": "active",
+			"timestamp": 1462629479859,
+			"webhookEventId": "01FZ74A0TDDPYRVKNK77XKC3ZS",
+			"deliveryContext": {"isRedelivery": false},
+			"replyToken": "token-sticker",
+			"source": {"type": "group", "groupId": "C1", "userId": "U2"},
+			"message": {"id": "2", "type": "sticker", "quoteToken": "q", "packageId": "1", "stickerId": "2", "stickerResourceType": "STATIC"}
+		},
+		{
+			"type": "postback",
+			"mode": "active",
+			"timestamp": 1462629479859,
+			"webhookEventId": "01FZ74A0TDDPYRVKNK77XKC3ZT",
+			"deliveryContext": {"isRedelivery": true},
+			"replyToken": "token-postback",
+			"source": {"type": "room", "roomId": "R1"},
+			"postback": {"data": "action=buy"}
+		},
+		{
+			"type": "follow",
+			"mode": "active",
+			"timestamp": 1462629479859,
+			"webhookEventId": "01FZ74A0TDDPYRVKNK77XKC3ZU",
+			"deliveryContext": {"isRedelivery": false},
+			"replyToken": "token-follow",
+			"source": {"type": "user", "userId": "U3"},
+			"follow": {"isUnblocked": false}
+		},
+		{
+			"type": "brandNewEvent",
+			"timestamp": 1462629479859
+		}
+	]
+}`
+
+func parseDispatcherCallback(t *testing.T) *webhook.CallbackRequest {
+	var cb webhook.CallbackRequest
+	if err := json.Unmarshal([]byte(dispatcherCallback), &cb); err != nil {
+		t.Fatalf("Failed to unmarshal callback request: %v", err)
+	}
+	return &cb
+}
+
+func TestDispatcherRoutesByType(t *testing.T) {
+	cb := parseDispatcherCallback(t)
+
+	var got []string
+	d := webhook.NewDispatcher()
+	d.OnText(func(ctx context.Context, e *webhook.MessageEvent, m webhook.TextMessageContent) error {
+		got = append(got, "text:"+m.Text+":"+e.ReplyToken)
+		return nil
+	})
+	d.OnMessage(func(ctx context.Context, e *webhook.MessageEvent) error {
+		got = append(got, "message:"+e.ReplyToken)
+		return nil
+	})
+	d.OnPostback(func(ctx context.Context, e *webhook.PostbackEvent) error {
+		got = append(got, "postback:"+e.Postback.Data)
+		return nil
+	})
+	d.OnFollow(func(ctx context.Context, e *webhook.FollowEvent) error {
+		got = append(got, "follow:"+webhook.GetSourceId(e.Source))
+		return nil
+	})
+	d.OnUnknown(func(ctx context.Context, e *webhook.UnknownEvent) error {
+		got = append(got, "unknown:"+e.Type)
+		return nil
+	})
+
+	if err := d.DispatchAll(context.Background(), cb); err != nil {
+		t.Fatalf("DispatchAll: %v", err)
+	}
+	want := []string{
+		"text:hello:token-text",
+		"message:token-sticker",
+		"postback:action=buy",
+		"follow:U3",
+		"unknown:brandNewEvent",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDispatcherSourceFilter(t *testing.T) {
+	cb := parseDispatcherCallback(t)
+
+	var got []string
+	d := webhook.NewDispatcher()
+	d.OnMessage(func(ctx context.Context, e *webhook.MessageEvent) error {
+		got = append(got, "group:"+webhook.GetSourceId(e.Source))
+		return nil
+	}, webhook.FromGroup, webhook.FromRoom)
+	d.Fallback(func(ctx context.Context, e webhook.EventInterface) error {
+		got = append(got, "fallback:"+e.GetType())
+		return nil
+	})
+
+	if err := d.DispatchAll(context.Background(), cb); err != nil {
+		t.Fatalf("DispatchAll: %v", err)
+	}
+	want := []string{
+		"fallback:message",
+		"group:C1",
+		"fallback:postback",
+		"fallback:follow",
+		"fallback:brandNewEvent",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDispatcherMiddlewareAndErrors(t *testing.T) {
+	cb := parseDispatcherCallback(t)
+
+	errFailed := errors.New("failed")
+	var order []string
+	d := webhook.NewDispatcher()
+	d.Use(
+		func(next webhook.EventHandler) webhook.EventHandler {
+			return func(ctx context.Context, e webhook.EventInterface) error {
+				order = append(order, "outer")
+				return next(ctx, e)
+			}
+		},
+		func(next webhook.EventHandler) webhook.EventHandler {
+			return func(ctx context.Context, e webhook.EventInterface) error {
+				order = append(order, "inner")
+				return next(ctx, e)
+			}
+		},
+	)
+	d.OnPostback(func(ctx context.Context, e *webhook.PostbackEvent) error {
+		order = append(order, "handler")
+		if !webhook.GetDeliveryContext(e).IsRedelivery {
+			t.Errorf("expected redelivery")
+		}
+		return errFailed
+	})
+
+	err := d.Dispatch(context.Background(), cb.Events[2])
+	if !errors.Is(err, errFailed) {
+		t.Errorf("got %v, want %v", err, errFailed)
+	}
+	if strings.Join(order, ",") != "outer,inner,handler" {
+		t.Errorf("unexpected order: %v", order)
+	}
+
+	// Events without a handler still go through the middlewares.
+	order = nil
+	if err := d.Dispatch(context.Background(), cb.Events[0]); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if strings.Join(order, ",") != "outer,inner" {
+		t.Errorf("unexpected order: %v", order)
+	}
+
+	if !errors.Is(d.DispatchAll(context.Background(), cb), errFailed) {
+		t.Errorf("DispatchAll should return the handler error")
+	}
+}
+
+func TestDispatcherPointerEvents(t *testing.T) {
+	called := false
+	d := webhook.NewDispatcher()
+	d.OnText(func(ctx context.Context, e *webhook.MessageEvent, m webhook.TextMessageContent) error {
+		called = m.Text == "hi" && e.ReplyToken == "r"
+		return nil
+	}, webhook.FromUser)
+
+	event := &webhook.MessageEvent{
+		ReplyToken: "r",
+		Source:     &webhook.UserSource{UserId: "U1"},
+		Message:    &webhook.TextMessageContent{Text: "hi"},
+	}
+	if err := d.Dispatch(context.Background(), event); err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+	if !called {
+		t.Errorf("handler was not called")
+	}
+	if webhook.GetReplyToken(event) != "r" {
+		t.Errorf("unexpected reply token: %q", webhook.GetReplyToken(event))
+	}
+}
@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+	"github.com/line/line-bot-sdk-go/v8/linebot/webhook"
+)
+
+func TestMultiChannelHandler(t *testing.T) {
+	clientA, err := messaging_api.NewMessagingApiAPI("tokenA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	channels := webhook.ChannelMap{
+		"Ua": {Destination: "Ua", ChannelSecret: "secretA", Client: clientA},
+		"Ub": {Destination: "Ub", ChannelSecret: "secretB"},
+	}
+	handler, err := webhook.NewMultiChannelHandler(channels)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []*webhook.Channel
+	var errs []error
+	handler.HandleEvents(func(cb *webhook.CallbackRequest, r *http.Request) {
+		ch, ok := webhook.ChannelFromContext(r.Context())
+		if !ok {
+			t.Errorf("the request context has no channel")
+		}
+		got = append(got, ch)
+	})
+	handler.HandleError(func(err error, r *http.Request) {
+		errs = append(errs, err)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	bodyA := []byte(`{"destination":"Ua","events":[]}`)
+	bodyB := []byte(`{"destination":"Ub","events":[]}`)
+	bodyC := []byte(`{"destination":"Uc","events":[]}`)
+	for _, tc := range []struct {
+		body   []byte
+		secret string
+		status int
+	}{
+		{bodyA, "secretA", http.StatusOK},
+		{bodyB, "secretB", http.StatusOK},
+		{bodyB, "secretA", http.StatusBadRequest},
+		{bodyC, "secretA", http.StatusBadRequest},
+	} {
+		res, err := http.DefaultClient.Do(makeRequest(t, server.URL, tc.body, generateSignature(tc.secret, tc.body)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.StatusCode != tc.status {
+			t.Errorf("%s signed with %s: StatusCode = %d; want %d", tc.body, tc.secret, res.StatusCode, tc.status)
+		}
+	}
+
+	if len(got) != 2 || got[0].Client != clientA || got[1].Destination != "Ub" {
+		t.Errorf("unexpected channels: %v", got)
+	}
+	if len(errs) != 2 || !errors.Is(errs[0], webhook.ErrInvalidSignature) || !errors.Is(errs[1], webhook.ErrUnknownDestination) {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
+
+func TestMultiChannelHandlerAsync(t *testing.T) {
+	resolver := webhook.ChannelResolverFunc(func(ctx context.Context, destination string) (*webhook.Channel, error) {
+		return &webhook.Channel{Destination: destination, ChannelSecret: "secret-" + destination}, nil
+	})
+	handler, err := webhook.NewMultiChannelHandler(resolver)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	destinations := make(chan string, 1)
+	d := webhook.NewDispatcher()
+	d.OnFollow(func(ctx context.Context, e *webhook.FollowEvent) error {
+		ch, _ := webhook.ChannelFromContext(ctx)
+		destinations <- ch.Destination
+		return nil
+	})
+	pool := webhook.NewWorkerPool(d.Dispatch)
+	handler.HandleEventsAsync(pool)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	body := []byte(`{"destination":"Ux","events":[{"type":"follow","mode":"active","timestamp":1,"webhookEventId":"1",` +
+		`"deliveryContext":{"isRedelivery":false},"replyToken":"r","source":{"type":"user","userId":"U1"},"follow":{"isUnblocked":false}}]}`)
+	res, err := http.DefaultClient.Do(makeRequest(t, server.URL, body, generateSignature("secret-Ux", body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d; want %d", res.StatusCode, http.StatusOK)
+	}
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if destination := <-destinations; destination != "Ux" {
+		t.Errorf("got destination %q", destination)
+	}
+}
@@ -0,0 +1,282 @@
+package webhooktest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/webhook"
+)
+
+// The event constructors fill in the properties common to all the events:
+// an active mode, the current timestamp, a unique webhook event ID, a
+// delivery context that is not a redelivery, and a reply token for the
+// events that have one. The returned events can be modified before they
+// are sent.
+
+var ids atomic.Int64
+
+// nextId returns a unique numeric ID, like the IDs of messages.
+func nextId() string {
+	return strconv.FormatInt(100000000000000+ids.Add(1), 10)
+}
+
+// newWebhookEventId returns a unique ID in the ULID format of webhook event IDs.
+func newWebhookEventId() string {
+	return fmt.Sprintf("01TEST%020d", ids.Add(1))
+}
+
+func newToken() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// User function
+// Returns the source of an event from a one-on-one chat.
+func User(userId string) *webhook.UserSource {
+	return &webhook.UserSource{UserId: userId}
+}
+
+// Group function
+// Returns the source of an event from a group chat, sent by userId if it is not empty.
+func Group(groupId, userId string) *webhook.GroupSource {
+	return &webhook.GroupSource{GroupId: groupId, UserId: userId}
+}
+
+// Room function
+// Returns the source of an event from a multi-person chat, sent by userId if it is not empty.
+func Room(roomId, userId string) *webhook.RoomSource {
+	return &webhook.RoomSource{RoomId: roomId, UserId: userId}
+}
+
+// newEvent fills in the common properties of e.
+func newEvent[E any](e *E, source webhook.SourceInterface) *E {
+	v := reflect.ValueOf(e).Elem()
+	set := func(name string, value any) {
+		if f := v.FieldByName(name); f.IsValid() {
+			f.Set(reflect.ValueOf(value))
+		}
+	}
+	if source != nil {
+		set("Source", source)
+	}
+	set("Timestamp", time.Now().UnixMilli())
+	set("Mode", webhook.EventMode_ACTIVE)
+	set("WebhookEventId", newWebhookEventId())
+	set("DeliveryContext", &webhook.DeliveryContext{})
+	set("ReplyToken", newToken())
+	return e
+}
+
+// Redelivered function
+// Marks the event as redelivered, as the LINE Platform does when it resends a webhook that failed.
+func Redelivered[E webhook.EventInterface](e E) E {
+	if deliveryContext := webhook.GetDeliveryContext(e); deliveryContext != nil {
+		deliveryContext.IsRedelivery = true
+	}
+	return e
+}
+
+// Standby function
+// Sets the mode of the event to standby, as for a channel that is not the active one.
+// Events in standby mode have no reply token.
+func Standby[E webhook.EventInterface](e E) E {
+	v := reflect.ValueOf(e)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		if f := v.Elem().FieldByName("Mode"); f.IsValid() {
+			f.Set(reflect.ValueOf(webhook.EventMode_STANDBY))
+		}
+		if f := v.Elem().FieldByName("ReplyToken"); f.IsValid() {
+			f.SetString("")
+		}
+	}
+	return e
+}
+
+// Message function
+// Returns a message event with the content, such as a webhook.TextMessageContent.
+func Message(source webhook.SourceInterface, content webhook.MessageContentInterface) *webhook.MessageEvent {
+	return newEvent(&webhook.MessageEvent{Message: content}, source)
+}
+
+// Text function
+func Text(source webhook.SourceInterface, text string) *webhook.MessageEvent {
+	return Message(source, &webhook.TextMessageContent{Id: nextId(), Text: text, QuoteToken: newToken()})
+}
+
+// Image function
+// Returns an image message event, whose content is on the LINE Platform.
+func Image(source webhook.SourceInterface) *webhook.MessageEvent {
+	return Message(source, &webhook.ImageMessageContent{
+		Id:              nextId(),
+		ContentProvider: &webhook.ContentProvider{Type: webhook.ContentProviderTYPE_LINE},
+		QuoteToken:      newToken(),
+	})
+}
+
+// Video function
+func Video(source webhook.SourceInterface, duration time.Duration) *webhook.MessageEvent {
+	return Message(source, &webhook.VideoMessageContent{
+		Id:              nextId(),
+		Duration:        duration.Milliseconds(),
+		ContentProvider: &webhook.ContentProvider{Type: webhook.ContentProviderTYPE_LINE},
+		QuoteToken:      newToken(),
+	})
+}
+
+// Audio function
+func Audio(source webhook.SourceInterface, duration time.Duration) *webhook.MessageEvent {
+	return Message(source, &webhook.AudioMessageContent{
+		Id:              nextId(),
+		Duration:        duration.Milliseconds(),
+		ContentProvider: &webhook.ContentProvider{Type: webhook.ContentProviderTYPE_LINE},
+	})
+}
+
+// File function
+func File(source webhook.SourceInterface, fileName string, fileSize int32) *webhook.MessageEvent {
+	return Message(source, &webhook.FileMessageContent{Id: nextId(), FileName: fileName, FileSize: fileSize})
+}
+
+// Location function
+func Location(source webhook.SourceInterface, title, address string, latitude, longitude float64) *webhook.MessageEvent {
+	return Message(source, &webhook.LocationMessageContent{
+		Id:        nextId(),
+		Title:     title,
+		Address:   address,
+		Latitude:  latitude,
+		Longitude: longitude,
+	})
+}
+
+// Sticker function
+func Sticker(source webhook.SourceInterface, packageId, stickerId string) *webhook.MessageEvent {
+	return Message(source, &webhook.StickerMessageContent{
+		Id:                  nextId(),
+		PackageId:           packageId,
+		StickerId:           stickerId,
+		StickerResourceType: webhook.StickerMessageContentSTICKER_RESOURCE_TYPE_STATIC,
+		QuoteToken:          newToken(),
+	})
+}
+
+// MessageEdited function
+// Returns a message edited event with the new content of the message.
+func MessageEdited(source webhook.SourceInterface, content webhook.MessageContentInterface) *webhook.MessageEditedEvent {
+	return newEvent(&webhook.MessageEditedEvent{Message: content}, source)
+}
+
+// Unsend function
+func Unsend(source webhook.SourceInterface, messageId string) *webhook.UnsendEvent {
+	return newEvent(&webhook.UnsendEvent{Unsend: &webhook.UnsendDetail{MessageId: messageId}}, source)
+}
+
+// Follow function
+// Returns a follow event. isUnblocked tells whether the user unblocked the bot rather than added it as a friend.
+func Follow(source webhook.SourceInterface, isUnblocked bool) *webhook.FollowEvent {
+	return newEvent(&webhook.FollowEvent{Follow: &webhook.FollowDetail{IsUnblocked: isUnblocked}}, source)
+}
+
+// Unfollow function
+func Unfollow(source webhook.SourceInterface) *webhook.UnfollowEvent {
+	return newEvent(&webhook.UnfollowEvent{}, source)
+}
+
+// Join function
+func Join(source webhook.SourceInterface) *webhook.JoinEvent {
+	return newEvent(&webhook.JoinEvent{}, source)
+}
+
+// Leave function
+func Leave(source webhook.SourceInterface) *webhook.LeaveEvent {
+	return newEvent(&webhook.LeaveEvent{}, source)
+}
+
+// MemberJoined function
+func MemberJoined(source webhook.SourceInterface, userIds ...string) *webhook.MemberJoinedEvent {
+	return newEvent(&webhook.MemberJoinedEvent{Joined: &webhook.JoinedMembers{Members: members(userIds)}}, source)
+}
+
+// MemberLeft function
+func MemberLeft(source webhook.SourceInterface, userIds ...string) *webhook.MemberLeftEvent {
+	return newEvent(&webhook.MemberLeftEvent{Left: &webhook.LeftMembers{Members: members(userIds)}}, source)
+}
+
+func members(userIds []string) []webhook.UserSource {
+	members := make([]webhook.UserSource, len(userIds))
+	for i, userId := range userIds {
+		members[i] = webhook.UserSource{UserId: userId}
+	}
+	return members
+}
+
+// Postback function
+// Returns a postback event. params holds the date and time selected with a datetime picker action, if any.
+func Postback(source webhook.SourceInterface, data string, params map[string]string) *webhook.PostbackEvent {
+	return newEvent(&webhook.PostbackEvent{Postback: &webhook.PostbackContent{Data: data, Params: params}}, source)
+}
+
+// Beacon function
+func Beacon(source webhook.SourceInterface, hwid string, beaconType webhook.BeaconContentTYPE) *webhook.BeaconEvent {
+	return newEvent(&webhook.BeaconEvent{Beacon: &webhook.BeaconContent{Hwid: hwid, Type: beaconType}}, source)
+}
+
+// AccountLink function
+func AccountLink(source webhook.SourceInterface, result webhook.LinkContentRESULT, nonce string) *webhook.AccountLinkEvent {
+	return newEvent(&webhook.AccountLinkEvent{Link: &webhook.LinkContent{Result: result, Nonce: nonce}}, source)
+}
+
+// Membership function
+// Returns a membership event with content such as a webhook.JoinedMembershipContent.
+func Membership(source webhook.SourceInterface, content webhook.MembershipContentInterface) *webhook.MembershipEvent {
+	return newEvent(&webhook.MembershipEvent{Membership: content}, source)
+}
+
+// Module function
+// Returns a module event with content such as a webhook.AttachedModuleContent.
+func Module(source webhook.SourceInterface, content webhook.ModuleContentInterface) *webhook.ModuleEvent {
+	return newEvent(&webhook.ModuleEvent{Module: content}, source)
+}
+
+// Activated function
+// Returns an event sent when the chat control of the module channel is activated until expireAt.
+func Activated(source webhook.SourceInterface, expireAt time.Time) *webhook.ActivatedEvent {
+	return newEvent(&webhook.ActivatedEvent{ChatControl: &webhook.ChatControl{ExpireAt: expireAt.UnixMilli()}}, source)
+}
+
+// Deactivated function
+func Deactivated(source webhook.SourceInterface) *webhook.DeactivatedEvent {
+	return newEvent(&webhook.DeactivatedEvent{}, source)
+}
+
+// BotSuspended function
+func BotSuspended() *webhook.BotSuspendedEvent {
+	return newEvent(&webhook.BotSuspendedEvent{}, nil)
+}
+
+// BotResumed function
+func BotResumed() *webhook.BotResumedEvent {
+	return newEvent(&webhook.BotResumedEvent{}, nil)
+}
+
+// Things function
+// Returns a LINE Things event with content such as a webhook.LinkThingsContent.
+func Things(source webhook.SourceInterface, content webhook.ThingsContentInterface) *webhook.ThingsEvent {
+	return newEvent(&webhook.ThingsEvent{Things: content}, source)
+}
+
+// VideoPlayComplete function
+func VideoPlayComplete(source webhook.SourceInterface, trackingId string) *webhook.VideoPlayCompleteEvent {
+	return newEvent(&webhook.VideoPlayCompleteEvent{VideoPlayComplete: &webhook.VideoPlayComplete{TrackingId: trackingId}}, source)
+}
+
+// PnpDeliveryCompletion function
+// Returns an event sent when a LINE notification message was delivered to the user.
+func PnpDeliveryCompletion(source webhook.SourceInterface, data string) *webhook.PnpDeliveryCompletionEvent {
+	return newEvent(&webhook.PnpDeliveryCompletionEvent{Delivery: &webhook.PnpDelivery{Data: data}}, source)
+}
@@ -0,0 +1,128 @@
+// Package webhooktest provides utilities for testing bots with webhooks
+// built from typed events, signed like the webhooks of the LINE Platform.
+//
+//	replayer := webhooktest.NewReplayer(handler, webhooktest.WithChannel(botUserId, channelSecret))
+//	res, err := replayer.Send(botUserId, webhooktest.Text(webhooktest.User("U1234"), "hello"))
+package webhooktest
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/webhook"
+)
+
+// NewCallbackRequest function
+// Returns the webhook of the events for the bot whose user ID is destination.
+func NewCallbackRequest(destination string, events ...webhook.EventInterface) *webhook.CallbackRequest {
+	if events == nil {
+		events = []webhook.EventInterface{}
+	}
+	return &webhook.CallbackRequest{Destination: destination, Events: events}
+}
+
+// Marshal function
+// Returns the body of the webhook.
+func Marshal(cb *webhook.CallbackRequest) ([]byte, error) {
+	return json.Marshal(cb)
+}
+
+// Sign function
+// Returns the x-line-signature header of a webhook body for the channel secret.
+func Sign(channelSecret string, body []byte) string {
+	hash := hmac.New(sha256.New, []byte(channelSecret))
+	hash.Write(body)
+	return base64.StdEncoding.EncodeToString(hash.Sum(nil))
+}
+
+// NewRequest function
+// Returns a POST request to target with the webhook as its body, signed with the channel secret.
+// As with httptest.NewRequest, target is a path or an absolute URL.
+func NewRequest(channelSecret, target string, cb *webhook.CallbackRequest) (*http.Request, error) {
+	body, err := Marshal(cb)
+	if err != nil {
+		return nil, err
+	}
+	req := httptest.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("x-line-signature", Sign(channelSecret, body))
+	return req, nil
+}
+
+// Replayer sends webhooks to an http.Handler, such as a webhook.WebhookHandler
+// or a webhook.MultiChannelHandler, and returns its responses.
+type Replayer struct {
+	handler  http.Handler
+	target   string
+	channels map[string]string
+}
+
+// ReplayerOption type
+type ReplayerOption func(*Replayer)
+
+// WithChannel function
+// Signs the webhooks for the bot whose user ID is destination with the channel secret.
+// It can be given several times for the destinations of a webhook.MultiChannelHandler.
+func WithChannel(destination, channelSecret string) ReplayerOption {
+	return func(r *Replayer) {
+		r.channels[destination] = channelSecret
+	}
+}
+
+// WithTarget function
+// Sets the path or URL the webhooks are sent to. The default is /callback.
+func WithTarget(target string) ReplayerOption {
+	return func(r *Replayer) {
+		r.target = target
+	}
+}
+
+// NewReplayer function
+func NewReplayer(handler http.Handler, options ...ReplayerOption) *Replayer {
+	r := &Replayer{
+		handler:  handler,
+		target:   "/callback",
+		channels: map[string]string{},
+	}
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
+
+// Send method
+// Sends a webhook of the events to destination.
+func (r *Replayer) Send(destination string, events ...webhook.EventInterface) (*http.Response, error) {
+	return r.Replay(NewCallbackRequest(destination, events...))
+}
+
+// Redeliver method
+// Marks the events as redelivered and sends them again to destination.
+func (r *Replayer) Redeliver(destination string, events ...webhook.EventInterface) (*http.Response, error) {
+	for _, e := range events {
+		Redelivered(e)
+	}
+	return r.Send(destination, events...)
+}
+
+// Replay method
+// Signs the webhook with the channel secret of its destination and sends it.
+func (r *Replayer) Replay(cb *webhook.CallbackRequest) (*http.Response, error) {
+	channelSecret, ok := r.channels[cb.Destination]
+	if !ok {
+		return nil, fmt.Errorf("no channel secret for destination %q, add it with WithChannel", cb.Destination)
+	}
+	req, err := NewRequest(channelSecret, r.target, cb)
+	if err != nil {
+		return nil, err
+	}
+	recorder := httptest.NewRecorder()
+	r.handler.ServeHTTP(recorder, req)
+	return recorder.Result(), nil
+}
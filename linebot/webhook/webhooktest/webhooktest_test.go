@@ -0,0 +1,144 @@
+package webhooktest
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/webhook"
+)
+
+func TestSend(t *testing.T) {
+	user := User("U1")
+	group := Group("G1", "U1")
+	events := []webhook.EventInterface{
+		Text(user, "hello"),
+		Image(user),
+		Video(group, 10*time.Second),
+		Audio(user, time.Second),
+		File(user, "a.pdf", 100),
+		Location(Room("R1", "U1"), "LINE", "Tokyo", 35.6, 139.7),
+		Sticker(user, "1", "2"),
+		MessageEdited(user, &webhook.TextMessageContent{Id: "1", Text: "edited"}),
+		Unsend(user, "1"),
+		Follow(user, true),
+		Unfollow(user),
+		Join(group),
+		Leave(group),
+		MemberJoined(group, "U2", "U3"),
+		MemberLeft(group, "U2"),
+		Postback(user, "action=buy", map[string]string{"date": "2024-01-01"}),
+		Beacon(user, "d41d8cd98f", webhook.BeaconContentTYPE_ENTER),
+		AccountLink(user, webhook.LinkContentRESULT_OK, "nonce"),
+		Membership(user, &webhook.JoinedMembershipContent{MembershipId: 1}),
+		Module(user, &webhook.AttachedModuleContent{BotId: "U2", Scopes: []string{"message"}}),
+		Activated(user, time.UnixMilli(1700000000000)),
+		Deactivated(user),
+		BotSuspended(),
+		BotResumed(),
+		Things(user, &webhook.LinkThingsContent{DeviceId: "t1"}),
+		VideoPlayComplete(user, "track"),
+		PnpDeliveryCompletion(user, "data"),
+	}
+
+	var received *webhook.CallbackRequest
+	handler, err := webhook.NewWebhookHandler("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler.HandleEvents(func(cb *webhook.CallbackRequest, r *http.Request) {
+		received = cb
+	})
+	res, err := NewReplayer(handler, WithChannel("Ubot", "secret")).Send("Ubot", events...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusOK || received == nil {
+		t.Fatalf("got status %d", res.StatusCode)
+	}
+	if received.Destination != "Ubot" || len(received.Events) != len(events) {
+		t.Fatalf("got %s with %d events, want %d", received.Destination, len(received.Events), len(events))
+	}
+	for i, e := range received.Events {
+		if unknown, ok := e.(webhook.UnknownEvent); ok {
+			// The generated webhook.UnmarshalEvent does not know things events yet.
+			if unknown.Type != "things" {
+				t.Errorf("events[%d]: got an unknown event: %+v", i, e)
+			}
+			continue
+		}
+		if got, want := reflect.TypeOf(e).Name(), reflect.TypeOf(events[i]).Elem().Name(); got != want {
+			t.Errorf("events[%d]: got %s, want %s", i, got, want)
+		}
+		if webhook.GetWebhookEventId(e) != webhook.GetWebhookEventId(events[i]) {
+			t.Errorf("events[%d]: got webhook event ID %q, want %q", i, webhook.GetWebhookEventId(e), webhook.GetWebhookEventId(events[i]))
+		}
+	}
+
+	text := received.Events[0].(webhook.MessageEvent)
+	content, ok := text.Message.(webhook.TextMessageContent)
+	if !ok || content.Text != "hello" || text.ReplyToken == "" || text.Mode != webhook.EventMode_ACTIVE {
+		t.Errorf("unexpected text event: %+v", text)
+	}
+	if source, ok := text.Source.(webhook.UserSource); !ok || source.UserId != "U1" {
+		t.Errorf("unexpected source: %+v", text.Source)
+	}
+	if joined := received.Events[13].(webhook.MemberJoinedEvent); fmt.Sprint(joined.Joined.Members[1].UserId) != "U3" {
+		t.Errorf("unexpected members: %+v", joined.Joined)
+	}
+	if postback := received.Events[15].(webhook.PostbackEvent); postback.Postback.Params["date"] != "2024-01-01" {
+		t.Errorf("unexpected postback: %+v", postback.Postback)
+	}
+}
+
+func TestRedeliverToDestinations(t *testing.T) {
+	handler, err := webhook.NewMultiChannelHandler(webhook.ChannelMap{
+		"Ubot1": {Destination: "Ubot1", ChannelSecret: "secret1"},
+		"Ubot2": {Destination: "Ubot2", ChannelSecret: "secret2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	handler.HandleEvents(func(cb *webhook.CallbackRequest, r *http.Request) {
+		ch, _ := webhook.ChannelFromContext(r.Context())
+		for _, e := range cb.Events {
+			got = append(got, fmt.Sprintf("%s %t %s", ch.Destination, webhook.GetDeliveryContext(e).IsRedelivery, e.(webhook.MessageEvent).Mode))
+		}
+	})
+	replayer := NewReplayer(handler, WithChannel("Ubot1", "secret1"), WithChannel("Ubot2", "secret2"))
+
+	if _, err := replayer.Send("Ubot1", Text(User("U1"), "a")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := replayer.Redeliver("Ubot2", Standby(Text(User("U1"), "b"))); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(got) != "[Ubot1 false active Ubot2 true standby]" {
+		t.Errorf("got %v", got)
+	}
+
+	if _, err := replayer.Send("Ubot3"); err == nil {
+		t.Error("expected an error for an unknown destination")
+	}
+	res, err := NewReplayer(handler, WithChannel("Ubot1", "wrong")).Send("Ubot1")
+	if err != nil || res.StatusCode != http.StatusBadRequest {
+		t.Errorf("got %v, %v, want a 400 response for a wrong signature", res, err)
+	}
+}
+
+func TestNewRequest(t *testing.T) {
+	req, err := NewRequest("secret", "https://example.com/callback", NewCallbackRequest("Ubot"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cb, err := webhook.ParseRequest("secret", req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cb.Destination != "Ubot" || cb.Events == nil || len(cb.Events) != 0 {
+		t.Errorf("unexpected webhook: %+v", cb)
+	}
+}
@@ -0,0 +1,200 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
+	"github.com/line/line-bot-sdk-go/v8/linebot/webhook"
+)
+
+const (
+	// MaxTextLength is the maximum number of characters of the text of a text message.
+	MaxTextLength = 5000
+	// MaxEmojis is the maximum number of LINE emojis in a text message.
+	MaxEmojis = 20
+)
+
+// UTF16Len returns the length of s in UTF-16 code units, the unit of the
+// indexes and lengths of emojis and mentions.
+func UTF16Len(s string) int32 {
+	var n int32
+	for _, r := range s {
+		n += int32(utf16.RuneLen(r))
+	}
+	return n
+}
+
+// TextBuilder composes the text of a messaging_api.TextMessage from runs of
+// plain text and LINE emojis, and computes the UTF-16 indexes of the emojis.
+//
+//	msg, err := util.NewTextBuilder().
+//		Text("Price: $10 ").Emoji("5ac1bfd5040ab15980c9b435", "001").
+//		Message()
+type TextBuilder struct {
+	text   strings.Builder
+	length int32
+	emojis []messaging_api.Emoji
+}
+
+// NewTextBuilder function
+func NewTextBuilder() *TextBuilder {
+	return &TextBuilder{}
+}
+
+// Text method
+// Appends plain text. Dollar signs in it are displayed as is.
+func (b *TextBuilder) Text(text string) *TextBuilder {
+	b.text.WriteString(text)
+	b.length += UTF16Len(text)
+	return b
+}
+
+// Dollar method
+// Appends a literal dollar sign.
+func (b *TextBuilder) Dollar() *TextBuilder {
+	return b.Text("$")
+}
+
+// Emoji method
+// Appends a LINE emoji.
+func (b *TextBuilder) Emoji(productId, emojiId string) *TextBuilder {
+	b.emojis = append(b.emojis, messaging_api.Emoji{
+		Index:     b.length,
+		ProductId: productId,
+		EmojiId:   emojiId,
+	})
+	return b.Text("$")
+}
+
+// String returns the text.
+func (b *TextBuilder) String() string {
+	return b.text.String()
+}
+
+// Emojis returns the emojis, with the indexes of their dollar signs in the text.
+func (b *TextBuilder) Emojis() []messaging_api.Emoji {
+	return slices.Clone(b.emojis)
+}
+
+// Message returns a text message with the text and the emojis, or an error if
+// the text is empty or exceeds the limits of a text message.
+func (b *TextBuilder) Message() (*messaging_api.TextMessage, error) {
+	text := b.text.String()
+	if n := utf8.RuneCountInString(text); n == 0 || n > MaxTextLength {
+		return nil, fmt.Errorf("text must have 1 to %d characters, got %d", MaxTextLength, n)
+	}
+	if len(b.emojis) > MaxEmojis {
+		return nil, fmt.Errorf("text must have at most %d emojis, got %d", MaxEmojis, len(b.emojis))
+	}
+	for _, e := range b.emojis {
+		if e.ProductId == "" || e.EmojiId == "" {
+			return nil, fmt.Errorf("the emoji at %d must have a product ID and an emoji ID", e.Index)
+		}
+	}
+	return &messaging_api.TextMessage{Text: text, Emojis: b.Emojis()}, nil
+}
+
+// Segment is a part of the text of a received text message: plain text, a
+// LINE emoji or a mention.
+type Segment struct {
+	// Text is the text of the segment, such as "(love)" for an emoji or "@Brown" for a mention.
+	Text string
+	// Index is the position of the segment in the text, in UTF-16 code units.
+	Index int32
+	// Length is the length of the segment, in UTF-16 code units.
+	Length int32
+	// Emoji is set if the segment is a LINE emoji.
+	Emoji *webhook.Emoji
+	// Mentionee is set if the segment is a mention, such as a webhook.UserMentionee.
+	Mentionee webhook.MentioneeInterface
+}
+
+// SplitTextMessageContent splits the text of a received text message into
+// segments of plain text, emojis and mentions, in order. It returns an
+// error if the emojis and mentions overlap or are outside the text.
+func SplitTextMessageContent(content *webhook.TextMessageContent) ([]Segment, error) {
+	var spans []Segment
+	for i := range content.Emojis {
+		e := &content.Emojis[i]
+		spans = append(spans, Segment{Index: e.Index, Length: e.Length, Emoji: e})
+	}
+	if content.Mention != nil {
+		for _, m := range content.Mention.Mentionees {
+			index, length, err := mentioneeSpan(m)
+			if err != nil {
+				return nil, err
+			}
+			spans = append(spans, Segment{Index: index, Length: length, Mentionee: m})
+		}
+	}
+	slices.SortStableFunc(spans, func(a, b Segment) int {
+		return int(a.Index - b.Index)
+	})
+
+	units := utf16.Encode([]rune(content.Text))
+	var segments []Segment
+	var pos int32
+	for _, span := range spans {
+		end := span.Index + span.Length
+		switch {
+		case span.Index < pos:
+			return nil, fmt.Errorf("the span at %d overlaps the previous one", span.Index)
+		case span.Length <= 0 || int(end) > len(units):
+			return nil, fmt.Errorf("the span at %d with length %d is outside the text of length %d", span.Index, span.Length, len(units))
+		case splitsSurrogatePair(units, span.Index) || splitsSurrogatePair(units, end):
+			return nil, fmt.Errorf("the span at %d with length %d splits a surrogate pair", span.Index, span.Length)
+		}
+		if span.Index > pos {
+			segments = append(segments, Segment{Text: decode(units[pos:span.Index]), Index: pos, Length: span.Index - pos})
+		}
+		span.Text = decode(units[span.Index:end])
+		segments = append(segments, span)
+		pos = end
+	}
+	if int(pos) < len(units) {
+		segments = append(segments, Segment{Text: decode(units[pos:]), Index: pos, Length: int32(len(units)) - pos})
+	}
+	return segments, nil
+}
+
+func decode(units []uint16) string {
+	return string(utf16.Decode(units))
+}
+
+// splitsSurrogatePair reports whether the index i is between the two halves of a surrogate pair.
+func splitsSurrogatePair(units []uint16, i int32) bool {
+	if i <= 0 || int(i) >= len(units) {
+		return false
+	}
+	high, low := units[i-1], units[i]
+	return 0xd800 <= high && high < 0xdc00 && 0xdc00 <= low && low < 0xe000
+}
+
+// mentioneeSpan returns the index and length of a mentionee.
+func mentioneeSpan(m webhook.MentioneeInterface) (int32, int32, error) {
+	switch m := m.(type) {
+	case webhook.UserMentionee:
+		return m.Index, m.Length, nil
+	case *webhook.UserMentionee:
+		return m.Index, m.Length, nil
+	case webhook.AllMentionee:
+		return m.Index, m.Length, nil
+	case *webhook.AllMentionee:
+		return m.Index, m.Length, nil
+	case webhook.UnknownMentionee:
+		var index, length int32
+		if err := json.Unmarshal(m.Raw["index"], &index); err != nil {
+			return 0, 0, fmt.Errorf("cannot read the index of the %s mentionee: %w", m.Type, err)
+		}
+		if err := json.Unmarshal(m.Raw["length"], &length); err != nil {
+			return 0, 0, fmt.Errorf("cannot read the length of the %s mentionee: %w", m.Type, err)
+		}
+		return index, length, nil
+	}
+	return 0, 0, fmt.Errorf("unknown mentionee %T", m)
+}
@@ -0,0 +1,104 @@
+package util
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/webhook"
+)
+
+func TestTextBuilder(t *testing.T) {
+	msg, err := NewTextBuilder().
+		Text("🍰 costs ").Dollar().Text("5 ").
+		Emoji("5ac1bfd5040ab15980c9b435", "001").
+		Text(" 𩸽").
+		Emoji("5ac1bfd5040ab15980c9b435", "002").
+		Message()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Text != "🍰 costs $5 $ 𩸽$" {
+		t.Errorf("unexpected text: %q", msg.Text)
+	}
+	// 🍰 and 𩸽 are surrogate pairs.
+	if len(msg.Emojis) != 2 || msg.Emojis[0].Index != 12 || msg.Emojis[1].Index != 16 {
+		t.Fatalf("unexpected emojis: %+v", msg.Emojis)
+	}
+	indexes := FindDollarSignIndexInUTF16Text(msg.Text)
+	if indexes[1] != msg.Emojis[0].Index || indexes[2] != msg.Emojis[1].Index {
+		t.Errorf("indexes %v do not match the emojis %+v", indexes, msg.Emojis)
+	}
+
+	if _, err := NewTextBuilder().Message(); err == nil {
+		t.Errorf("empty text should be an error")
+	}
+	b := NewTextBuilder()
+	for i := 0; i < 21; i++ {
+		b.Emoji("p", "001")
+	}
+	if _, err := b.Message(); err == nil {
+		t.Errorf("21 emojis should be an error")
+	}
+}
+
+func TestSplitTextMessageContent(t *testing.T) {
+	var content webhook.TextMessageContent
+	err := json.Unmarshal([]byte(`{
+		"id": "1", "type": "text", "quoteToken": "q",
+		"text": "@Brown 🍰(love) hi @All",
+		"emojis": [{"index": 9, "length": 6, "productId": "p", "emojiId": "001"}],
+		"mention": {"mentionees": [
+			{"index": 19, "length": 4, "type": "all"},
+			{"index": 0, "length": 6, "type": "user", "userId": "U1"}
+		]}
+	}`), &content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	segments, err := SplitTextMessageContent(&content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		text          string
+		index, length int32
+		kind          string
+	}{
+		{"@Brown", 0, 6, "user"},
+		{" 🍰", 6, 3, ""},
+		{"(love)", 9, 6, "emoji"},
+		{" hi ", 15, 4, ""},
+		{"@All", 19, 4, "all"},
+	}
+	if len(segments) != len(want) {
+		t.Fatalf("got %+v", segments)
+	}
+	for i, s := range segments {
+		kind := ""
+		if s.Emoji != nil {
+			kind = "emoji"
+		} else if s.Mentionee != nil {
+			kind = s.Mentionee.GetType()
+		}
+		if s.Text != want[i].text || s.Index != want[i].index || s.Length != want[i].length || kind != want[i].kind {
+			t.Errorf("segment %d: got %+v, want %+v", i, s, want[i])
+		}
+	}
+}
+
+func TestSplitTextMessageContentErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content webhook.TextMessageContent
+	}{
+		{"outside", webhook.TextMessageContent{Text: "hi", Emojis: []webhook.Emoji{{Index: 1, Length: 2}}}},
+		{"overlap", webhook.TextMessageContent{Text: "hello", Emojis: []webhook.Emoji{{Index: 0, Length: 3}, {Index: 2, Length: 2}}}},
+		{"surrogate pair", webhook.TextMessageContent{Text: "🍰", Emojis: []webhook.Emoji{{Index: 1, Length: 1}}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := SplitTextMessageContent(&tc.content); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}